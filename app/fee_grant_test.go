@@ -0,0 +1,133 @@
+package app
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsign "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestWasmTxsWithFeeGranter(t *testing.T) {
+	granterKey, userKey := secp256k1.GenPrivKey(), secp256k1.GenPrivKey()
+	granter, user := sdk.AccAddress(granterKey.PubKey().Address()), sdk.AccAddress(userKey.PubKey().Address())
+	granterFunds := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1_000_000))
+	wasmApp := SetupWithGenesisAccounts(
+		[]authtypes.GenesisAccount{authtypes.NewBaseAccountWithAddress(granter), authtypes.NewBaseAccountWithAddress(user)},
+		banktypes.Balance{Address: granter.String(), Coins: granterFunds},
+	)
+	wasmCode, err := ioutil.ReadFile("../x/wasm/keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	// granter sponsors the fees of the user
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	grant, err := feegrant.NewMsgGrantAllowance(&feegrant.BasicAllowance{}, granter, user)
+	require.NoError(t, err)
+	deliverTxWithFeeGranter(t, wasmApp, []sdk.Msg{grant}, fee, nil, granterKey)
+
+	// when the user sends wasm txs with the fees paid by the granter
+	res := deliverTxWithFeeGranter(t, wasmApp, []sdk.Msg{&types.MsgStoreCode{
+		Sender:       user.String(),
+		WASMByteCode: wasmCode,
+	}}, fee, granter, userKey)
+	var storeResp types.MsgStoreCodeResponse
+	require.NoError(t, storeResp.Unmarshal(res.Data[0].Data))
+
+	beneficiary := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	initMsg, err := json.Marshal(map[string]string{"verifier": user.String(), "beneficiary": beneficiary.String()})
+	require.NoError(t, err)
+	res = deliverTxWithFeeGranter(t, wasmApp, []sdk.Msg{&types.MsgInstantiateContract{
+		Sender: user.String(),
+		CodeID: storeResp.CodeID,
+		Label:  "sponsored",
+		Msg:    initMsg,
+	}}, fee, granter, userKey)
+	var instResp types.MsgInstantiateContractResponse
+	require.NoError(t, instResp.Unmarshal(res.Data[0].Data))
+
+	deliverTxWithFeeGranter(t, wasmApp, []sdk.Msg{&types.MsgExecuteContract{
+		Sender:   user.String(),
+		Contract: instResp.Address,
+		Msg:      []byte(`{"release":{}}`),
+	}}, fee, granter, userKey)
+
+	// then all fees were paid by the granter
+	ctx := wasmApp.BaseApp.NewContext(true, tmproto.Header{})
+	expGranterBalance := granterFunds.Sub(sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 4*100)))
+	assert.Equal(t, expGranterBalance, wasmApp.bankKeeper.GetAllBalances(ctx, granter))
+	assert.Empty(t, wasmApp.bankKeeper.GetAllBalances(ctx, user))
+}
+
+// deliverTxWithFeeGranter signs the messages with the single key and delivers them in a new block.
+// The fees are paid by the fee granter when set.
+func deliverTxWithFeeGranter(t *testing.T, wasmApp *WasmApp, msgs []sdk.Msg, fee sdk.Coins, feeGranter sdk.AccAddress, key cryptotypes.PrivKey) *sdk.TxMsgData {
+	t.Helper()
+	checkCtx := wasmApp.BaseApp.NewContext(true, tmproto.Header{})
+	acc := wasmApp.accountKeeper.GetAccount(checkCtx, sdk.AccAddress(key.PubKey().Address()))
+	require.NotNil(t, acc)
+
+	txCfg := MakeEncodingConfig().TxConfig
+	tx, err := signTx(txCfg, msgs, fee, feeGranter, acc.GetAccountNumber(), acc.GetSequence(), key)
+	require.NoError(t, err)
+
+	header := tmproto.Header{Height: wasmApp.LastBlockHeight() + 1, Time: time.Now()}
+	wasmApp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	_, res, err := wasmApp.Deliver(txCfg.TxEncoder(), tx)
+	require.NoError(t, err)
+	wasmApp.EndBlock(abci.RequestEndBlock{})
+	wasmApp.Commit()
+
+	var msgData sdk.TxMsgData
+	require.NoError(t, msgData.Unmarshal(res.Data))
+	return &msgData
+}
+
+func signTx(txCfg client.TxConfig, msgs []sdk.Msg, fee sdk.Coins, feeGranter sdk.AccAddress, accNum, accSeq uint64, key cryptotypes.PrivKey) (sdk.Tx, error) {
+	signMode := txCfg.SignModeHandler().DefaultMode()
+	sig := signing.SignatureV2{
+		PubKey:   key.PubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: signMode},
+		Sequence: accSeq,
+	}
+	builder := txCfg.NewTxBuilder()
+	if err := builder.SetMsgs(msgs...); err != nil {
+		return nil, err
+	}
+	// set the signer info before signing
+	if err := builder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	builder.SetFeeAmount(fee)
+	builder.SetFeeGranter(feeGranter)
+	builder.SetGasLimit(10 * DefaultGas)
+
+	signerData := authsign.SignerData{ChainID: "", AccountNumber: accNum, Sequence: accSeq}
+	signBytes, err := txCfg.SignModeHandler().GetSignBytes(signMode, signerData, builder.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	sigBytes, err := key.Sign(signBytes)
+	if err != nil {
+		return nil, err
+	}
+	sig.Data.(*signing.SingleSignatureData).Signature = sigBytes
+	if err := builder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	return builder.GetTx(), nil
+}
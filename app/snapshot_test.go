@@ -0,0 +1,134 @@
+package app
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/snapshots"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/CosmWasm/wasmd/x/wasm"
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestWasmSnapshotter(t *testing.T) {
+	const (
+		reflectWasm = "../x/wasm/keeper/testdata/reflect.wasm"
+		burnerWasm  = "../x/wasm/keeper/testdata/burner.wasm"
+	)
+	specs := map[string]struct {
+		wasmFiles  []string
+		pruneCodes []uint64
+	}{
+		"single contract": {
+			wasmFiles: []string{reflectWasm},
+		},
+		"multiple contract": {
+			wasmFiles: []string{reflectWasm, burnerWasm, reflectWasm},
+		},
+		"duplicate contracts": {
+			wasmFiles: []string{reflectWasm, reflectWasm},
+		},
+		"pruned code": {
+			wasmFiles:  []string{reflectWasm, burnerWasm},
+			pruneCodes: []uint64{2},
+		},
+		"pruned code with live duplicate": {
+			wasmFiles:  []string{burnerWasm, reflectWasm, reflectWasm},
+			pruneCodes: []uint64{2},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// setup source app
+			srcWasmApp := setupWithSnapshotStore(t)
+			stateBytes, err := json.Marshal(NewDefaultGenesisState())
+			require.NoError(t, err)
+			srcWasmApp.InitChain(abci.RequestInitChain{
+				Validators:      []abci.ValidatorUpdate{},
+				ConsensusParams: DefaultConsensusParams,
+				AppStateBytes:   stateBytes,
+			})
+			srcWasmApp.Commit()
+			header := tmproto.Header{
+				ChainID: "foo",
+				Height:  srcWasmApp.LastBlockHeight() + 1,
+				Time:    time.Now(),
+			}
+			srcWasmApp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+			// store wasm codes on chain
+			ctx := srcWasmApp.NewUncachedContext(false, header)
+			contractKeeper := wasmkeeper.NewDefaultPermissionKeeper(&srcWasmApp.wasmKeeper)
+			creator := sdk.AccAddress(make([]byte, types.SDKAddrLen))
+			srcCodeIDToWasm := make(map[uint64][]byte, len(spec.wasmFiles))
+			for i, v := range spec.wasmFiles {
+				wasmCode, err := ioutil.ReadFile(v)
+				require.NoError(t, err)
+				codeID, err := contractKeeper.Create(ctx, creator, wasmCode, nil)
+				require.NoError(t, err)
+				require.Equal(t, uint64(i+1), codeID)
+				srcCodeIDToWasm[codeID] = wasmCode
+			}
+			require.NoError(t, contractKeeper.PinCode(ctx, 1))
+			for _, codeID := range spec.pruneCodes {
+				require.NoError(t, contractKeeper.PruneCode(ctx, codeID))
+				srcCodeIDToWasm[codeID] = nil
+			}
+
+			// create snapshot
+			srcWasmApp.Commit()
+			snapshotHeight := uint64(srcWasmApp.LastBlockHeight())
+			snapshot, err := srcWasmApp.SnapshotManager().Create(snapshotHeight)
+			require.NoError(t, err)
+			assert.NotNil(t, snapshot)
+
+			// when snapshot imported into a new app
+			destWasmApp := setupWithSnapshotStore(t)
+			require.NoError(t, destWasmApp.SnapshotManager().Restore(*snapshot))
+			for i := uint32(0); i < snapshot.Chunks; i++ {
+				chunkBz, err := srcWasmApp.SnapshotManager().LoadChunk(snapshot.Height, snapshot.Format, i)
+				require.NoError(t, err)
+				end, err := destWasmApp.SnapshotManager().RestoreChunk(chunkBz)
+				require.NoError(t, err)
+				if end {
+					break
+				}
+			}
+
+			// then all wasm codes are restored
+			ctx = destWasmApp.NewUncachedContext(true, tmproto.Header{
+				ChainID: "foo",
+				Height:  int64(snapshotHeight) + 1,
+				Time:    time.Now(),
+			})
+			destCodeIDToWasm := make(map[uint64][]byte, len(spec.wasmFiles))
+			destWasmApp.wasmKeeper.IterateCodeInfos(ctx, func(id uint64, info types.CodeInfo) bool {
+				bz, err := destWasmApp.wasmKeeper.GetByteCode(ctx, id)
+				require.NoError(t, err)
+				destCodeIDToWasm[id] = bz
+				return false
+			})
+			assert.Equal(t, srcCodeIDToWasm, destCodeIDToWasm)
+			assert.True(t, destWasmApp.wasmKeeper.IsPinnedCode(ctx, 1))
+		})
+	}
+}
+
+func setupWithSnapshotStore(t *testing.T) *WasmApp {
+	homeDir := t.TempDir()
+	snapshotStore, err := snapshots.NewStore(dbm.NewMemDB(), filepath.Join(homeDir, "data", "snapshots"))
+	require.NoError(t, err)
+	return NewWasmApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, homeDir, 0, MakeEncodingConfig(), wasm.EnableAllProposals, EmptyBaseAppOptions{}, nil, bam.SetSnapshotStore(snapshotStore))
+}
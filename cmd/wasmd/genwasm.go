@@ -22,6 +22,8 @@ func AddGenesisWasmMsgCmd(defaultNodeHome string) *cobra.Command {
 		wasmcli.GenesisExecuteContractCmd(defaultNodeHome, genesisIO),
 		wasmcli.GenesisListContractsCmd(defaultNodeHome, genesisIO),
 		wasmcli.GenesisListCodesCmd(defaultNodeHome, genesisIO),
+		wasmcli.GenesisExtractCodesCmd(defaultNodeHome, genesisIO),
+		wasmcli.GenesisRestoreCodesCmd(defaultNodeHome, genesisIO),
 	)
 	return txCmd
 
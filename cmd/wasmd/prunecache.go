@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+
+	"github.com/CosmWasm/wasmd/app"
+	"github.com/CosmWasm/wasmd/app/params"
+	"github.com/CosmWasm/wasmd/x/wasm"
+)
+
+// PruneWasmCacheCmd returns a command that removes the compiled modules of all codes that are neither pinned nor
+// used by any contract from the wasmvm file system cache. The wasm byte code is kept so that a module is compiled
+// again when the code is instantiated later. The node must be stopped.
+func PruneWasmCacheCmd(defaultNodeHome string, encodingConfig params.EncodingConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune-wasm-cache",
+		Short: "Remove compiled wasm modules of codes without contracts from the cache",
+		Long: `Remove compiled wasm modules of codes that are neither pinned nor used by any contract
+from the wasmvm file system cache. The wasm byte code is kept so that a module is compiled again on
+demand. The node must be stopped while the command runs.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			homeDir := serverCtx.Config.RootDir
+
+			db, err := sdk.NewLevelDB("application", filepath.Join(homeDir, "data"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			var emptyWasmOpts []wasm.Option
+			wasmApp := app.NewWasmApp(
+				serverCtx.Logger,
+				db,
+				nil,
+				true,
+				map[int64]bool{},
+				homeDir,
+				cast.ToUint(serverCtx.Viper.Get(server.FlagInvCheckPeriod)),
+				encodingConfig,
+				app.GetEnabledProposals(),
+				serverCtx.Viper,
+				emptyWasmOpts,
+			)
+
+			removed, err := pruneCompiledModules(filepath.Join(homeDir, "wasm", "wasm", "cache", "modules"), wasmApp.WasmChecksumsInUse())
+			if err != nil {
+				return err
+			}
+			cmd.Printf("removed %d compiled modules\n", removed)
+			return nil
+		},
+	}
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	return cmd
+}
+
+// pruneCompiledModules removes all compiled modules from the cache directory that do not belong to one of the given
+// checksums. The modules are stored in one sub directory per module format and are named by the hex encoded checksum.
+// It returns the number of removed files.
+func pruneCompiledModules(modulesDir string, keep [][]byte) (int, error) {
+	keepNames := make(map[string]bool, len(keep))
+	for _, c := range keep {
+		keepNames[hex.EncodeToString(c)] = true
+	}
+	versionDirs, err := ioutil.ReadDir(modulesDir)
+	switch {
+	case os.IsNotExist(err):
+		return 0, nil
+	case err != nil:
+		return 0, err
+	}
+	var removed int
+	for _, versionDir := range versionDirs {
+		if !versionDir.IsDir() {
+			continue
+		}
+		dir := filepath.Join(modulesDir, versionDir.Name())
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return removed, err
+		}
+		for _, f := range files {
+			if f.IsDir() || keepNames[f.Name()] || !isChecksumName(f.Name()) {
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, f.Name())); err != nil {
+				return removed, fmt.Errorf("remove compiled module %s: %w", f.Name(), err)
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// isChecksumName returns true when the name is a hex encoded sha256 checksum
+func isChecksumName(name string) bool {
+	bz, err := hex.DecodeString(name)
+	return err == nil && len(bz) == 32
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneCompiledModules(t *testing.T) {
+	usedChecksum := bytes.Repeat([]byte{1}, 32)
+	unusedChecksum := bytes.Repeat([]byte{2}, 32)
+	modulesDir := t.TempDir()
+	versionDir := filepath.Join(modulesDir, "v3-wasmer1")
+	require.NoError(t, os.Mkdir(versionDir, 0o700))
+	for _, name := range []string{hex.EncodeToString(usedChecksum), hex.EncodeToString(unusedChecksum), "other-file"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(versionDir, name), []byte("compiled"), 0o600))
+	}
+
+	// when
+	removed, err := pruneCompiledModules(modulesDir, [][]byte{usedChecksum})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	files, err := ioutil.ReadDir(versionDir)
+	require.NoError(t, err)
+	var gotNames []string
+	for _, f := range files {
+		gotNames = append(gotNames, f.Name())
+	}
+	assert.Equal(t, []string{hex.EncodeToString(usedChecksum), "other-file"}, gotNames)
+}
+
+func TestPruneCompiledModulesWithoutCacheDir(t *testing.T) {
+	removed, err := pruneCompiledModules(filepath.Join(t.TempDir(), "not-existing"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, removed)
+}
@@ -3,10 +3,15 @@ package cli
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 
@@ -22,6 +27,7 @@ import (
 	"github.com/spf13/cobra"
 	tmtypes "github.com/tendermint/tendermint/types"
 
+	"github.com/CosmWasm/wasmd/x/wasm/client/utils"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
@@ -57,6 +63,9 @@ func GenesisStoreCodeCmd(defaultNodeHome string, genesisMutator GenesisMutator)
 			if err != nil {
 				return err
 			}
+			if msg.Source, msg.Builder, err = parseCodeSourceFlags(cmd.Flags()); err != nil {
+				return err
+			}
 			if err = msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -73,6 +82,7 @@ func GenesisStoreCodeCmd(defaultNodeHome string, genesisMutator GenesisMutator)
 	cmd.Flags().String(flagInstantiateByEverybody, "", "Everybody can instantiate a contract from the code, optional")
 	cmd.Flags().String(flagInstantiateNobody, "", "Nobody except the governance process can instantiate a contract from the code, optional")
 	cmd.Flags().String(flagInstantiateByAddress, "", "Only this address can instantiate a contract instance from the code, optional")
+	addCodeSourceFlags(cmd)
 
 	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
 	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test)")
@@ -140,6 +150,7 @@ func GenesisInstantiateContractCmd(defaultNodeHome string, genesisMutator Genesi
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
 	cmd.Flags().String(flagAdmin, "", "Address of an admin")
 	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
+	cmd.Flags().Bool(flagAdminCreator, false, "Set the run-as address as admin of the contract")
 	cmd.Flags().String(flagRunAs, "", "The address that pays the init funds. It is the creator of the contract.")
 
 	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
@@ -179,8 +190,12 @@ func GenesisExecuteContractCmd(defaultNodeHome string, genesisMutator GenesisMut
 				}
 
 				// - does contract address exists?
-				if !hasContract(state, msg.Contract) {
-					return fmt.Errorf("unknown contract: %state", msg.Contract)
+				exists, err := hasContract(state, msg.Contract)
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return fmt.Errorf("unknown contract: %s", msg.Contract)
 				}
 				state.GenMsgs = append(state.GenMsgs, types.GenesisState_GenMsgs{
 					Sum: &types.GenesisState_GenMsgs_ExecuteContract{ExecuteContract: &msg},
@@ -202,7 +217,7 @@ func GenesisExecuteContractCmd(defaultNodeHome string, genesisMutator GenesisMut
 // as well as from messages that are queued in the wasm.genMsgs section.
 func GenesisListCodesCmd(defaultNodeHome string, genReader GenesisReader) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "list-codes ",
+		Use:   "list-codes",
 		Short: "Lists all codes from genesis code dump and queued messages",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -227,7 +242,7 @@ func GenesisListCodesCmd(defaultNodeHome string, genReader GenesisReader) *cobra
 // as well as from messages that are queued in the wasm.genMsgs section.
 func GenesisListContractsCmd(defaultNodeHome string, genReader GenesisReader) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "list-contracts ",
+		Use:   "list-contracts",
 		Short: "Lists all contracts from genesis contract dump and queued messages",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -236,7 +251,10 @@ func GenesisListContractsCmd(defaultNodeHome string, genReader GenesisReader) *c
 				return err
 			}
 			state := g.WasmModuleState
-			all := GetAllContracts(state)
+			all, err := GetAllContracts(state)
+			if err != nil {
+				return err
+			}
 			return printJSONOutput(cmd, all)
 		},
 	}
@@ -245,6 +263,80 @@ func GenesisListContractsCmd(defaultNodeHome string, genReader GenesisReader) *c
 	return cmd
 }
 
+// GenesisExtractCodesCmd cli command to move the byte code of all codes in the genesis wasm.code section
+// into a directory. The files are named by the hex encoded code hash. This keeps the genesis file small and
+// reviewable. Use GenesisRestoreCodesCmd to put the byte code back before starting the chain.
+func GenesisExtractCodesCmd(defaultNodeHome string, genReader GenesisReader) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "extract-codes [directory]",
+		Short: "Moves the byte code of all genesis codes into files in the given directory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			g, err := genReader.ReadWasmGenesis(cmd)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(args[0], 0700); err != nil {
+				return err
+			}
+			for i, c := range g.WasmModuleState.Codes {
+				if len(c.CodeBytes) == 0 {
+					continue
+				}
+				if err := ioutil.WriteFile(codeFilename(args[0], c.CodeInfo.CodeHash), c.CodeBytes, 0600); err != nil {
+					return sdkerrors.Wrapf(err, "code id %d", c.CodeID)
+				}
+				g.WasmModuleState.Codes[i].CodeBytes = nil
+			}
+			return writeWasmGenesis(cmd, g)
+		},
+	}
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	return cmd
+}
+
+// GenesisRestoreCodesCmd cli command to read the byte code of all codes in the genesis wasm.code section
+// back from a directory that was written by GenesisExtractCodesCmd.
+func GenesisRestoreCodesCmd(defaultNodeHome string, genReader GenesisReader) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore-codes [directory]",
+		Short: "Reads the byte code of all genesis codes back from files in the given directory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			g, err := genReader.ReadWasmGenesis(cmd)
+			if err != nil {
+				return err
+			}
+			for i, c := range g.WasmModuleState.Codes {
+				if len(c.CodeBytes) != 0 {
+					continue
+				}
+				bz, err := ioutil.ReadFile(codeFilename(args[0], c.CodeInfo.CodeHash))
+				if err != nil {
+					return sdkerrors.Wrapf(err, "code id %d", c.CodeID)
+				}
+				// gzipped byte code is verified on import by the keeper
+				if len(bz) >= 4 && utils.IsWasm(bz) {
+					if checksum := sha256.Sum256(bz); !bytes.Equal(c.CodeInfo.CodeHash, checksum[:]) {
+						return fmt.Errorf("code id %d: code hash does not match file content", c.CodeID)
+					}
+				}
+				g.WasmModuleState.Codes[i].CodeBytes = bz
+			}
+			if err := g.WasmModuleState.ValidateBasic(); err != nil {
+				return err
+			}
+			return writeWasmGenesis(cmd, g)
+		},
+	}
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	return cmd
+}
+
+func codeFilename(dir string, codeHash []byte) string {
+	return filepath.Join(dir, hex.EncodeToString(codeHash)+".wasm")
+}
+
 // clientCtx marshaller works only with proto or bytes so we marshal the output ourself
 func printJSONOutput(cmd *cobra.Command, obj interface{}) error {
 	clientCtx := client.GetClientContextFromCmd(cmd)
@@ -258,6 +350,7 @@ func printJSONOutput(cmd *cobra.Command, obj interface{}) error {
 type CodeMeta struct {
 	CodeID uint64         `json:"code_id"`
 	Info   types.CodeInfo `json:"info"`
+	Pinned bool           `json:"pinned"`
 }
 
 func GetAllCodes(state *types.GenesisState) ([]CodeMeta, error) {
@@ -266,6 +359,7 @@ func GetAllCodes(state *types.GenesisState) ([]CodeMeta, error) {
 		all[i] = CodeMeta{
 			CodeID: c.CodeID,
 			Info:   c.CodeInfo,
+			Pinned: c.Pinned,
 		}
 	}
 	// add inflight
@@ -283,7 +377,12 @@ func GetAllCodes(state *types.GenesisState) ([]CodeMeta, error) {
 				}
 				accessConfig = state.Params.InstantiateDefaultPermission.With(creator)
 			}
-			hash := sha256.Sum256(msg.WASMByteCode)
+			// the checksum is calculated from the uncompressed code like in the keeper
+			wasmCode, err := uncompressGenesisCode(msg.WASMByteCode)
+			if err != nil {
+				return nil, fmt.Errorf("wasm byte code: %s", err)
+			}
+			hash := sha256.Sum256(wasmCode)
 			all = append(all, CodeMeta{
 				CodeID: seq,
 				Info: types.CodeInfo{
@@ -303,7 +402,9 @@ type ContractMeta struct {
 	Info            types.ContractInfo `json:"info"`
 }
 
-func GetAllContracts(state *types.GenesisState) []ContractMeta {
+// GetAllContracts returns all contracts from the genesis contract dump and queued messages.
+// Contracts instantiated by genesis messages get an address derived from the code checksum and label.
+func GetAllContracts(state *types.GenesisState) ([]ContractMeta, error) {
 	all := make([]ContractMeta, len(state.Contracts))
 	for i, c := range state.Contracts {
 		all[i] = ContractMeta{
@@ -312,22 +413,56 @@ func GetAllContracts(state *types.GenesisState) []ContractMeta {
 		}
 	}
 	// add inflight
-	seq := contractSeqValue(state)
+	var codes []CodeMeta
 	for _, m := range state.GenMsgs {
-		if msg := m.GetInstantiateContract(); msg != nil {
-			all = append(all, ContractMeta{
-				ContractAddress: keeper.BuildContractAddress(msg.CodeID, seq).String(),
-				Info: types.ContractInfo{
-					CodeID:  msg.CodeID,
-					Creator: msg.Sender,
-					Admin:   msg.Admin,
-					Label:   msg.Label,
-				},
-			})
-			seq++
+		msg := m.GetInstantiateContract()
+		if msg == nil {
+			continue
+		}
+		if codes == nil {
+			var err error
+			if codes, err = GetAllCodes(state); err != nil {
+				return nil, err
+			}
+		}
+		checksum, err := codeChecksum(codes, msg.CodeID)
+		if err != nil {
+			return nil, err
 		}
+		all = append(all, ContractMeta{
+			ContractAddress: keeper.BuildGenesisContractAddress(checksum, msg.Label).String(),
+			Info: types.ContractInfo{
+				CodeID:  msg.CodeID,
+				Creator: msg.Sender,
+				Admin:   msg.Admin,
+				Label:   msg.Label,
+			},
+		})
+	}
+	return all, nil
+}
+
+// codeChecksum returns the checksum for the code with the given id
+func codeChecksum(codes []CodeMeta, codeID uint64) ([]byte, error) {
+	for _, c := range codes {
+		if c.CodeID == codeID {
+			return c.Info.CodeHash, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown code id: %d", codeID)
+}
+
+// uncompressGenesisCode returns the wasm byte code uncompressed when gzipped
+func uncompressGenesisCode(wasmCode []byte) ([]byte, error) {
+	if !utils.IsGzip(wasmCode) {
+		return wasmCode, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(wasmCode))
+	if err != nil {
+		return nil, err
 	}
-	return all
+	defer zr.Close()
+	return ioutil.ReadAll(keeper.LimitReader(zr, int64(types.MaxWasmSize)))
 }
 
 func hasAccountBalance(cmd *cobra.Command, appState map[string]json.RawMessage, sender sdk.AccAddress, coins sdk.Coins) (bool, error) {
@@ -348,22 +483,17 @@ func hasAccountBalance(cmd *cobra.Command, appState map[string]json.RawMessage,
 	return true, nil
 }
 
-func hasContract(state *types.GenesisState, contractAddr string) bool {
-	for _, c := range state.Contracts {
-		if c.ContractAddress == contractAddr {
-			return true
-		}
+func hasContract(state *types.GenesisState, contractAddr string) (bool, error) {
+	all, err := GetAllContracts(state)
+	if err != nil {
+		return false, err
 	}
-	seq := contractSeqValue(state)
-	for _, m := range state.GenMsgs {
-		if msg := m.GetInstantiateContract(); msg != nil {
-			if keeper.BuildContractAddress(msg.CodeID, seq).String() == contractAddr {
-				return true
-			}
-			seq++
+	for _, c := range all {
+		if c.ContractAddress == contractAddr {
+			return true, nil
 		}
 	}
-	return false
+	return false, nil
 }
 
 // GenesisData contains raw and unmarshalled data from the genesis file
@@ -435,6 +565,11 @@ func (x DefaultGenesisIO) AlterWasmModuleState(cmd *cobra.Command, callback func
 	if err := g.WasmModuleState.ValidateBasic(); err != nil {
 		return err
 	}
+	return writeWasmGenesis(cmd, g)
+}
+
+// writeWasmGenesis marshals the wasm module state back into the genesis file
+func writeWasmGenesis(cmd *cobra.Command, g *GenesisData) error {
 	clientCtx := client.GetClientContextFromCmd(cmd)
 	wasmGenStateBz, err := clientCtx.Codec.MarshalJSON(g.WasmModuleState)
 	if err != nil {
@@ -451,19 +586,6 @@ func (x DefaultGenesisIO) AlterWasmModuleState(cmd *cobra.Command, callback func
 	return genutil.ExportGenesisFile(g.GenDoc, g.GenesisFile)
 }
 
-// contractSeqValue reads the contract sequence from the genesis or
-// returns default start value used in the keeper
-func contractSeqValue(state *types.GenesisState) uint64 {
-	var seq uint64 = 1
-	for _, s := range state.Sequences {
-		if bytes.Equal(s.IDKey, types.KeyLastInstanceID) {
-			seq = s.Value
-			break
-		}
-	}
-	return seq
-}
-
 // codeSeqValue reads the code sequence from the genesis or
 // returns default start value used in the keeper
 func codeSeqValue(state *types.GenesisState) uint64 {
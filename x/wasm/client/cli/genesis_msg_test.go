@@ -1,8 +1,13 @@
 package cli
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
@@ -262,6 +267,59 @@ func TestInstantiateContractCmd(t *testing.T) {
 			},
 			expError: true,
 		},
+		"all good with --admin-creator": {
+			srcGenesis: types.GenesisState{
+				Params: types.DefaultParams(),
+				Codes: []types.Code{
+					{
+						CodeID: 1,
+						CodeInfo: types.CodeInfo{
+							CodeHash: []byte("a-valid-code-hash"),
+							Creator:  keeper.RandomBech32AccountAddress(t),
+							InstantiateConfig: types.AccessConfig{
+								Permission: types.AccessTypeEverybody,
+							},
+						},
+						CodeBytes: wasmIdent,
+					},
+				},
+			},
+			mutator: func(cmd *cobra.Command) {
+				cmd.SetArgs([]string{"1", `{}`})
+				flagSet := cmd.Flags()
+				flagSet.Set("label", "testing")
+				flagSet.Set("run-as", myWellFundedAccount)
+				flagSet.Set("admin-creator", "true")
+			},
+			expMsgCount: 1,
+		},
+		"fails if both --admin-creator and --no-admin passed": {
+			srcGenesis: types.GenesisState{
+				Params: types.DefaultParams(),
+				Codes: []types.Code{
+					{
+						CodeID: 1,
+						CodeInfo: types.CodeInfo{
+							CodeHash: []byte("a-valid-code-hash"),
+							Creator:  keeper.RandomBech32AccountAddress(t),
+							InstantiateConfig: types.AccessConfig{
+								Permission: types.AccessTypeEverybody,
+							},
+						},
+						CodeBytes: wasmIdent,
+					},
+				},
+			},
+			mutator: func(cmd *cobra.Command) {
+				cmd.SetArgs([]string{"1", `{}`})
+				flagSet := cmd.Flags()
+				flagSet.Set("label", "testing")
+				flagSet.Set("run-as", myWellFundedAccount)
+				flagSet.Set("no-admin", "true")
+				flagSet.Set("admin-creator", "true")
+			},
+			expError: true,
+		},
 		"succeeds with unknown account when no funds": {
 			srcGenesis: types.GenesisState{
 				Params: types.DefaultParams(),
@@ -365,6 +423,7 @@ func TestInstantiateContractCmd(t *testing.T) {
 
 func TestExecuteContractCmd(t *testing.T) {
 	const firstContractAddress = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	genesisContractAddress := keeper.BuildGenesisContractAddress(types.CodeInfoFixture().CodeHash, types.MsgInstantiateContractFixture().Label).String()
 	minimalWasmGenesis := types.GenesisState{
 		Params: types.DefaultParams(),
 	}
@@ -406,7 +465,7 @@ func TestExecuteContractCmd(t *testing.T) {
 			},
 			expMsgCount: 1,
 		},
-		"all good with contract from genesis store messages without initial sequence": {
+		"all good with contract from genesis instantiate messages": {
 			srcGenesis: types.GenesisState{
 				Params: types.DefaultParams(),
 				Codes: []types.Code{
@@ -421,13 +480,13 @@ func TestExecuteContractCmd(t *testing.T) {
 				},
 			},
 			mutator: func(cmd *cobra.Command) {
-				cmd.SetArgs([]string{firstContractAddress, `{}`})
+				cmd.SetArgs([]string{genesisContractAddress, `{}`})
 				flagSet := cmd.Flags()
 				flagSet.Set("run-as", myWellFundedAccount)
 			},
 			expMsgCount: 2,
 		},
-		"all good with contract from genesis store messages and contract sequence set": {
+		"fails with sequence based address for contract from genesis instantiate messages": {
 			srcGenesis: types.GenesisState{
 				Params: types.DefaultParams(),
 				Codes: []types.Code{
@@ -440,17 +499,13 @@ func TestExecuteContractCmd(t *testing.T) {
 				GenMsgs: []types.GenesisState_GenMsgs{
 					{Sum: &types.GenesisState_GenMsgs_InstantiateContract{InstantiateContract: types.MsgInstantiateContractFixture()}},
 				},
-				Sequences: []types.Sequence{
-					{IDKey: types.KeyLastInstanceID, Value: 100},
-				},
 			},
 			mutator: func(cmd *cobra.Command) {
-				// See TestBuildContractAddress in keeper_test.go
-				cmd.SetArgs([]string{"cosmos1mujpjkwhut9yjw4xueyugc02evfv46y0dtmnz4lh8xxkkdapym9stu5qm8", `{}`})
+				cmd.SetArgs([]string{firstContractAddress, `{}`})
 				flagSet := cmd.Flags()
 				flagSet.Set("run-as", myWellFundedAccount)
 			},
-			expMsgCount: 2,
+			expError: true,
 		},
 		"fails with unknown contract address": {
 			srcGenesis: minimalWasmGenesis,
@@ -564,10 +619,73 @@ func TestExecuteContractCmd(t *testing.T) {
 		})
 	}
 }
-func TestGetAllContracts(t *testing.T) {
+func TestGetAllCodes(t *testing.T) {
+	wasmCode := append(wasmIdent, []byte("any content")...)
+	codeHash := sha256.Sum256(wasmCode)
+	myCodeInfo := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
+	creator := keeper.RandomBech32AccountAddress(t)
+
 	specs := map[string]struct {
 		src types.GenesisState
-		exp []ContractMeta
+		exp []CodeMeta
+	}{
+		"read from codes state": {
+			src: types.GenesisState{
+				Codes: []types.Code{
+					{CodeID: 1, CodeInfo: myCodeInfo, CodeBytes: wasmCode},
+					{CodeID: 2, CodeInfo: myCodeInfo, CodeBytes: wasmCode, Pinned: true},
+				},
+			},
+			exp: []CodeMeta{
+				{CodeID: 1, Info: myCodeInfo},
+				{CodeID: 2, Info: myCodeInfo, Pinned: true},
+			},
+		},
+		"read from message state with code sequence": {
+			src: types.GenesisState{
+				Params: types.DefaultParams(),
+				Sequences: []types.Sequence{
+					{IDKey: types.KeyLastCodeID, Value: 100},
+				},
+				GenMsgs: []types.GenesisState_GenMsgs{
+					{Sum: &types.GenesisState_GenMsgs_StoreCode{StoreCode: &types.MsgStoreCode{Sender: creator, WASMByteCode: wasmCode}}},
+				},
+			},
+			exp: []CodeMeta{
+				{
+					CodeID: 100,
+					Info: types.CodeInfo{
+						CodeHash:          codeHash[:],
+						Creator:           creator,
+						InstantiateConfig: types.AllowEverybody,
+					},
+				},
+			},
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, err := GetAllCodes(&spec.src)
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestGetAllContracts(t *testing.T) {
+	wasmCode := append(wasmIdent, []byte("any content")...)
+	codeInfo := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
+	creator := keeper.RandomBech32AccountAddress(t)
+	var gzippedCode bytes.Buffer
+	zw := gzip.NewWriter(&gzippedCode)
+	_, err := zw.Write(wasmCode)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	specs := map[string]struct {
+		src    types.GenesisState
+		exp    []ContractMeta
+		expErr bool
 	}{
 		"read from contracts state": {
 			src: types.GenesisState{
@@ -595,40 +713,44 @@ func TestGetAllContracts(t *testing.T) {
 		},
 		"read from message state": {
 			src: types.GenesisState{
+				Codes: []types.Code{{CodeID: 1, CodeInfo: codeInfo}},
 				GenMsgs: []types.GenesisState_GenMsgs{
-					{Sum: &types.GenesisState_GenMsgs_InstantiateContract{InstantiateContract: &types.MsgInstantiateContract{Label: "first"}}},
-					{Sum: &types.GenesisState_GenMsgs_InstantiateContract{InstantiateContract: &types.MsgInstantiateContract{Label: "second"}}},
+					{Sum: &types.GenesisState_GenMsgs_InstantiateContract{InstantiateContract: &types.MsgInstantiateContract{CodeID: 1, Label: "first"}}},
+					{Sum: &types.GenesisState_GenMsgs_InstantiateContract{InstantiateContract: &types.MsgInstantiateContract{CodeID: 1, Label: "second"}}},
 				},
 			},
 			exp: []ContractMeta{
 				{
-					ContractAddress: keeper.BuildContractAddress(0, 1).String(),
-					Info:            types.ContractInfo{Label: "first"},
+					ContractAddress: keeper.BuildGenesisContractAddress(codeInfo.CodeHash, "first").String(),
+					Info:            types.ContractInfo{CodeID: 1, Label: "first"},
 				},
 				{
-					ContractAddress: keeper.BuildContractAddress(0, 2).String(),
-					Info:            types.ContractInfo{Label: "second"},
+					ContractAddress: keeper.BuildGenesisContractAddress(codeInfo.CodeHash, "second").String(),
+					Info:            types.ContractInfo{CodeID: 1, Label: "second"},
 				},
 			},
 		},
-		"read from message state with contract sequence": {
+		"read from message state with gzipped code from message state": {
 			src: types.GenesisState{
+				Params: types.DefaultParams(),
 				Sequences: []types.Sequence{
-					{IDKey: types.KeyLastInstanceID, Value: 100},
+					{IDKey: types.KeyLastCodeID, Value: 100},
 				},
 				GenMsgs: []types.GenesisState_GenMsgs{
-					{Sum: &types.GenesisState_GenMsgs_InstantiateContract{InstantiateContract: &types.MsgInstantiateContract{Label: "hundred"}}},
+					{Sum: &types.GenesisState_GenMsgs_StoreCode{StoreCode: &types.MsgStoreCode{Sender: creator, WASMByteCode: gzippedCode.Bytes()}}},
+					{Sum: &types.GenesisState_GenMsgs_InstantiateContract{InstantiateContract: &types.MsgInstantiateContract{CodeID: 100, Label: "hundred"}}},
 				},
 			},
 			exp: []ContractMeta{
 				{
-					ContractAddress: keeper.BuildContractAddress(0, 100).String(),
-					Info:            types.ContractInfo{Label: "hundred"},
+					ContractAddress: keeper.BuildGenesisContractAddress(codeInfo.CodeHash, "hundred").String(),
+					Info:            types.ContractInfo{CodeID: 100, Label: "hundred"},
 				},
 			},
 		},
-		"read from contract and message state with contract sequence": {
+		"read from contract and message state": {
 			src: types.GenesisState{
+				Codes: []types.Code{{CodeID: 1, CodeInfo: codeInfo}},
 				Contracts: []types.Contract{
 					{
 						ContractAddress: "first-contract",
@@ -639,7 +761,7 @@ func TestGetAllContracts(t *testing.T) {
 					{IDKey: types.KeyLastInstanceID, Value: 100},
 				},
 				GenMsgs: []types.GenesisState_GenMsgs{
-					{Sum: &types.GenesisState_GenMsgs_InstantiateContract{InstantiateContract: &types.MsgInstantiateContract{Label: "hundred"}}},
+					{Sum: &types.GenesisState_GenMsgs_InstantiateContract{InstantiateContract: &types.MsgInstantiateContract{CodeID: 1, Label: "hundred"}}},
 				},
 			},
 			exp: []ContractMeta{
@@ -648,19 +770,139 @@ func TestGetAllContracts(t *testing.T) {
 					Info:            types.ContractInfo{Label: "first"},
 				},
 				{
-					ContractAddress: keeper.BuildContractAddress(0, 100).String(),
-					Info:            types.ContractInfo{Label: "hundred"},
+					ContractAddress: keeper.BuildGenesisContractAddress(codeInfo.CodeHash, "hundred").String(),
+					Info:            types.ContractInfo{CodeID: 1, Label: "hundred"},
 				},
 			},
 		},
+		"unknown code id in message state": {
+			src: types.GenesisState{
+				GenMsgs: []types.GenesisState_GenMsgs{
+					{Sum: &types.GenesisState_GenMsgs_InstantiateContract{InstantiateContract: &types.MsgInstantiateContract{CodeID: 1, Label: "first"}}},
+				},
+			},
+			expErr: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
-			got := GetAllContracts(&spec.src)
+			got, gotErr := GetAllContracts(&spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
 			assert.Equal(t, spec.exp, got)
 		})
 	}
+}
+
+func TestGenesisListCodesAndContractsCmd(t *testing.T) {
+	wasmCode := append(wasmIdent, []byte("any content")...)
+	codeInfo := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
+	contractAddr := keeper.BuildContractAddress(1, 1).String()
+	contractInfo := types.ContractInfoFixture()
+	homeDir := setupGenesis(t, types.GenesisState{
+		Params: types.DefaultParams(),
+		Codes: []types.Code{{
+			CodeID:    1,
+			CodeInfo:  codeInfo,
+			CodeBytes: wasmCode,
+			Pinned:    true,
+		}},
+		Contracts: []types.Contract{{
+			ContractAddress: contractAddr,
+			ContractInfo:    contractInfo,
+		}},
+		Sequences: []types.Sequence{
+			{IDKey: types.KeyLastCodeID, Value: 2},
+			{IDKey: types.KeyLastInstanceID, Value: 2},
+		},
+	})
+
+	// when codes listed
+	var out bytes.Buffer
+	cmd := GenesisListCodesCmd(homeDir, NewDefaultGenesisIO())
+	cmd.SetArgs([]string{})
+	require.NoError(t, executeCmdWithOutput(t, homeDir, cmd, &out))
+
+	// then
+	var gotCodes []CodeMeta
+	require.NoError(t, json.Unmarshal(out.Bytes(), &gotCodes))
+	assert.Equal(t, []CodeMeta{{CodeID: 1, Info: codeInfo, Pinned: true}}, gotCodes)
+
+	// and when contracts listed
+	out.Reset()
+	cmd = GenesisListContractsCmd(homeDir, NewDefaultGenesisIO())
+	cmd.SetArgs([]string{})
+	require.NoError(t, executeCmdWithOutput(t, homeDir, cmd, &out))
+
+	// then
+	var gotContracts []ContractMeta
+	require.NoError(t, json.Unmarshal(out.Bytes(), &gotContracts))
+	assert.Equal(t, []ContractMeta{{ContractAddress: contractAddr, Info: contractInfo}}, gotContracts)
+}
+
+func TestGenesisExtractAndRestoreCodesCmd(t *testing.T) {
+	wasmCode := append(wasmIdent, []byte("any content")...)
+	codeHash := sha256.Sum256(wasmCode)
+	codeInfo := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
+	srcGenesis := types.GenesisState{
+		Params: types.DefaultParams(),
+		Codes: []types.Code{{
+			CodeID:    1,
+			CodeInfo:  codeInfo,
+			CodeBytes: wasmCode,
+		}},
+		Sequences: []types.Sequence{
+			{IDKey: types.KeyLastCodeID, Value: 2},
+		},
+	}
+	homeDir := setupGenesis(t, srcGenesis)
+	codesDir := path.Join(t.TempDir(), "codes")
+
+	// when extracted
+	cmd := GenesisExtractCodesCmd(homeDir, NewDefaultGenesisIO())
+	cmd.SetArgs([]string{codesDir})
+	require.NoError(t, executeCmdWithContext(t, homeDir, cmd))
+
+	// then
+	moduleState := loadModuleState(t, homeDir)
+	require.Len(t, moduleState.Codes, 1)
+	assert.Empty(t, moduleState.Codes[0].CodeBytes)
+	gotFileContent, err := ioutil.ReadFile(path.Join(codesDir, hex.EncodeToString(codeHash[:])+".wasm"))
+	require.NoError(t, err)
+	assert.Equal(t, wasmCode, gotFileContent)
+
+	// and when restored
+	cmd = GenesisRestoreCodesCmd(homeDir, NewDefaultGenesisIO())
+	cmd.SetArgs([]string{codesDir})
+	require.NoError(t, executeCmdWithContext(t, homeDir, cmd))
+
+	// then
+	moduleState = loadModuleState(t, homeDir)
+	assert.Equal(t, srcGenesis.Codes, moduleState.Codes)
+}
 
+func TestGenesisRestoreCodesCmdRejectsModifiedCode(t *testing.T) {
+	wasmCode := append(wasmIdent, []byte("any content")...)
+	codeInfo := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
+	homeDir := setupGenesis(t, types.GenesisState{
+		Params: types.DefaultParams(),
+		Codes:  []types.Code{{CodeID: 1, CodeInfo: codeInfo}},
+	})
+	codesDir := t.TempDir()
+	otherCode := append(wasmIdent, []byte("other content")...)
+	require.NoError(t, ioutil.WriteFile(path.Join(codesDir, hex.EncodeToString(codeInfo.CodeHash)+".wasm"), otherCode, 0600))
+
+	// when
+	cmd := GenesisRestoreCodesCmd(homeDir, NewDefaultGenesisIO())
+	cmd.SetArgs([]string{codesDir})
+	err := executeCmdWithContext(t, homeDir, cmd)
+
+	// then
+	require.Error(t, err)
+	assert.Empty(t, loadModuleState(t, homeDir).Codes[0].CodeBytes)
 }
 
 func setupGenesis(t *testing.T, wasmGenesis types.GenesisState) string {
@@ -694,12 +936,18 @@ func setupGenesis(t *testing.T, wasmGenesis types.GenesisState) string {
 }
 
 func executeCmdWithContext(t *testing.T, homeDir string, cmd *cobra.Command) error {
+	return executeCmdWithOutput(t, homeDir, cmd, nil)
+}
+
+// executeCmdWithOutput executes the command like executeCmdWithContext but prints the client output
+// to the given writer. With a nil writer the output goes to stdout.
+func executeCmdWithOutput(t *testing.T, homeDir string, cmd *cobra.Command, out io.Writer) error {
 	logger := log.NewNopLogger()
 	cfg, err := genutiltest.CreateDefaultTendermintConfig(homeDir)
 	require.NoError(t, err)
 	appCodec := keeper.MakeEncodingConfig(t).Marshaler
 	serverCtx := server.NewContext(viper.New(), cfg, logger)
-	clientCtx := client.Context{}.WithCodec(appCodec).WithHomeDir(homeDir)
+	clientCtx := client.Context{}.WithCodec(appCodec).WithHomeDir(homeDir).WithOutput(out)
 
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, client.ClientContextKey, &clientCtx)
@@ -711,8 +959,10 @@ func executeCmdWithContext(t *testing.T, homeDir string, cmd *cobra.Command) err
 	mockIn := testutil.ApplyMockIODiscardOutErr(cmd)
 	kb, err := keyring.New(sdk.KeyringServiceName(), keyring.BackendTest, homeDir, mockIn)
 	require.NoError(t, err)
-	_, err = kb.NewAccount(defaultTestKeyName, testdata.TestMnemonic, "", sdk.FullFundraiserPath, hd.Secp256k1)
-	require.NoError(t, err)
+	if _, err := kb.Key(defaultTestKeyName); err != nil { // not created in a previous call
+		_, err = kb.NewAccount(defaultTestKeyName, testdata.TestMnemonic, "", sdk.FullFundraiserPath, hd.Secp256k1)
+		require.NoError(t, err)
+	}
 	return cmd.ExecuteContext(ctx)
 }
 
@@ -2,12 +2,16 @@ package cli
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -57,6 +61,50 @@ func parseMigrateContractArgs(args []string, cliCtx client.Context) (types.MsgMi
 	return msg, nil
 }
 
+// StoreAndMigrateContractCmd will upload code and migrate contracts to it in a single message.
+func StoreAndMigrateContractCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "store-migrate [wasm file] [json_encoded_migration_args] [contract_addr_bech32]...",
+		Short:   "Upload a wasm binary and migrate the given contracts to it",
+		Aliases: []string{"store-mig", "sm"},
+		Args:    cobra.MinimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg, err := parseStoreAndMigrateContractArgs(args, clientCtx.GetFromAddress(), cmd.Flags())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	cmd.Flags().String(flagInstantiateByEverybody, "", "Everybody can instantiate a contract from the code, optional")
+	cmd.Flags().String(flagInstantiateNobody, "", "Nobody except the governance process can instantiate a contract from the code, optional")
+	cmd.Flags().String(flagInstantiateByAddress, "", "Only this address can instantiate a contract instance from the code, optional")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func parseStoreAndMigrateContractArgs(args []string, sender sdk.AccAddress, flags *flag.FlagSet) (types.MsgStoreAndMigrateContract, error) {
+	storeMsg, err := parseStoreCodeArgs(args[0], sender, flags)
+	if err != nil {
+		return types.MsgStoreAndMigrateContract{}, err
+	}
+	return types.MsgStoreAndMigrateContract{
+		Sender:                sender.String(),
+		WASMByteCode:          storeMsg.WASMByteCode,
+		InstantiatePermission: storeMsg.InstantiatePermission,
+		Contracts:             args[2:],
+		Msg:                   []byte(args[1]),
+	}, nil
+}
+
 // UpdateContractAdminCmd sets an new admin for a contract
 func UpdateContractAdminCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -119,3 +167,277 @@ func ClearContractAdminCmd() *cobra.Command {
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
+
+// UpdateExecuteGasLimitCmd sets the max gas for a single execution of a contract
+func UpdateExecuteGasLimitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "set-execute-gas-limit [contract_addr_bech32] [gas_limit]",
+		Short:   "Set the max gas a single execution of a contract may consume, 0 removes the limit",
+		Aliases: []string{"execute-gas-limit"},
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			gasLimit, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(err, "gas limit")
+			}
+			msg := types.MsgUpdateExecuteGasLimit{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				GasLimit: gasLimit,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// DepositRentCmd adds funds to the state rent deposit of a contract
+func DepositRentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposit-rent [contract_addr_bech32] [amount]",
+		Short: "Add funds to the state rent deposit of a contract",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoinNormalized(args[1])
+			if err != nil {
+				return sdkerrors.Wrap(err, "amount")
+			}
+			msg := types.MsgDepositRent{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				Amount:   amount,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// UpdateContractOperatorCmd sets or clears the operator of a contract
+func UpdateContractOperatorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-contract-operator [contract_addr_bech32] [operator_addr_bech32,optional] --allow-sudo-msg [msg_keys,optional]",
+		Short: "Set the operator of a contract, clears the operator when no address is given",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			sudoMsgs, err := cmd.Flags().GetStringSlice(flagAllowSudoMsgs)
+			if err != nil {
+				return sdkerrors.Wrap(err, "allowed sudo msgs")
+			}
+			msg := types.MsgUpdateOperator{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				SudoMsgs: sudoMsgs,
+			}
+			if len(args) == 2 {
+				msg.Operator = args[1]
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	cmd.Flags().StringSlice(flagAllowSudoMsgs, []string{}, "Top level keys of the sudo messages the operator is allowed to send")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// UpdateContractLabelCmd sets a new label for a contract
+func UpdateContractLabelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-contract-label [contract_addr_bech32] [label]",
+		Short: "Set a new label for a contract, as admin or operator",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgUpdateLabel{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				Label:    args[1],
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// OperatorSudoCmd sends an allowed sudo message to a contract
+func OperatorSudoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operator-sudo [contract_addr_bech32] [json_encoded_sudo_msg]",
+		Short: "Send a sudo message that is allowed for the operator to a contract",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgOperatorSudo{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				Msg:      []byte(args[1]),
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// GrantContractExecutionCmd grants another account the right to execute a contract on behalf of the sender
+func GrantContractExecutionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant-execute [grantee] [contract_addr_bech32] --allow-msg [msg_keys,optional] --max-funds [coins,optional] --max-calls [count,optional]",
+		Short: "Grant an account the authorization to execute a contract on your behalf",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return sdkerrors.Wrap(err, "grantee")
+			}
+			grant, err := parseContractGrantArgs(args[1], cmd.Flags())
+			if err != nil {
+				return err
+			}
+			authorization := types.NewContractExecutionAuthorization(grant)
+			if err := authorization.ValidateBasic(); err != nil {
+				return err
+			}
+			exp, err := cmd.Flags().GetInt64(flagExpiration)
+			if err != nil {
+				return err
+			}
+			msg, err := authz.NewMsgGrant(clientCtx.GetFromAddress(), grantee, authorization, time.Unix(exp, 0))
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().StringSlice(flagAllowMsgKeys, []string{}, "Top level keys of the contract messages that are allowed, all when empty")
+	cmd.Flags().String(flagMaxFunds, "", "Total amount of coins that can be sent to the contract with all executions")
+	cmd.Flags().Uint64(flagMaxCalls, 0, "Max number of executions, no limit when 0")
+	cmd.Flags().Int64(flagExpiration, time.Now().AddDate(1, 0, 0).Unix(), "The expiration of the grant as Unix timestamp. Default is one year.")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func parseContractGrantArgs(contractAddr string, flags *flag.FlagSet) (types.ContractGrant, error) {
+	msgKeys, err := flags.GetStringSlice(flagAllowMsgKeys)
+	if err != nil {
+		return types.ContractGrant{}, sdkerrors.Wrap(err, "allowed msg keys")
+	}
+	maxFundsStr, err := flags.GetString(flagMaxFunds)
+	if err != nil {
+		return types.ContractGrant{}, sdkerrors.Wrap(err, "max funds")
+	}
+	maxFunds, err := sdk.ParseCoinsNormalized(maxFundsStr)
+	if err != nil {
+		return types.ContractGrant{}, sdkerrors.Wrap(err, "max funds")
+	}
+	maxCalls, err := flags.GetUint64(flagMaxCalls)
+	if err != nil {
+		return types.ContractGrant{}, sdkerrors.Wrap(err, "max calls")
+	}
+	return types.ContractGrant{
+		Contract:           contractAddr,
+		AllowedMessageKeys: msgKeys,
+		MaxFunds:           maxFunds,
+		MaxCalls:           maxCalls,
+	}, nil
+}
+
+// GrantContractMigrationCmd grants another account the right to migrate a contract on behalf of the admin
+func GrantContractMigrationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant-migrate [grantee] [contract_addr_bech32] --allow-code-ids [code_ids,optional] --max-calls [count,optional]",
+		Short: "Grant an account the authorization to migrate a contract that you are the admin of",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return sdkerrors.Wrap(err, "grantee")
+			}
+			codeIDs, err := cmd.Flags().GetUintSlice(flagAllowCodeIDs)
+			if err != nil {
+				return sdkerrors.Wrap(err, "allowed code ids")
+			}
+			maxCalls, err := cmd.Flags().GetUint64(flagMaxCalls)
+			if err != nil {
+				return sdkerrors.Wrap(err, "max calls")
+			}
+			grant := types.ContractMigrationGrant{Contract: args[1], MaxCalls: maxCalls}
+			for _, id := range codeIDs {
+				grant.AllowedCodeIDs = append(grant.AllowedCodeIDs, uint64(id))
+			}
+			authorization := types.NewContractMigrationAuthorization(grant)
+			if err := authorization.ValidateBasic(); err != nil {
+				return err
+			}
+			exp, err := cmd.Flags().GetInt64(flagExpiration)
+			if err != nil {
+				return err
+			}
+			msg, err := authz.NewMsgGrant(clientCtx.GetFromAddress(), grantee, authorization, time.Unix(exp, 0))
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().UintSlice(flagAllowCodeIDs, []uint{}, "Code ids the contract can be migrated to, all when empty")
+	cmd.Flags().Uint64(flagMaxCalls, 0, "Max number of migrations, no limit when 0")
+	cmd.Flags().Int64(flagExpiration, time.Now().AddDate(1, 0, 0).Unix(), "The expiration of the grant as Unix timestamp. Default is one year.")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
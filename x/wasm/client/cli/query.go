@@ -35,6 +35,11 @@ func GetQueryCmd() *cobra.Command {
 		GetCmdGetContractHistory(),
 		GetCmdGetContractState(),
 		GetCmdListPinnedCode(),
+		GetCmdQueryParams(),
+		GetCmdListContractsByCreator(),
+		GetCmdListContractsByAdmin(),
+		GetCmdGetContractRent(),
+		GetCmdQueryVMCacheMetrics(),
 	)
 	return queryCmd
 }
@@ -208,6 +213,7 @@ func GetCmdGetContractState() *cobra.Command {
 		GetCmdGetContractStateAll(),
 		GetCmdGetContractStateRaw(),
 		GetCmdGetContractStateSmart(),
+		GetCmdGetContractStateSize(),
 	)
 	return cmd
 
@@ -253,6 +259,75 @@ func GetCmdGetContractStateAll() *cobra.Command {
 	return cmd
 }
 
+func GetCmdGetContractStateSize() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "size [bech32_address]",
+		Short: "Prints out the number of keys and bytes stored by a contract given its address",
+		Long:  "Prints out the number of keys and bytes stored by a contract given its address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractStateSize(
+				context.Background(),
+				&types.QueryContractStateSizeRequest{
+					Address: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdGetContractRent prints the state rent account of a contract
+func GetCmdGetContractRent() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract-rent [bech32_address]",
+		Short: "Prints out the state rent deposit of a contract given its address",
+		Long:  "Prints out the state rent deposit of a contract given its address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractRent(
+				context.Background(),
+				&types.QueryContractRentRequest{
+					Address: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 func GetCmdGetContractStateRaw() *cobra.Command {
 	decoder := newArgDecoder(hex.DecodeString)
 	cmd := &cobra.Command{
@@ -391,7 +466,7 @@ func GetCmdListPinnedCode() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "pinned",
 		Short: "List all pinned code ids",
-		Long:  "\t\tLong:    List all pinned code ids,\n",
+		Long:  "List all code ids that are pinned in the wasm VM cache",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
@@ -417,7 +492,143 @@ func GetCmdListPinnedCode() *cobra.Command {
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
-	flags.AddPaginationFlagsToCmd(cmd, "list codes")
+	flags.AddPaginationFlagsToCmd(cmd, "list pinned codes")
+	return cmd
+}
+
+// GetCmdListContractsByCreator lists all contracts instantiated by the given creator address
+func GetCmdListContractsByCreator() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-contracts-by-creator [creator]",
+		Short: "List all contracts by creator",
+		Long:  "List all contracts by creator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractsByCreator(
+				context.Background(),
+				&types.QueryContractsByCreatorRequest{
+					CreatorAddress: args[0],
+					Pagination:     pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "list contracts by creator")
+	return cmd
+}
+
+// GetCmdListContractsByAdmin lists all contracts with the given admin address
+func GetCmdListContractsByAdmin() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-contracts-by-admin [admin]",
+		Short: "List all contracts by admin",
+		Long:  "List all contracts that have the given address set as admin",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractsByAdmin(
+				context.Background(),
+				&types.QueryContractsByAdminRequest{
+					AdminAddress: args[0],
+					Pagination:   pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "list contracts by admin")
+	return cmd
+}
+
+// GetCmdQueryParams implements a command to return the current wasm
+// parameters.
+func GetCmdQueryParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "Query the current wasm parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryParamsRequest{}
+			res, err := queryClient.Params(cmd.Context(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(&res.Params)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryVMCacheMetrics queries the cache statistics of the wasm VM of the connected node
+func GetCmdQueryVMCacheMetrics() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vm-cache-metrics",
+		Short: "Query the wasm VM cache statistics of the connected node",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.VMCacheMetrics(cmd.Context(), &types.QueryVMCacheMetricsRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
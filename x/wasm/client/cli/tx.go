@@ -23,11 +23,20 @@ const (
 	flagLabel                  = "label"
 	flagAdmin                  = "admin"
 	flagNoAdmin                = "no-admin"
+	flagAdminCreator           = "admin-creator"
 	flagRunAs                  = "run-as"
 	flagInstantiateByEverybody = "instantiate-everybody"
 	flagInstantiateNobody      = "instantiate-nobody"
 	flagInstantiateByAddress   = "instantiate-only-address"
+	flagSource                 = "code-source-url"
+	flagBuilder                = "builder"
 	flagProposalType           = "type"
+	flagAllowMsgKeys           = "allow-msg"
+	flagMaxFunds               = "max-funds"
+	flagMaxCalls               = "max-calls"
+	flagAllowCodeIDs           = "allow-code-ids"
+	flagExpiration             = "expiration"
+	flagAllowSudoMsgs          = "allow-sudo-msg"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -46,6 +55,15 @@ func GetTxCmd() *cobra.Command {
 		MigrateContractCmd(),
 		UpdateContractAdminCmd(),
 		ClearContractAdminCmd(),
+		UpdateExecuteGasLimitCmd(),
+		DepositRentCmd(),
+		UpdateContractOperatorCmd(),
+		UpdateContractLabelCmd(),
+		OperatorSudoCmd(),
+		StoreAndInstantiateContractCmd(),
+		StoreAndMigrateContractCmd(),
+		GrantContractExecutionCmd(),
+		GrantContractMigrationCmd(),
 	)
 	return txCmd
 }
@@ -66,6 +84,9 @@ func StoreCodeCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if msg.Source, msg.Builder, err = parseCodeSourceFlags(cmd.Flags()); err != nil {
+				return err
+			}
 			if err = msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -76,10 +97,27 @@ func StoreCodeCmd() *cobra.Command {
 	cmd.Flags().String(flagInstantiateByEverybody, "", "Everybody can instantiate a contract from the code, optional")
 	cmd.Flags().String(flagInstantiateNobody, "", "Nobody except the governance process can instantiate a contract from the code, optional")
 	cmd.Flags().String(flagInstantiateByAddress, "", "Only this address can instantiate a contract instance from the code, optional")
+	addCodeSourceFlags(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
+// addCodeSourceFlags registers the optional flags for the source and builder metadata that are stored with the code
+func addCodeSourceFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagSource, "", "An absolute https url to the source code of the contract, optional")
+	cmd.Flags().String(flagBuilder, "", "The docker image with tag used to build the wasm binary, i.e. \"cosmwasm/rust-optimizer:0.12.6\", optional")
+}
+
+func parseCodeSourceFlags(flags *flag.FlagSet) (source, builder string, err error) {
+	if source, err = flags.GetString(flagSource); err != nil {
+		return "", "", fmt.Errorf("source: %s", err)
+	}
+	if builder, err = flags.GetString(flagBuilder); err != nil {
+		return "", "", fmt.Errorf("builder: %s", err)
+	}
+	return source, builder, nil
+}
+
 func parseStoreCodeArgs(file string, sender sdk.AccAddress, flags *flag.FlagSet) (types.MsgStoreCode, error) {
 	wasm, err := ioutil.ReadFile(file)
 	if err != nil {
@@ -176,6 +214,7 @@ func InstantiateContractCmd() *cobra.Command {
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
 	cmd.Flags().String(flagAdmin, "", "Address of an admin")
 	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
+	cmd.Flags().Bool(flagAdminCreator, false, "Set the sender as admin of the contract")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -187,6 +226,16 @@ func parseInstantiateArgs(rawCodeID, initMsg string, sender sdk.AccAddress, flag
 		return types.MsgInstantiateContract{}, err
 	}
 
+	msg, err := parseInstantiateFlags(initMsg, sender, flags)
+	if err != nil {
+		return types.MsgInstantiateContract{}, err
+	}
+	msg.CodeID = codeID
+	return msg, nil
+}
+
+// parseInstantiateFlags builds an instantiate message without code id from the flags
+func parseInstantiateFlags(initMsg string, sender sdk.AccAddress, flags *flag.FlagSet) (types.MsgInstantiateContract, error) {
 	amountStr, err := flags.GetString(flagAmount)
 	if err != nil {
 		return types.MsgInstantiateContract{}, fmt.Errorf("amount: %s", err)
@@ -210,27 +259,93 @@ func parseInstantiateArgs(rawCodeID, initMsg string, sender sdk.AccAddress, flag
 	if err != nil {
 		return types.MsgInstantiateContract{}, fmt.Errorf("no-admin: %s", err)
 	}
+	adminCreator, err := flags.GetBool(flagAdminCreator)
+	if err != nil {
+		return types.MsgInstantiateContract{}, fmt.Errorf("admin-creator: %s", err)
+	}
 
 	// ensure sensible admin is set (or explicitly immutable)
-	if adminStr == "" && !noAdmin {
-		return types.MsgInstantiateContract{}, fmt.Errorf("you must set an admin or explicitly pass --no-admin to make it immutible (wasmd issue #719)")
-	}
-	if adminStr != "" && noAdmin {
-		return types.MsgInstantiateContract{}, fmt.Errorf("you set an admin and passed --no-admin, those cannot both be true")
+	var adminMode types.InstantiateAdminMode
+	switch {
+	case adminStr != "" && (noAdmin || adminCreator), noAdmin && adminCreator:
+		return types.MsgInstantiateContract{}, fmt.Errorf("only one of --admin, --admin-creator and --no-admin can be set")
+	case noAdmin:
+		adminMode = types.InstantiateAdminModeNone
+	case adminCreator:
+		adminMode = types.InstantiateAdminModeCreator
+	case adminStr == "":
+		return types.MsgInstantiateContract{}, fmt.Errorf("you must set an admin, pass --admin-creator or explicitly pass --no-admin to make it immutible (wasmd issue #719)")
 	}
 
 	// build and sign the transaction, then broadcast to Tendermint
 	msg := types.MsgInstantiateContract{
-		Sender: sender.String(),
-		CodeID: codeID,
-		Label:  label,
-		Funds:  amount,
-		Msg:    []byte(initMsg),
-		Admin:  adminStr,
+		Sender:    sender.String(),
+		Label:     label,
+		Funds:     amount,
+		Msg:       []byte(initMsg),
+		Admin:     adminStr,
+		AdminMode: adminMode,
 	}
 	return msg, nil
 }
 
+// StoreAndInstantiateContractCmd will upload code and instantiate a contract from it in a single message.
+func StoreAndInstantiateContractCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "store-instantiate [wasm file] [json_encoded_init_args] --label [text] --admin [address,optional] --amount [coins,optional]",
+		Short:   "Upload a wasm binary and instantiate a contract from it",
+		Aliases: []string{"store-init", "si"},
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg, err := parseStoreAndInstantiateArgs(args[0], args[1], clientCtx.GetFromAddress(), cmd.Flags())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+
+	cmd.Flags().String(flagInstantiateByEverybody, "", "Everybody can instantiate a contract from the code, optional")
+	cmd.Flags().String(flagInstantiateNobody, "", "Nobody except the governance process can instantiate a contract from the code, optional")
+	cmd.Flags().String(flagInstantiateByAddress, "", "Only this address can instantiate a contract instance from the code, optional")
+	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
+	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
+	cmd.Flags().String(flagAdmin, "", "Address of an admin")
+	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
+	cmd.Flags().Bool(flagAdminCreator, false, "Set the sender as admin of the contract")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func parseStoreAndInstantiateArgs(file, initMsg string, sender sdk.AccAddress, flags *flag.FlagSet) (types.MsgStoreAndInstantiateContract, error) {
+	storeMsg, err := parseStoreCodeArgs(file, sender, flags)
+	if err != nil {
+		return types.MsgStoreAndInstantiateContract{}, err
+	}
+	instantiateMsg, err := parseInstantiateFlags(initMsg, sender, flags)
+	if err != nil {
+		return types.MsgStoreAndInstantiateContract{}, err
+	}
+	return types.MsgStoreAndInstantiateContract{
+		Sender:                sender.String(),
+		WASMByteCode:          storeMsg.WASMByteCode,
+		InstantiatePermission: storeMsg.InstantiatePermission,
+		Admin:                 instantiateMsg.Admin,
+		AdminMode:             instantiateMsg.AdminMode,
+		Label:                 instantiateMsg.Label,
+		Msg:                   instantiateMsg.Msg,
+		Funds:                 instantiateMsg.Funds,
+	}, nil
+}
+
 // ExecuteContractCmd will instantiate a contract from previously uploaded code.
 func ExecuteContractCmd() *cobra.Command {
 	cmd := &cobra.Command{
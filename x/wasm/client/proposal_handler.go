@@ -18,4 +18,12 @@ var ProposalHandlers = []govclient.ProposalHandler{
 	govclient.NewProposalHandler(cli.ProposalClearContractAdminCmd, rest.ClearContractAdminProposalHandler),
 	govclient.NewProposalHandler(cli.ProposalPinCodesCmd, rest.PinCodeProposalHandler),
 	govclient.NewProposalHandler(cli.ProposalUnpinCodesCmd, rest.UnpinCodeProposalHandler),
+	govclient.NewProposalHandler(cli.ProposalDeactivateContractsCmd, rest.DeactivateContractsProposalHandler),
+	govclient.NewProposalHandler(cli.ProposalActivateContractsCmd, rest.ActivateContractsProposalHandler),
+	govclient.NewProposalHandler(cli.ProposalUpdateExecuteGasLimitCmd, rest.UpdateExecuteGasLimitProposalHandler),
+	govclient.NewProposalHandler(cli.ProposalRegisterCronContractsCmd, rest.RegisterCronContractsProposalHandler),
+	govclient.NewProposalHandler(cli.ProposalUnregisterCronContractsCmd, rest.UnregisterCronContractsProposalHandler),
+	govclient.NewProposalHandler(cli.ProposalPruneCodesCmd, rest.PruneCodesProposalHandler),
+	govclient.NewProposalHandler(cli.ProposalGrantContractCapabilitiesCmd, rest.GrantContractCapabilitiesProposalHandler),
+	govclient.NewProposalHandler(cli.ProposalRevokeContractCapabilitiesCmd, rest.RevokeContractCapabilitiesProposalHandler),
 }
@@ -25,6 +25,10 @@ type StoreCodeProposalJSONReq struct {
 	RunAs string `json:"run_as" yaml:"run_as"`
 	// WASMByteCode can be raw or gzip compressed
 	WASMByteCode []byte `json:"wasm_byte_code" yaml:"wasm_byte_code"`
+	// Source is a valid absolute HTTPS URI to the contract's source code, optional
+	Source string `json:"source" yaml:"source"`
+	// Builder is a valid docker image name with tag, optional
+	Builder string `json:"builder" yaml:"builder"`
 	// InstantiatePermission to apply on contract creation, optional
 	InstantiatePermission *types.AccessConfig `json:"instantiate_permission" yaml:"instantiate_permission"`
 }
@@ -35,6 +39,8 @@ func (s StoreCodeProposalJSONReq) Content() govtypes.Content {
 		Description:           s.Description,
 		RunAs:                 s.RunAs,
 		WASMByteCode:          s.WASMByteCode,
+		Source:                s.Source,
+		Builder:               s.Builder,
 		InstantiatePermission: s.InstantiatePermission,
 	}
 }
@@ -418,6 +424,134 @@ func UnpinCodeProposalHandler(cliCtx client.Context) govrest.ProposalRESTHandler
 	}
 }
 
+type DeactivateContractsJSONReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+
+	Proposer string    `json:"proposer" yaml:"proposer"`
+	Deposit  sdk.Coins `json:"deposit" yaml:"deposit"`
+
+	Contracts []string `json:"contracts" yaml:"contracts"`
+}
+
+func (s DeactivateContractsJSONReq) Content() govtypes.Content {
+	return &types.DeactivateContractsProposal{
+		Title:       s.Title,
+		Description: s.Description,
+		Contracts:   s.Contracts,
+	}
+}
+func (s DeactivateContractsJSONReq) GetProposer() string {
+	return s.Proposer
+}
+func (s DeactivateContractsJSONReq) GetDeposit() sdk.Coins {
+	return s.Deposit
+}
+func (s DeactivateContractsJSONReq) GetBaseReq() rest.BaseReq {
+	return s.BaseReq
+}
+
+func DeactivateContractsProposalHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "deactivate_contracts",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req DeactivateContractsJSONReq
+			if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+				return
+			}
+			toStdTxResponse(cliCtx, w, req)
+		},
+	}
+}
+
+type ActivateContractsJSONReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+
+	Proposer string    `json:"proposer" yaml:"proposer"`
+	Deposit  sdk.Coins `json:"deposit" yaml:"deposit"`
+
+	Contracts []string `json:"contracts" yaml:"contracts"`
+}
+
+func (s ActivateContractsJSONReq) Content() govtypes.Content {
+	return &types.ActivateContractsProposal{
+		Title:       s.Title,
+		Description: s.Description,
+		Contracts:   s.Contracts,
+	}
+}
+func (s ActivateContractsJSONReq) GetProposer() string {
+	return s.Proposer
+}
+func (s ActivateContractsJSONReq) GetDeposit() sdk.Coins {
+	return s.Deposit
+}
+func (s ActivateContractsJSONReq) GetBaseReq() rest.BaseReq {
+	return s.BaseReq
+}
+
+func ActivateContractsProposalHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "activate_contracts",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req ActivateContractsJSONReq
+			if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+				return
+			}
+			toStdTxResponse(cliCtx, w, req)
+		},
+	}
+}
+
+type UpdateExecuteGasLimitJSONReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+
+	Proposer string    `json:"proposer" yaml:"proposer"`
+	Deposit  sdk.Coins `json:"deposit" yaml:"deposit"`
+
+	Contract string `json:"contract" yaml:"contract"`
+	GasLimit uint64 `json:"gas_limit" yaml:"gas_limit"`
+}
+
+func (s UpdateExecuteGasLimitJSONReq) Content() govtypes.Content {
+	return &types.UpdateExecuteGasLimitProposal{
+		Title:       s.Title,
+		Description: s.Description,
+		Contract:    s.Contract,
+		GasLimit:    s.GasLimit,
+	}
+}
+func (s UpdateExecuteGasLimitJSONReq) GetProposer() string {
+	return s.Proposer
+}
+func (s UpdateExecuteGasLimitJSONReq) GetDeposit() sdk.Coins {
+	return s.Deposit
+}
+func (s UpdateExecuteGasLimitJSONReq) GetBaseReq() rest.BaseReq {
+	return s.BaseReq
+}
+
+func UpdateExecuteGasLimitProposalHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "execute_gas_limit",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req UpdateExecuteGasLimitJSONReq
+			if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+				return
+			}
+			toStdTxResponse(cliCtx, w, req)
+		},
+	}
+}
+
 type wasmProposalData interface {
 	Content() govtypes.Content
 	GetProposer() string
@@ -446,3 +580,217 @@ func toStdTxResponse(cliCtx client.Context, w http.ResponseWriter, data wasmProp
 	}
 	tx.WriteGeneratedTxResponse(cliCtx, w, baseReq, msg)
 }
+
+type RegisterCronContractsJSONReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+
+	Proposer string    `json:"proposer" yaml:"proposer"`
+	Deposit  sdk.Coins `json:"deposit" yaml:"deposit"`
+
+	Contracts []string `json:"contracts" yaml:"contracts"`
+}
+
+func (s RegisterCronContractsJSONReq) Content() govtypes.Content {
+	return &types.RegisterCronContractsProposal{
+		Title:       s.Title,
+		Description: s.Description,
+		Contracts:   s.Contracts,
+	}
+}
+func (s RegisterCronContractsJSONReq) GetProposer() string {
+	return s.Proposer
+}
+func (s RegisterCronContractsJSONReq) GetDeposit() sdk.Coins {
+	return s.Deposit
+}
+func (s RegisterCronContractsJSONReq) GetBaseReq() rest.BaseReq {
+	return s.BaseReq
+}
+
+func RegisterCronContractsProposalHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "register_cron_contracts",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req RegisterCronContractsJSONReq
+			if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+				return
+			}
+			toStdTxResponse(cliCtx, w, req)
+		},
+	}
+}
+
+type UnregisterCronContractsJSONReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+
+	Proposer string    `json:"proposer" yaml:"proposer"`
+	Deposit  sdk.Coins `json:"deposit" yaml:"deposit"`
+
+	Contracts []string `json:"contracts" yaml:"contracts"`
+}
+
+func (s UnregisterCronContractsJSONReq) Content() govtypes.Content {
+	return &types.UnregisterCronContractsProposal{
+		Title:       s.Title,
+		Description: s.Description,
+		Contracts:   s.Contracts,
+	}
+}
+func (s UnregisterCronContractsJSONReq) GetProposer() string {
+	return s.Proposer
+}
+func (s UnregisterCronContractsJSONReq) GetDeposit() sdk.Coins {
+	return s.Deposit
+}
+func (s UnregisterCronContractsJSONReq) GetBaseReq() rest.BaseReq {
+	return s.BaseReq
+}
+
+func UnregisterCronContractsProposalHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "unregister_cron_contracts",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req UnregisterCronContractsJSONReq
+			if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+				return
+			}
+			toStdTxResponse(cliCtx, w, req)
+		},
+	}
+}
+
+type PruneCodesJSONReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+
+	Proposer string    `json:"proposer" yaml:"proposer"`
+	Deposit  sdk.Coins `json:"deposit" yaml:"deposit"`
+
+	CodeIDs []uint64 `json:"code_ids" yaml:"code_ids"`
+}
+
+func (s PruneCodesJSONReq) Content() govtypes.Content {
+	return &types.PruneCodesProposal{
+		Title:       s.Title,
+		Description: s.Description,
+		CodeIDs:     s.CodeIDs,
+	}
+}
+func (s PruneCodesJSONReq) GetProposer() string {
+	return s.Proposer
+}
+func (s PruneCodesJSONReq) GetDeposit() sdk.Coins {
+	return s.Deposit
+}
+func (s PruneCodesJSONReq) GetBaseReq() rest.BaseReq {
+	return s.BaseReq
+}
+
+func PruneCodesProposalHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "prune_codes",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req PruneCodesJSONReq
+			if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+				return
+			}
+			toStdTxResponse(cliCtx, w, req)
+		},
+	}
+}
+
+type GrantContractCapabilitiesJSONReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+
+	Proposer string    `json:"proposer" yaml:"proposer"`
+	Deposit  sdk.Coins `json:"deposit" yaml:"deposit"`
+
+	Contract     string   `json:"contract" yaml:"contract"`
+	Capabilities []string `json:"capabilities" yaml:"capabilities"`
+}
+
+func (s GrantContractCapabilitiesJSONReq) Content() govtypes.Content {
+	return &types.GrantContractCapabilitiesProposal{
+		Title:        s.Title,
+		Description:  s.Description,
+		Contract:     s.Contract,
+		Capabilities: s.Capabilities,
+	}
+}
+func (s GrantContractCapabilitiesJSONReq) GetProposer() string {
+	return s.Proposer
+}
+func (s GrantContractCapabilitiesJSONReq) GetDeposit() sdk.Coins {
+	return s.Deposit
+}
+func (s GrantContractCapabilitiesJSONReq) GetBaseReq() rest.BaseReq {
+	return s.BaseReq
+}
+
+func GrantContractCapabilitiesProposalHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "grant_contract_capabilities",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req GrantContractCapabilitiesJSONReq
+			if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+				return
+			}
+			toStdTxResponse(cliCtx, w, req)
+		},
+	}
+}
+
+type RevokeContractCapabilitiesJSONReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+
+	Proposer string    `json:"proposer" yaml:"proposer"`
+	Deposit  sdk.Coins `json:"deposit" yaml:"deposit"`
+
+	Contract     string   `json:"contract" yaml:"contract"`
+	Capabilities []string `json:"capabilities" yaml:"capabilities"`
+}
+
+func (s RevokeContractCapabilitiesJSONReq) Content() govtypes.Content {
+	return &types.RevokeContractCapabilitiesProposal{
+		Title:        s.Title,
+		Description:  s.Description,
+		Contract:     s.Contract,
+		Capabilities: s.Capabilities,
+	}
+}
+func (s RevokeContractCapabilitiesJSONReq) GetProposer() string {
+	return s.Proposer
+}
+func (s RevokeContractCapabilitiesJSONReq) GetDeposit() sdk.Coins {
+	return s.Deposit
+}
+func (s RevokeContractCapabilitiesJSONReq) GetBaseReq() rest.BaseReq {
+	return s.BaseReq
+}
+
+func RevokeContractCapabilitiesProposalHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "revoke_contract_capabilities",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req RevokeContractCapabilitiesJSONReq
+			if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+				return
+			}
+			toStdTxResponse(cliCtx, w, req)
+		},
+	}
+}
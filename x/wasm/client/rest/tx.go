@@ -23,6 +23,8 @@ func registerTxRoutes(cliCtx client.Context, r *mux.Router) {
 type storeCodeReq struct {
 	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
 	WasmBytes []byte       `json:"wasm_bytes"`
+	Source    string       `json:"source,omitempty" yaml:"source"`
+	Builder   string       `json:"builder,omitempty" yaml:"builder"`
 }
 
 type instantiateContractReq struct {
@@ -70,6 +72,8 @@ func storeCodeHandlerFn(cliCtx client.Context) http.HandlerFunc {
 		msg := types.MsgStoreCode{
 			Sender:       req.BaseReq.From,
 			WASMByteCode: wasm,
+			Source:       req.Source,
+			Builder:      req.Builder,
 		}
 
 		if err := msg.ValidateBasic(); err != nil {
@@ -41,6 +41,7 @@ func TestInitGenesis(t *testing.T) {
 	initCmd := MsgInstantiateContract{
 		Sender: creator.String(),
 		CodeID: firstCodeID,
+		Label:  "testing",
 		Msg:    initMsgBz,
 		Funds:  deposit,
 	}
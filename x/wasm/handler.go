@@ -14,8 +14,8 @@ import (
 )
 
 // NewHandler returns a handler for "wasm" type messages.
-func NewHandler(k types.ContractOpsKeeper) sdk.Handler {
-	msgServer := keeper.NewMsgServerImpl(k)
+func NewHandler(k types.ContractOpsKeeper, s keeper.MsgServerSettings) sdk.Handler {
+	msgServer := keeper.NewMsgServerImpl(k, s)
 
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
 		ctx = ctx.WithEventManager(sdk.NewEventManager())
@@ -37,6 +37,22 @@ func NewHandler(k types.ContractOpsKeeper) sdk.Handler {
 			res, err = msgServer.UpdateAdmin(sdk.WrapSDKContext(ctx), msg)
 		case *MsgClearAdmin:
 			res, err = msgServer.ClearAdmin(sdk.WrapSDKContext(ctx), msg)
+		case *types.MsgUpdateExecuteGasLimit:
+			res, err = msgServer.UpdateExecuteGasLimit(sdk.WrapSDKContext(ctx), msg)
+		case *types.MsgStoreAndInstantiateContract:
+			res, err = msgServer.StoreAndInstantiateContract(sdk.WrapSDKContext(ctx), msg)
+		case *types.MsgStoreAndMigrateContract:
+			res, err = msgServer.StoreAndMigrateContract(sdk.WrapSDKContext(ctx), msg)
+		case *types.MsgScheduleContractCall:
+			res, err = msgServer.ScheduleContractCall(sdk.WrapSDKContext(ctx), msg)
+		case *types.MsgDepositRent:
+			res, err = msgServer.DepositRent(sdk.WrapSDKContext(ctx), msg)
+		case *types.MsgUpdateOperator:
+			res, err = msgServer.UpdateOperator(sdk.WrapSDKContext(ctx), msg)
+		case *types.MsgUpdateLabel:
+			res, err = msgServer.UpdateLabel(sdk.WrapSDKContext(ctx), msg)
+		case *types.MsgOperatorSudo:
+			res, err = msgServer.OperatorSudo(sdk.WrapSDKContext(ctx), msg)
 		default:
 			errMsg := fmt.Sprintf("unrecognized wasm message type: %T", msg)
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
@@ -174,13 +174,7 @@ func (i IBCHandler) OnChanCloseInit(ctx sdk.Context, portID, channelID string) e
 	msg := wasmvmtypes.IBCChannelCloseMsg{
 		CloseInit: &wasmvmtypes.IBCCloseInit{Channel: toWasmVMChannel(portID, channelID, channelInfo)},
 	}
-	err = i.keeper.OnCloseChannel(ctx, contractAddr, msg)
-	if err != nil {
-		return err
-	}
-	// emit events?
-
-	return err
+	return i.keeper.OnCloseChannel(ctx, contractAddr, msg)
 }
 
 // OnChanCloseConfirm implements the IBCModule interface
@@ -198,13 +192,7 @@ func (i IBCHandler) OnChanCloseConfirm(ctx sdk.Context, portID, channelID string
 	msg := wasmvmtypes.IBCChannelCloseMsg{
 		CloseConfirm: &wasmvmtypes.IBCCloseConfirm{Channel: toWasmVMChannel(portID, channelID, channelInfo)},
 	}
-	err = i.keeper.OnCloseChannel(ctx, contractAddr, msg)
-	if err != nil {
-		return err
-	}
-	// emit events?
-
-	return err
+	return i.keeper.OnCloseChannel(ctx, contractAddr, msg)
 }
 
 func toWasmVMChannel(portID, channelID string, channelInfo channeltypes.Channel) wasmvmtypes.IBCChannel {
@@ -1,12 +1,20 @@
 package wasm
 
 import (
+	"errors"
 	"testing"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
 	clienttypes "github.com/cosmos/ibc-go/v2/modules/core/02-client/types"
 	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v2/modules/core/exported"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 )
 
 func TestMapToWasmVMIBCPacket(t *testing.T) {
@@ -61,6 +69,368 @@ func TestMapToWasmVMIBCPacket(t *testing.T) {
 	}
 }
 
+func TestOnChanOpenInit(t *testing.T) {
+	contractAddr := keeper.RandomAccountAddress(t)
+	portID := keeper.PortIDForContract(contractAddr)
+	myCapability := &capabilitytypes.Capability{Index: 1}
+
+	specs := map[string]struct {
+		portID      string
+		channelID   string
+		contractErr error
+		expClaimed  bool
+		expErr      bool
+	}{
+		"contract accepts": {
+			portID:     portID,
+			channelID:  "channel-1",
+			expClaimed: true,
+		},
+		"contract rejects": {
+			portID:      portID,
+			channelID:   "channel-1",
+			contractErr: errors.New("test, ignore"),
+			expErr:      true,
+		},
+		"invalid channel id": {
+			portID:    portID,
+			channelID: "invalid",
+			expErr:    true,
+		},
+		"non contract port": {
+			portID:    "transfer",
+			channelID: "channel-1",
+			expErr:    true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var (
+				gotMsg     *wasmvmtypes.IBCChannelOpenMsg
+				gotClaimed bool
+			)
+			mock := &wasmtesting.MockIBCContractKeeper{
+				OnOpenChannelFn: func(ctx sdk.Context, addr sdk.AccAddress, msg wasmvmtypes.IBCChannelOpenMsg) error {
+					assert.Equal(t, contractAddr, addr)
+					gotMsg = &msg
+					return spec.contractErr
+				},
+				ClaimCapabilityFn: func(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error {
+					assert.Equal(t, myCapability, cap)
+					assert.Equal(t, "capabilities/ports/"+portID+"/channels/channel-1", name)
+					gotClaimed = true
+					return nil
+				},
+			}
+			h := NewIBCHandler(mock, &wasmtesting.MockChannelKeeper{})
+			counterparty := channeltypes.NewCounterparty("otherPort", "channel-7")
+
+			// when
+			err := h.OnChanOpenInit(sdk.Context{}, channeltypes.UNORDERED, []string{"connection-1"}, spec.portID, spec.channelID, myCapability, counterparty, "my-version")
+
+			// then
+			assert.Equal(t, spec.expClaimed, gotClaimed)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotMsg)
+			exp := wasmvmtypes.IBCChannel{
+				Endpoint:             wasmvmtypes.IBCEndpoint{PortID: spec.portID, ChannelID: spec.channelID},
+				CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: "otherPort", ChannelID: "channel-7"},
+				Order:                channeltypes.UNORDERED.String(),
+				Version:              "my-version",
+				ConnectionID:         "connection-1",
+			}
+			assert.Equal(t, exp, gotMsg.OpenInit.Channel)
+		})
+	}
+}
+
+func TestOnChanOpenTry(t *testing.T) {
+	contractAddr := keeper.RandomAccountAddress(t)
+	portID := keeper.PortIDForContract(contractAddr)
+	myCapability := &capabilitytypes.Capability{Index: 1}
+
+	specs := map[string]struct {
+		contractErr  error
+		alreadyOwned bool
+		expClaimed   bool
+		expErr       bool
+	}{
+		"contract accepts": {
+			expClaimed: true,
+		},
+		"contract accepts with crossing hellos": {
+			alreadyOwned: true,
+		},
+		"contract rejects": {
+			contractErr: errors.New("test, ignore"),
+			expErr:      true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var (
+				gotMsg     *wasmvmtypes.IBCChannelOpenMsg
+				gotClaimed bool
+			)
+			mock := &wasmtesting.MockIBCContractKeeper{
+				OnOpenChannelFn: func(ctx sdk.Context, addr sdk.AccAddress, msg wasmvmtypes.IBCChannelOpenMsg) error {
+					gotMsg = &msg
+					return spec.contractErr
+				},
+				AuthenticateCapabilityFn: func(ctx sdk.Context, cap *capabilitytypes.Capability, name string) bool {
+					return spec.alreadyOwned
+				},
+				ClaimCapabilityFn: func(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error {
+					gotClaimed = true
+					return nil
+				},
+			}
+			h := NewIBCHandler(mock, &wasmtesting.MockChannelKeeper{})
+			counterparty := channeltypes.NewCounterparty("otherPort", "channel-7")
+
+			// when
+			err := h.OnChanOpenTry(sdk.Context{}, channeltypes.ORDERED, []string{"connection-1"}, portID, "channel-1", myCapability, counterparty, "my-version", "other-version")
+
+			// then
+			assert.Equal(t, spec.expClaimed, gotClaimed)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotMsg)
+			assert.Equal(t, "other-version", gotMsg.OpenTry.CounterpartyVersion)
+			assert.Equal(t, "my-version", gotMsg.OpenTry.Channel.Version)
+		})
+	}
+}
+
+func TestOnChanClose(t *testing.T) {
+	contractAddr := keeper.RandomAccountAddress(t)
+	portID := keeper.PortIDForContract(contractAddr)
+	myChannel := channeltypes.Channel{
+		Ordering:       channeltypes.UNORDERED,
+		Counterparty:   channeltypes.NewCounterparty("otherPort", "channel-7"),
+		ConnectionHops: []string{"connection-1"},
+		Version:        "my-version",
+	}
+	expChannel := wasmvmtypes.IBCChannel{
+		Endpoint:             wasmvmtypes.IBCEndpoint{PortID: portID, ChannelID: "channel-1"},
+		CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: "otherPort", ChannelID: "channel-7"},
+		Order:                channeltypes.UNORDERED.String(),
+		Version:              "my-version",
+		ConnectionID:         "connection-1",
+	}
+	channelKeeper := &wasmtesting.MockChannelKeeper{
+		GetChannelFn: func(ctx sdk.Context, srcPort, srcChan string) (channeltypes.Channel, bool) {
+			return myChannel, srcPort == portID && srcChan == "channel-1"
+		},
+	}
+
+	specs := map[string]struct {
+		channelID   string
+		contractErr error
+		expErr      bool
+	}{
+		"contract accepts": {
+			channelID: "channel-1",
+		},
+		"contract rejects": {
+			channelID:   "channel-1",
+			contractErr: errors.New("test, ignore"),
+			expErr:      true,
+		},
+		"unknown channel": {
+			channelID: "channel-2",
+			expErr:    true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotMsgs []wasmvmtypes.IBCChannelCloseMsg
+			mock := &wasmtesting.MockIBCContractKeeper{
+				OnCloseChannelFn: func(ctx sdk.Context, addr sdk.AccAddress, msg wasmvmtypes.IBCChannelCloseMsg) error {
+					gotMsgs = append(gotMsgs, msg)
+					return spec.contractErr
+				},
+			}
+			h := NewIBCHandler(mock, channelKeeper)
+
+			// when
+			initErr := h.OnChanCloseInit(sdk.Context{}, portID, spec.channelID)
+			confirmErr := h.OnChanCloseConfirm(sdk.Context{}, portID, spec.channelID)
+
+			// then
+			if spec.expErr {
+				require.Error(t, initErr)
+				require.Error(t, confirmErr)
+				return
+			}
+			require.NoError(t, initErr)
+			require.NoError(t, confirmErr)
+			require.Len(t, gotMsgs, 2)
+			assert.Equal(t, expChannel, gotMsgs[0].CloseInit.Channel)
+			assert.Equal(t, expChannel, gotMsgs[1].CloseConfirm.Channel)
+		})
+	}
+}
+
+func TestOnRecvPacket(t *testing.T) {
+	contractAddr := keeper.RandomAccountAddress(t)
+	portID := keeper.PortIDForContract(contractAddr)
+
+	specs := map[string]struct {
+		destPort    string
+		contractAck []byte
+		contractErr error
+		expAck      ibcexported.Acknowledgement
+		expErrAck   bool
+	}{
+		"contract ack committed": {
+			destPort:    portID,
+			contractAck: []byte("my-ack"),
+			expAck:      ContractConfirmStateAck("my-ack"),
+		},
+		"contract error returned as error ack": {
+			destPort:    portID,
+			contractErr: errors.New("test, ignore"),
+			expErrAck:   true,
+		},
+		"non contract port": {
+			destPort:  "transfer",
+			expErrAck: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			packet := IBCPacketFixture(func(p *channeltypes.Packet) { p.DestinationPort = spec.destPort })
+			mock := &wasmtesting.MockIBCContractKeeper{
+				OnRecvPacketFn: func(ctx sdk.Context, addr sdk.AccAddress, msg wasmvmtypes.IBCPacketReceiveMsg) ([]byte, error) {
+					assert.Equal(t, contractAddr, addr)
+					assert.Equal(t, newIBCPacket(packet), msg.Packet)
+					return spec.contractAck, spec.contractErr
+				},
+			}
+			h := NewIBCHandler(mock, &wasmtesting.MockChannelKeeper{})
+
+			// when
+			gotAck := h.OnRecvPacket(sdk.Context{}, packet, keeper.RandomAccountAddress(t))
+
+			// then
+			if spec.expErrAck {
+				assert.False(t, gotAck.Success())
+				return
+			}
+			assert.Equal(t, spec.expAck, gotAck)
+		})
+	}
+}
+
+func TestOnAcknowledgementPacket(t *testing.T) {
+	contractAddr := keeper.RandomAccountAddress(t)
+	portID := keeper.PortIDForContract(contractAddr)
+
+	specs := map[string]struct {
+		srcPort     string
+		contractErr error
+		expErr      bool
+	}{
+		"contract accepts": {
+			srcPort: portID,
+		},
+		"contract fails": {
+			srcPort:     portID,
+			contractErr: errors.New("test, ignore"),
+			expErr:      true,
+		},
+		"non contract port": {
+			srcPort: "transfer",
+			expErr:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			packet := IBCPacketFixture(func(p *channeltypes.Packet) { p.SourcePort = spec.srcPort })
+			var gotMsg *wasmvmtypes.IBCPacketAckMsg
+			mock := &wasmtesting.MockIBCContractKeeper{
+				OnAckPacketFn: func(ctx sdk.Context, addr sdk.AccAddress, msg wasmvmtypes.IBCPacketAckMsg) error {
+					assert.Equal(t, contractAddr, addr)
+					gotMsg = &msg
+					return spec.contractErr
+				},
+			}
+			h := NewIBCHandler(mock, &wasmtesting.MockChannelKeeper{})
+
+			// when
+			err := h.OnAcknowledgementPacket(sdk.Context{}, packet, []byte("my-ack"), keeper.RandomAccountAddress(t))
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotMsg)
+			assert.Equal(t, []byte("my-ack"), gotMsg.Acknowledgement.Data)
+			assert.Equal(t, newIBCPacket(packet), gotMsg.OriginalPacket)
+		})
+	}
+}
+
+func TestOnTimeoutPacket(t *testing.T) {
+	contractAddr := keeper.RandomAccountAddress(t)
+	portID := keeper.PortIDForContract(contractAddr)
+
+	specs := map[string]struct {
+		srcPort     string
+		contractErr error
+		expErr      bool
+	}{
+		"contract accepts": {
+			srcPort: portID,
+		},
+		"contract fails": {
+			srcPort:     portID,
+			contractErr: errors.New("test, ignore"),
+			expErr:      true,
+		},
+		"non contract port": {
+			srcPort: "transfer",
+			expErr:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			packet := IBCPacketFixture(func(p *channeltypes.Packet) { p.SourcePort = spec.srcPort })
+			var gotMsg *wasmvmtypes.IBCPacketTimeoutMsg
+			mock := &wasmtesting.MockIBCContractKeeper{
+				OnTimeoutPacketFn: func(ctx sdk.Context, addr sdk.AccAddress, msg wasmvmtypes.IBCPacketTimeoutMsg) error {
+					assert.Equal(t, contractAddr, addr)
+					gotMsg = &msg
+					return spec.contractErr
+				},
+			}
+			h := NewIBCHandler(mock, &wasmtesting.MockChannelKeeper{})
+
+			// when
+			err := h.OnTimeoutPacket(sdk.Context{}, packet, keeper.RandomAccountAddress(t))
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotMsg)
+			assert.Equal(t, newIBCPacket(packet), gotMsg.Packet)
+		})
+	}
+}
+
 func IBCPacketFixture(mutators ...func(p *channeltypes.Packet)) channeltypes.Packet {
 	r := channeltypes.Packet{
 		Sequence:           1,
@@ -0,0 +1,149 @@
+package wasm
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	transfertypes "github.com/cosmos/ibc-go/v2/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v2/modules/core/05-port/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+var _ porttypes.IBCModule = ICS20TransferCallbacks{}
+
+const (
+	// ICS20CallbackCapability is the contract capability that opts a contract in to the ICS-20 callbacks.
+	// It is granted by governance.
+	ICS20CallbackCapability = "ics20_callback"
+	// ICS20CallbackGasLimit is the max gas for a single callback to a contract
+	ICS20CallbackGasLimit uint64 = 1_000_000
+)
+
+// ICS20TransferCallbacks is a middleware for the ICS-20 transfer module. When a transfer that was sent by a contract
+// is acknowledged or timed out, the outcome is delivered to the sending contract via its `sudo` entry point so that
+// it can release or refund escrowed funds. Only contracts with the ICS20CallbackCapability are called.
+//
+// The callback is executed after the transfer module has processed the packet. It runs with the
+// ICS20CallbackGasLimit and the gas used is charged to the relayer. A failing callback, including one that runs
+// out of gas, does not revert the transfer module state changes, so that refunds are never blocked by a contract.
+// The error is reported in an `ics20_callback` event instead.
+type ICS20TransferCallbacks struct {
+	porttypes.IBCModule
+	keeper types.ICS20CallbackKeeper
+}
+
+// NewICS20TransferCallbacks constructor
+func NewICS20TransferCallbacks(transferModule porttypes.IBCModule, k types.ICS20CallbackKeeper) ICS20TransferCallbacks {
+	return ICS20TransferCallbacks{IBCModule: transferModule, keeper: k}
+}
+
+// ICS20CallbackSudoMsg is the message sent to the contract `sudo` entry point
+type ICS20CallbackSudoMsg struct {
+	ICS20Callback *ICS20Callback `json:"ics20_callback,omitempty"`
+}
+
+// ICS20Callback contains either the acknowledgement or the timeout of a transfer
+type ICS20Callback struct {
+	Ack     *ICS20AckCallback     `json:"ack,omitempty"`
+	Timeout *ICS20TimeoutCallback `json:"timeout,omitempty"`
+}
+
+// ICS20AckCallback is sent when the counterparty chain acknowledged a transfer
+type ICS20AckCallback struct {
+	ChannelID string `json:"channel_id"`
+	Sequence  uint64 `json:"sequence"`
+	// Success is false when the transfer failed on the counterparty chain and the tokens were refunded
+	Success bool `json:"success"`
+	// Ack is the raw acknowledgement
+	Ack []byte `json:"ack"`
+}
+
+// ICS20TimeoutCallback is sent when a transfer timed out and the tokens were refunded
+type ICS20TimeoutCallback struct {
+	ChannelID string `json:"channel_id"`
+	Sequence  uint64 `json:"sequence"`
+}
+
+// OnAcknowledgementPacket implements the IBCModule interface
+func (i ICS20TransferCallbacks) OnAcknowledgementPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	acknowledgement []byte,
+	relayer sdk.AccAddress,
+) error {
+	if err := i.IBCModule.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer); err != nil {
+		return err
+	}
+	var ack channeltypes.Acknowledgement
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(acknowledgement, &ack); err != nil {
+		return nil // rejected by the transfer module already
+	}
+	i.callback(ctx, packet, ICS20Callback{Ack: &ICS20AckCallback{
+		ChannelID: packet.SourceChannel,
+		Sequence:  packet.Sequence,
+		Success:   ack.Success(),
+		Ack:       acknowledgement,
+	}})
+	return nil
+}
+
+// OnTimeoutPacket implements the IBCModule interface
+func (i ICS20TransferCallbacks) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) error {
+	if err := i.IBCModule.OnTimeoutPacket(ctx, packet, relayer); err != nil {
+		return err
+	}
+	i.callback(ctx, packet, ICS20Callback{Timeout: &ICS20TimeoutCallback{
+		ChannelID: packet.SourceChannel,
+		Sequence:  packet.Sequence,
+	}})
+	return nil
+}
+
+// callback calls the sending contract, if it opted in. State changes by the contract are only committed on success.
+func (i ICS20TransferCallbacks) callback(ctx sdk.Context, packet channeltypes.Packet, cb ICS20Callback) {
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		return
+	}
+	sender, err := sdk.AccAddressFromBech32(data.Sender)
+	if err != nil || !i.keeper.HasContractCapability(ctx, sender, ICS20CallbackCapability) {
+		return
+	}
+	msg, err := json.Marshal(ICS20CallbackSudoMsg{ICS20Callback: &cb})
+	if err != nil {
+		panic(err) // can not happen
+	}
+	event := sdk.NewEvent(
+		types.EventTypeICS20Callback,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, sender.String()),
+	)
+	cacheCtx, commit := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(sdk.NewGasMeter(ICS20CallbackGasLimit))
+	err = sudoWithOutOfGasRecovery(cacheCtx, i.keeper, sender, msg)
+	ctx.GasMeter().ConsumeGas(cacheCtx.GasMeter().GasConsumedToLimit(), "ics20 callback")
+	if err != nil {
+		ctx.EventManager().EmitEvent(event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyCallbackError, err.Error())))
+		return
+	}
+	commit()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	ctx.EventManager().EmitEvent(event)
+}
+
+// sudoWithOutOfGasRecovery calls the sudo entry point of the contract and returns an out of gas panic as error
+func sudoWithOutOfGasRecovery(ctx sdk.Context, k types.ICS20CallbackKeeper, contractAddr sdk.AccAddress, msg []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// if it's not an OutOfGas error, raise it again
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			err = sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "callback hit gas limit")
+		}
+	}()
+	_, err = k.Sudo(ctx, contractAddr, msg)
+	return err
+}
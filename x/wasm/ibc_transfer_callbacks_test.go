@@ -0,0 +1,221 @@
+package wasm
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v2/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v2/modules/core/05-port/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+)
+
+func TestICS20TransferCallbacksOnAck(t *testing.T) {
+	contractAddr := keeper.RandomAccountAddress(t)
+	successAck := channeltypes.NewResultAcknowledgement([]byte{byte(1)}).Acknowledgement()
+	errorAck := channeltypes.NewErrorAcknowledgement("testing").Acknowledgement()
+
+	specs := map[string]struct {
+		sender      string
+		ack         []byte
+		transferErr error
+		sudoErr     error
+		sudoGas     sdk.Gas
+		expSudoMsg  string
+		expCommits  []bool
+		expErr      bool
+		expEvent    bool
+		expEventErr string
+		expGas      sdk.Gas
+	}{
+		"success ack to contract": {
+			sender:     contractAddr.String(),
+			ack:        successAck,
+			expSudoMsg: `{"ics20_callback":{"ack":{"channel_id":"channel-1","sequence":1,"success":true,"ack":"` + base64.StdEncoding.EncodeToString(successAck) + `"}}}`,
+			expCommits: []bool{true},
+			expEvent:   true,
+		},
+		"error ack to contract": {
+			sender:     contractAddr.String(),
+			ack:        errorAck,
+			expSudoMsg: `{"ics20_callback":{"ack":{"channel_id":"channel-1","sequence":1,"success":false,"ack":"` + base64.StdEncoding.EncodeToString(errorAck) + `"}}}`,
+			expCommits: []bool{true},
+			expEvent:   true,
+		},
+		"contract callback fails": {
+			sender:      contractAddr.String(),
+			ack:         successAck,
+			sudoErr:     errors.New("testing"),
+			expSudoMsg:  `{"ics20_callback":{"ack":{"channel_id":"channel-1","sequence":1,"success":true,"ack":"` + base64.StdEncoding.EncodeToString(successAck) + `"}}}`,
+			expCommits:  []bool{false},
+			expEvent:    true,
+			expEventErr: "testing",
+		},
+		"contract callback gas charged": {
+			sender:     contractAddr.String(),
+			ack:        successAck,
+			sudoGas:    1000,
+			expSudoMsg: `{"ics20_callback":{"ack":{"channel_id":"channel-1","sequence":1,"success":true,"ack":"` + base64.StdEncoding.EncodeToString(successAck) + `"}}}`,
+			expCommits: []bool{true},
+			expEvent:   true,
+			expGas:     1000,
+		},
+		"contract callback out of gas": {
+			sender:      contractAddr.String(),
+			ack:         successAck,
+			sudoGas:     ICS20CallbackGasLimit + 1,
+			expSudoMsg:  `{"ics20_callback":{"ack":{"channel_id":"channel-1","sequence":1,"success":true,"ack":"` + base64.StdEncoding.EncodeToString(successAck) + `"}}}`,
+			expCommits:  []bool{false},
+			expEvent:    true,
+			expEventErr: "callback hit gas limit: out of gas",
+			expGas:      ICS20CallbackGasLimit,
+		},
+		"contract without capability": {
+			sender: keeper.RandomBech32AccountAddress(t),
+			ack:    successAck,
+		},
+		"transfer module fails": {
+			sender:      contractAddr.String(),
+			ack:         successAck,
+			transferErr: errors.New("testing"),
+			expErr:      true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotSudoMsg string
+			transferModule := &mockIBCModule{
+				OnAcknowledgementPacketFn: func(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte, relayer sdk.AccAddress) error {
+					return spec.transferErr
+				},
+			}
+			mock := &wasmtesting.MockICS20CallbackKeeper{
+				HasContractCapabilityFn: func(ctx sdk.Context, addr sdk.AccAddress, capability string) bool {
+					return addr.Equals(contractAddr) && capability == "ics20_callback"
+				},
+				SudoFn: func(ctx sdk.Context, addr sdk.AccAddress, msg []byte) ([]byte, error) {
+					gotSudoMsg = string(msg)
+					ctx.GasMeter().ConsumeGas(spec.sudoGas, "testing")
+					return nil, spec.sudoErr
+				},
+			}
+			var mockStore wasmtesting.MockCommitMultiStore
+			em := sdk.NewEventManager()
+			ctx := sdk.Context{}.WithMultiStore(&mockStore).WithEventManager(em).WithGasMeter(sdk.NewInfiniteGasMeter())
+			packet := ics20PacketFixture(t, spec.sender)
+
+			// when
+			err := NewICS20TransferCallbacks(transferModule, mock).OnAcknowledgementPacket(ctx, packet, spec.ack, keeper.RandomAccountAddress(t))
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if spec.expSudoMsg == "" {
+				assert.Empty(t, gotSudoMsg)
+			} else {
+				assert.JSONEq(t, spec.expSudoMsg, gotSudoMsg)
+			}
+			assert.Equal(t, spec.expCommits, mockStore.Committed)
+			assert.Equal(t, spec.expGas, ctx.GasMeter().GasConsumed())
+			if !spec.expEvent {
+				assert.Empty(t, em.Events())
+				return
+			}
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, "ics20_callback", em.Events()[0].Type)
+			expAttrs := []abci.EventAttribute{{Key: []byte("_contract_address"), Value: []byte(contractAddr.String())}}
+			if spec.expEventErr != "" {
+				expAttrs = append(expAttrs, abci.EventAttribute{Key: []byte("error"), Value: []byte(spec.expEventErr)})
+			}
+			assert.Equal(t, expAttrs, em.Events()[0].Attributes)
+		})
+	}
+}
+
+func TestICS20TransferCallbacksOnTimeout(t *testing.T) {
+	contractAddr := keeper.RandomAccountAddress(t)
+	specs := map[string]struct {
+		sender     string
+		expSudoMsg string
+	}{
+		"contract sender": {
+			sender:     contractAddr.String(),
+			expSudoMsg: `{"ics20_callback":{"timeout":{"channel_id":"channel-1","sequence":1}}}`,
+		},
+		"contract without capability": {
+			sender: keeper.RandomBech32AccountAddress(t),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotSudoMsg string
+			transferModule := &mockIBCModule{
+				OnTimeoutPacketFn: func(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) error {
+					return nil
+				},
+			}
+			mock := &wasmtesting.MockICS20CallbackKeeper{
+				HasContractCapabilityFn: func(ctx sdk.Context, addr sdk.AccAddress, capability string) bool {
+					return addr.Equals(contractAddr) && capability == "ics20_callback"
+				},
+				SudoFn: func(ctx sdk.Context, addr sdk.AccAddress, msg []byte) ([]byte, error) {
+					gotSudoMsg = string(msg)
+					return nil, nil
+				},
+			}
+			var mockStore wasmtesting.MockCommitMultiStore
+			ctx := sdk.Context{}.WithMultiStore(&mockStore).WithEventManager(sdk.NewEventManager()).WithGasMeter(sdk.NewInfiniteGasMeter())
+
+			// when
+			err := NewICS20TransferCallbacks(transferModule, mock).OnTimeoutPacket(ctx, ics20PacketFixture(t, spec.sender), keeper.RandomAccountAddress(t))
+
+			// then
+			require.NoError(t, err)
+			if spec.expSudoMsg == "" {
+				assert.Empty(t, gotSudoMsg)
+				return
+			}
+			assert.JSONEq(t, spec.expSudoMsg, gotSudoMsg)
+		})
+	}
+}
+
+func ics20PacketFixture(t *testing.T, sender string) channeltypes.Packet {
+	data := transfertypes.NewFungibleTokenPacketData("stake", "100", sender, keeper.RandomBech32AccountAddress(t))
+	return IBCPacketFixture(func(p *channeltypes.Packet) {
+		p.SourcePort = transfertypes.PortID
+		p.Data = data.GetBytes()
+	})
+}
+
+var _ porttypes.IBCModule = &mockIBCModule{}
+
+type mockIBCModule struct {
+	porttypes.IBCModule
+	OnAcknowledgementPacketFn func(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte, relayer sdk.AccAddress) error
+	OnTimeoutPacketFn         func(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) error
+}
+
+func (m *mockIBCModule) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte, relayer sdk.AccAddress) error {
+	if m.OnAcknowledgementPacketFn == nil {
+		panic("not expected to be called")
+	}
+	return m.OnAcknowledgementPacketFn(ctx, packet, acknowledgement, relayer)
+}
+
+func (m *mockIBCModule) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) error {
+	if m.OnTimeoutPacketFn == nil {
+		panic("not expected to be called")
+	}
+	return m.OnTimeoutPacketFn(ctx, packet, relayer)
+}
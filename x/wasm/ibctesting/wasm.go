@@ -21,14 +21,14 @@ import (
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
-var wasmIdent = []byte("\x00\x61\x73\x6D")
-
 // SeedNewContractInstance stores some wasm code and instantiates a new contract on this chain.
 // This method can be called to prepare the store with some valid CodeInfo and ContractInfo. The returned
 // Address is the contract address for this instance. Test should make use of this data and/or use NewIBCContractMockWasmer
 // for using a contract mock in Go.
 func (chain *TestChain) SeedNewContractInstance() sdk.AccAddress {
-	pInstResp := chain.StoreCode(append(wasmIdent, rand.Bytes(10)...))
+	// an empty wasm module with a random custom section gives each code a unique checksum
+	wasmCode := append([]byte("\x00asm\x01\x00\x00\x00\x00\x0b\x00"), rand.Bytes(10)...)
+	pInstResp := chain.StoreCode(wasmCode)
 	codeID := pInstResp.CodeID
 
 	anyAddressStr := chain.SenderAccount.GetAddress().String()
@@ -0,0 +1,466 @@
+package keeper
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// DefaultMaxTimelockedCallsPerBlock is the default max number of timelocked migrations and operator sudo calls that
+// are executed in a single block, see applyPendingContractChanges.
+const DefaultMaxTimelockedCallsPerBlock uint32 = 100
+
+// scheduleAdminChange stores an admin change that takes effect after the admin timelock. A pending change
+// of the contract is replaced. The caller is authorized again when the change is applied.
+func (k Keeper) scheduleAdminChange(ctx sdk.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ AuthorizationPolicy) (uint64, error) {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	change := types.PendingAdminChange{
+		Contract: contractAddress.String(),
+		Sender:   caller.String(),
+		NewAdmin: newAdmin.String(),
+		Height:   uint64(ctx.BlockHeight()) + k.adminTimelock,
+	}
+	if err := change.ValidateBasic(); err != nil {
+		return 0, err
+	}
+	k.storePendingAdminChange(ctx, contractAddress, change)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypePendingAdmin,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, change.Contract),
+		sdk.NewAttribute(types.AttributeKeyAdmin, change.NewAdmin),
+		sdk.NewAttribute(types.AttributeKeyHeight, strconv.FormatUint(change.Height, 10)),
+	))
+	return change.Height, nil
+}
+
+// scheduleMigration stores a migration that takes effect after the admin timelock. A pending migration
+// of the contract is replaced. The caller is authorized again when the migration is applied.
+// The cron gas limit for the execution is charged upfront as the migration runs in end block where nobody
+// pays for gas.
+func (k Keeper) scheduleMigration(ctx sdk.Context, contractAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) (uint64, error) {
+	if err := k.checkContractMsgSize(ctx, msg); err != nil {
+		return 0, err
+	}
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not migrate")
+	}
+	if k.GetCodeInfo(ctx, newCodeID) == nil {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown code")
+	}
+	migration := types.PendingMigration{
+		Contract: contractAddress.String(),
+		Sender:   caller.String(),
+		CodeID:   newCodeID,
+		Msg:      msg,
+		Height:   uint64(ctx.BlockHeight()) + k.adminTimelock,
+	}
+	if err := migration.ValidateBasic(); err != nil {
+		return 0, err
+	}
+	ctx.GasMeter().ConsumeGas(k.cronGasLimit, "timelocked migration")
+	k.storePendingMigration(ctx, contractAddress, migration)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypePendingMigration,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, migration.Contract),
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(migration.CodeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyHeight, strconv.FormatUint(migration.Height, 10)),
+	))
+	return migration.Height, nil
+}
+
+// scheduleOperatorChange stores an operator change that takes effect after the admin timelock. A pending change
+// of the contract is replaced. The caller is authorized again when the change is applied.
+func (k Keeper) scheduleOperatorChange(ctx sdk.Context, contractAddress, caller, operator sdk.AccAddress, sudoMsgs []string, authZ AuthorizationPolicy) (uint64, error) {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	change := types.PendingOperatorChange{
+		Contract: contractAddress.String(),
+		Sender:   caller.String(),
+		Operator: operator.String(),
+		SudoMsgs: sudoMsgs,
+		Height:   uint64(ctx.BlockHeight()) + k.adminTimelock,
+	}
+	if err := change.ValidateBasic(); err != nil {
+		return 0, err
+	}
+	k.storePendingOperatorChange(ctx, contractAddress, change)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypePendingOperator,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, change.Contract),
+		sdk.NewAttribute(types.AttributeKeyOperator, change.Operator),
+		sdk.NewAttribute(types.AttributeKeyHeight, strconv.FormatUint(change.Height, 10)),
+	))
+	return change.Height, nil
+}
+
+// scheduleOperatorSudo stores an operator sudo call that is executed after the admin timelock. A pending call
+// of the contract is replaced. The caller and the message are authorized again when the call is executed.
+// The cron gas limit for the execution is charged upfront as the call runs in end block where nobody pays for gas.
+func (k Keeper) scheduleOperatorSudo(ctx sdk.Context, contractAddress, caller sdk.AccAddress, msg []byte, authZ AuthorizationPolicy) (uint64, error) {
+	if err := k.checkContractMsgSize(ctx, msg); err != nil {
+		return 0, err
+	}
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanOperateContract(contractInfo.AdminAddr(), contractInfo.OperatorAddr(), caller) {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not operate contract")
+	}
+	name, err := sudoMsgName(msg)
+	if err != nil {
+		return 0, err
+	}
+	if !contains(contractInfo.OperatorSudoMsgs, name) {
+		return 0, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "sudo msg not allowed: %s", name)
+	}
+	call := types.PendingOperatorSudo{
+		Contract: contractAddress.String(),
+		Sender:   caller.String(),
+		Msg:      msg,
+		Height:   uint64(ctx.BlockHeight()) + k.adminTimelock,
+	}
+	if err := call.ValidateBasic(); err != nil {
+		return 0, err
+	}
+	ctx.GasMeter().ConsumeGas(k.cronGasLimit, "timelocked operator sudo")
+	k.storePendingOperatorSudo(ctx, contractAddress, call)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypePendingSudo,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, call.Contract),
+		sdk.NewAttribute(types.AttributeKeyHeight, strconv.FormatUint(call.Height, 10)),
+	))
+	return call.Height, nil
+}
+
+// IteratePendingAdminChanges iterates over all timelocked admin changes. Iteration stops when the callback returns true.
+func (k Keeper) IteratePendingAdminChanges(ctx sdk.Context, cb func(types.PendingAdminChange) bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.PendingAdminChangePrefix)
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var change types.PendingAdminChange
+		k.cdc.MustUnmarshal(iter.Value(), &change)
+		if cb(change) {
+			return
+		}
+	}
+}
+
+// IteratePendingMigrations iterates over all timelocked migrations. Iteration stops when the callback returns true.
+func (k Keeper) IteratePendingMigrations(ctx sdk.Context, cb func(types.PendingMigration) bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.PendingMigrationPrefix)
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var migration types.PendingMigration
+		k.cdc.MustUnmarshal(iter.Value(), &migration)
+		if cb(migration) {
+			return
+		}
+	}
+}
+
+// IteratePendingOperatorChanges iterates over all timelocked operator changes. Iteration stops when the callback
+// returns true.
+func (k Keeper) IteratePendingOperatorChanges(ctx sdk.Context, cb func(types.PendingOperatorChange) bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.PendingOperatorChangePrefix)
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var change types.PendingOperatorChange
+		k.cdc.MustUnmarshal(iter.Value(), &change)
+		if cb(change) {
+			return
+		}
+	}
+}
+
+// IteratePendingOperatorSudos iterates over all timelocked operator sudo calls. Iteration stops when the callback
+// returns true.
+func (k Keeper) IteratePendingOperatorSudos(ctx sdk.Context, cb func(types.PendingOperatorSudo) bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.PendingOperatorSudoPrefix)
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var call types.PendingOperatorSudo
+		k.cdc.MustUnmarshal(iter.Value(), &call)
+		if cb(call) {
+			return
+		}
+	}
+}
+
+// importPendingAdminChange stores a timelocked admin change from genesis
+func (k Keeper) importPendingAdminChange(ctx sdk.Context, change types.PendingAdminChange) error {
+	if err := change.ValidateBasic(); err != nil {
+		return err
+	}
+	contractAddr, _ := sdk.AccAddressFromBech32(change.Contract)
+	if k.getPendingAdminChange(ctx, contractAddr) != nil {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "pending admin change: %s", change.Contract)
+	}
+	k.storePendingAdminChange(ctx, contractAddr, change)
+	return nil
+}
+
+// importPendingMigration stores a timelocked migration from genesis
+func (k Keeper) importPendingMigration(ctx sdk.Context, migration types.PendingMigration) error {
+	if err := migration.ValidateBasic(); err != nil {
+		return err
+	}
+	contractAddr, _ := sdk.AccAddressFromBech32(migration.Contract)
+	if k.getPendingMigration(ctx, contractAddr) != nil {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "pending migration: %s", migration.Contract)
+	}
+	k.storePendingMigration(ctx, contractAddr, migration)
+	return nil
+}
+
+// importPendingOperatorChange stores a timelocked operator change from genesis
+func (k Keeper) importPendingOperatorChange(ctx sdk.Context, change types.PendingOperatorChange) error {
+	if err := change.ValidateBasic(); err != nil {
+		return err
+	}
+	contractAddr, _ := sdk.AccAddressFromBech32(change.Contract)
+	if k.getPendingOperatorChange(ctx, contractAddr) != nil {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "pending operator change: %s", change.Contract)
+	}
+	k.storePendingOperatorChange(ctx, contractAddr, change)
+	return nil
+}
+
+// importPendingOperatorSudo stores a timelocked operator sudo call from genesis
+func (k Keeper) importPendingOperatorSudo(ctx sdk.Context, call types.PendingOperatorSudo) error {
+	if err := call.ValidateBasic(); err != nil {
+		return err
+	}
+	contractAddr, _ := sdk.AccAddressFromBech32(call.Contract)
+	if k.getPendingOperatorSudo(ctx, contractAddr) != nil {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "pending operator sudo: %s", call.Contract)
+	}
+	k.storePendingOperatorSudo(ctx, contractAddr, call)
+	return nil
+}
+
+// storePendingAdminChange stores the change and replaces a pending change of the contract, if any
+func (k Keeper) storePendingAdminChange(ctx sdk.Context, contractAddr sdk.AccAddress, change types.PendingAdminChange) {
+	k.deletePendingAdminChange(ctx, contractAddr)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPendingAdminChangeKey(contractAddr), k.cdc.MustMarshal(&change))
+	// store 1 byte to not run into `nil` debugging issues
+	store.Set(types.GetPendingAdminChangeByHeightKey(change.Height, contractAddr), []byte{1})
+}
+
+// getPendingAdminChange returns the pending admin change of the contract or nil
+func (k Keeper) getPendingAdminChange(ctx sdk.Context, contractAddr sdk.AccAddress) *types.PendingAdminChange {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetPendingAdminChangeKey(contractAddr))
+	if bz == nil {
+		return nil
+	}
+	var change types.PendingAdminChange
+	k.cdc.MustUnmarshal(bz, &change)
+	return &change
+}
+
+// deletePendingAdminChange removes the pending admin change of the contract and its height index entry
+func (k Keeper) deletePendingAdminChange(ctx sdk.Context, contractAddr sdk.AccAddress) {
+	change := k.getPendingAdminChange(ctx, contractAddr)
+	if change == nil {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPendingAdminChangeKey(contractAddr))
+	store.Delete(types.GetPendingAdminChangeByHeightKey(change.Height, contractAddr))
+}
+
+// storePendingMigration stores the migration and replaces a pending migration of the contract, if any
+func (k Keeper) storePendingMigration(ctx sdk.Context, contractAddr sdk.AccAddress, migration types.PendingMigration) {
+	k.deletePendingMigration(ctx, contractAddr)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPendingMigrationKey(contractAddr), k.cdc.MustMarshal(&migration))
+	// store 1 byte to not run into `nil` debugging issues
+	store.Set(types.GetPendingMigrationByHeightKey(migration.Height, contractAddr), []byte{1})
+}
+
+// getPendingMigration returns the pending migration of the contract or nil
+func (k Keeper) getPendingMigration(ctx sdk.Context, contractAddr sdk.AccAddress) *types.PendingMigration {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetPendingMigrationKey(contractAddr))
+	if bz == nil {
+		return nil
+	}
+	var migration types.PendingMigration
+	k.cdc.MustUnmarshal(bz, &migration)
+	return &migration
+}
+
+// deletePendingMigration removes the pending migration of the contract and its height index entry
+func (k Keeper) deletePendingMigration(ctx sdk.Context, contractAddr sdk.AccAddress) {
+	migration := k.getPendingMigration(ctx, contractAddr)
+	if migration == nil {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPendingMigrationKey(contractAddr))
+	store.Delete(types.GetPendingMigrationByHeightKey(migration.Height, contractAddr))
+}
+
+// storePendingOperatorChange stores the change and replaces a pending change of the contract, if any
+func (k Keeper) storePendingOperatorChange(ctx sdk.Context, contractAddr sdk.AccAddress, change types.PendingOperatorChange) {
+	k.deletePendingOperatorChange(ctx, contractAddr)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPendingOperatorChangeKey(contractAddr), k.cdc.MustMarshal(&change))
+	// store 1 byte to not run into `nil` debugging issues
+	store.Set(types.GetPendingOperatorChangeByHeightKey(change.Height, contractAddr), []byte{1})
+}
+
+// getPendingOperatorChange returns the pending operator change of the contract or nil
+func (k Keeper) getPendingOperatorChange(ctx sdk.Context, contractAddr sdk.AccAddress) *types.PendingOperatorChange {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetPendingOperatorChangeKey(contractAddr))
+	if bz == nil {
+		return nil
+	}
+	var change types.PendingOperatorChange
+	k.cdc.MustUnmarshal(bz, &change)
+	return &change
+}
+
+// deletePendingOperatorChange removes the pending operator change of the contract and its height index entry
+func (k Keeper) deletePendingOperatorChange(ctx sdk.Context, contractAddr sdk.AccAddress) {
+	change := k.getPendingOperatorChange(ctx, contractAddr)
+	if change == nil {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPendingOperatorChangeKey(contractAddr))
+	store.Delete(types.GetPendingOperatorChangeByHeightKey(change.Height, contractAddr))
+}
+
+// storePendingOperatorSudo stores the sudo call and replaces a pending call of the contract, if any
+func (k Keeper) storePendingOperatorSudo(ctx sdk.Context, contractAddr sdk.AccAddress, call types.PendingOperatorSudo) {
+	k.deletePendingOperatorSudo(ctx, contractAddr)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPendingOperatorSudoKey(contractAddr), k.cdc.MustMarshal(&call))
+	// store 1 byte to not run into `nil` debugging issues
+	store.Set(types.GetPendingOperatorSudoByHeightKey(call.Height, contractAddr), []byte{1})
+}
+
+// getPendingOperatorSudo returns the pending operator sudo call of the contract or nil
+func (k Keeper) getPendingOperatorSudo(ctx sdk.Context, contractAddr sdk.AccAddress) *types.PendingOperatorSudo {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetPendingOperatorSudoKey(contractAddr))
+	if bz == nil {
+		return nil
+	}
+	var call types.PendingOperatorSudo
+	k.cdc.MustUnmarshal(bz, &call)
+	return &call
+}
+
+// deletePendingOperatorSudo removes the pending operator sudo call of the contract and its height index entry
+func (k Keeper) deletePendingOperatorSudo(ctx sdk.Context, contractAddr sdk.AccAddress) {
+	call := k.getPendingOperatorSudo(ctx, contractAddr)
+	if call == nil {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPendingOperatorSudoKey(contractAddr))
+	store.Delete(types.GetPendingOperatorSudoByHeightKey(call.Height, contractAddr))
+}
+
+// dueContracts returns up to max addresses of the contracts in the height index with the given prefix that are due
+// at the given height. Entries from earlier heights that were not processed yet come first.
+func (k Keeper) dueContracts(ctx sdk.Context, indexPrefix []byte, height uint64, max int) []sdk.AccAddress {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), indexPrefix)
+	iter := store.Iterator(nil, sdk.Uint64ToBigEndian(height+1))
+	defer iter.Close()
+
+	var r []sdk.AccAddress
+	for ; iter.Valid() && len(r) < max; iter.Next() {
+		// key: <height><contractAddr>
+		r = append(r, iter.Key()[8:])
+	}
+	return r
+}
+
+// applyPendingContractChanges applies the timelocked admin changes, operator changes, migrations and operator sudo
+// calls that are due. Only the due entries of the height indexes are read. The sender must still be authorized for
+// the contract, otherwise the change is dropped. Migrations and sudo calls run with the cron gas limit applied, a
+// failing call does not modify the contract. Not more than the max timelocked calls per block are executed, the
+// others stay in the height index for the next blocks. Migrations are executed first and sudo calls get the rest
+// of the limit.
+func (k Keeper) applyPendingContractChanges(ctx sdk.Context) {
+	height := uint64(ctx.BlockHeight())
+	// collect first to not write into the store while iterating
+	for _, contractAddr := range k.dueContracts(ctx, types.PendingAdminChangeByHeightPrefix, height, math.MaxInt) {
+		change := k.getPendingAdminChange(ctx, contractAddr)
+		k.deletePendingAdminChange(ctx, contractAddr)
+		// addresses are validated before the changes are stored
+		sender, _ := sdk.AccAddressFromBech32(change.Sender)
+		newAdmin, _ := sdk.AccAddressFromBech32(change.NewAdmin)
+		if err := k.setContractAdmin(ctx, contractAddr, sender, newAdmin, DefaultAuthorizationPolicy{}); err != nil {
+			k.Logger(ctx).Error("pending admin change dropped", "contract", change.Contract, "error", err)
+		}
+	}
+	for _, contractAddr := range k.dueContracts(ctx, types.PendingOperatorChangeByHeightPrefix, height, math.MaxInt) {
+		change := k.getPendingOperatorChange(ctx, contractAddr)
+		k.deletePendingOperatorChange(ctx, contractAddr)
+		sender, _ := sdk.AccAddressFromBech32(change.Sender)
+		operator, _ := sdk.AccAddressFromBech32(change.Operator)
+		if err := k.setContractOperator(ctx, contractAddr, sender, operator, change.SudoMsgs, DefaultAuthorizationPolicy{}); err != nil {
+			k.Logger(ctx).Error("pending operator change dropped", "contract", change.Contract, "error", err)
+		}
+	}
+	migrations := k.dueContracts(ctx, types.PendingMigrationByHeightPrefix, height, int(k.maxTimelockedCallsPerBlock))
+	for _, contractAddr := range migrations {
+		migration := k.getPendingMigration(ctx, contractAddr)
+		k.deletePendingMigration(ctx, contractAddr)
+		sender, _ := sdk.AccAddressFromBech32(migration.Sender)
+		err := k.runWithGasLimit(ctx, func(ctx sdk.Context) error {
+			_, err := k.migrate(ctx, contractAddr, sender, migration.CodeID, migration.Msg, DefaultAuthorizationPolicy{})
+			return err
+		})
+		if err != nil {
+			k.Logger(ctx).Error("pending migration failed", "contract", migration.Contract, "error", err)
+		}
+	}
+	for _, contractAddr := range k.dueContracts(ctx, types.PendingOperatorSudoByHeightPrefix, height, int(k.maxTimelockedCallsPerBlock)-len(migrations)) {
+		call := k.getPendingOperatorSudo(ctx, contractAddr)
+		k.deletePendingOperatorSudo(ctx, contractAddr)
+		sender, _ := sdk.AccAddressFromBech32(call.Sender)
+		err := k.runWithGasLimit(ctx, func(ctx sdk.Context) error {
+			_, err := k.operatorSudo(ctx, contractAddr, sender, call.Msg, DefaultAuthorizationPolicy{})
+			return err
+		})
+		if err != nil {
+			k.Logger(ctx).Error("pending operator sudo failed", "contract", call.Contract, "error", err)
+		}
+	}
+}
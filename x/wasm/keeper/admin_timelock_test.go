@@ -0,0 +1,416 @@
+package keeper
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestAdminTimelockUpdateAdmin(t *testing.T) {
+	specs := map[string]struct {
+		srcClearAdmin bool
+		expApplied    bool
+	}{
+		"applied": {
+			expApplied: true,
+		},
+		"dropped when sender is not admin anymore": {
+			srcClearAdmin: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithAdminTimelock(10))
+			k := keepers.WasmKeeper
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			newAdmin := RandomAccountAddress(t)
+			dueHeight := ctx.BlockHeight() + 10
+			em := sdk.NewEventManager()
+
+			// when
+			_, err := NewMsgServerImpl(NewDefaultPermissionKeeper(k), k).UpdateAdmin(sdk.WrapSDKContext(ctx.WithEventManager(em)), &types.MsgUpdateAdmin{
+				Sender:   example.CreatorAddr.String(),
+				NewAdmin: newAdmin.String(),
+				Contract: example.Contract.String(),
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, example.CreatorAddr.String(), k.GetContractInfo(ctx, example.Contract).Admin)
+			expEvt := sdk.NewEvent("pending_admin_change",
+				sdk.NewAttribute("_contract_address", example.Contract.String()),
+				sdk.NewAttribute("admin", newAdmin.String()),
+				sdk.NewAttribute("height", strconv.FormatInt(dueHeight, 10)),
+			)
+			assert.Contains(t, em.Events(), expEvt)
+
+			if spec.srcClearAdmin {
+				require.NoError(t, keepers.ContractKeeper.ClearContractAdmin(ctx, example.Contract, example.CreatorAddr))
+			}
+			// and when not due yet
+			k.EndBlocker(ctx.WithBlockHeight(dueHeight - 1))
+			// then
+			assert.Len(t, collectPendingAdminChanges(ctx, k), 1)
+
+			// and when due
+			k.EndBlocker(ctx.WithBlockHeight(dueHeight))
+			// then
+			assert.Empty(t, collectPendingAdminChanges(ctx, k))
+			if spec.expApplied {
+				assert.Equal(t, newAdmin.String(), k.GetContractInfo(ctx, example.Contract).Admin)
+			} else {
+				assert.Empty(t, k.GetContractInfo(ctx, example.Contract).Admin)
+			}
+		})
+	}
+}
+
+func TestAdminTimelockMigrate(t *testing.T) {
+	specs := map[string]struct {
+		srcMigrateErr error
+		expMigrated   bool
+	}{
+		"applied": {
+			expMigrated: true,
+		},
+		"migration fails": {
+			srcMigrateErr: errors.New("testing"),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithAdminTimelock(10))
+			k := keepers.WasmKeeper
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			newCodeID := StoreRandomContract(t, ctx, keepers, &mock).CodeID
+			dueHeight := ctx.BlockHeight() + 10
+			var called bool
+			mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+				called = true
+				return &wasmvmtypes.Response{}, 0, spec.srcMigrateErr
+			}
+
+			// when
+			rsp, err := NewMsgServerImpl(NewDefaultPermissionKeeper(k), k).MigrateContract(sdk.WrapSDKContext(ctx), &types.MsgMigrateContract{
+				Sender:   example.CreatorAddr.String(),
+				Contract: example.Contract.String(),
+				CodeID:   newCodeID,
+				Msg:      []byte(`{}`),
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Nil(t, rsp.Data)
+			assert.False(t, called)
+			exp := []types.PendingMigration{{
+				Contract: example.Contract.String(),
+				Sender:   example.CreatorAddr.String(),
+				CodeID:   newCodeID,
+				Msg:      []byte(`{}`),
+				Height:   uint64(dueHeight),
+			}}
+			assert.Equal(t, exp, collectPendingMigrations(ctx, k))
+
+			// and when due
+			k.EndBlocker(ctx.WithBlockHeight(dueHeight))
+
+			// then
+			assert.True(t, called)
+			assert.Empty(t, collectPendingMigrations(ctx, k))
+			if spec.expMigrated {
+				assert.Equal(t, newCodeID, k.GetContractInfo(ctx, example.Contract).CodeID)
+			} else {
+				assert.Equal(t, example.CodeID, k.GetContractInfo(ctx, example.Contract).CodeID)
+			}
+		})
+	}
+}
+
+func TestAdminTimelockMaxCallsPerBlock(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	var migrated, sudoCalled int
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		migrated++
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		sudoCalled++
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithAdminTimelock(10), WithMaxTimelockedCallsPerBlock(2))
+	k := keepers.WasmKeeper
+	dueHeight := ctx.BlockHeight() + 10
+	for i := 0; i < 3; i++ {
+		example := SeedNewContractInstance(t, ctx, keepers, &mock)
+		gasBefore := ctx.GasMeter().GasConsumed()
+		_, err := keepers.ContractKeeper.ScheduleMigrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, []byte(`{}`))
+		require.NoError(t, err)
+		// the execution in end block is paid upfront
+		assert.GreaterOrEqual(t, ctx.GasMeter().GasConsumed()-gasBefore, DefaultCronGasLimit)
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	operator := RandomAccountAddress(t)
+	require.NoError(t, keepers.ContractKeeper.UpdateContractOperator(ctx, example.Contract, example.CreatorAddr, operator, []string{"set_params"}))
+	gasBefore := ctx.GasMeter().GasConsumed()
+	_, err := keepers.ContractKeeper.ScheduleOperatorSudo(ctx, example.Contract, operator, []byte(`{"set_params":{}}`))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, ctx.GasMeter().GasConsumed()-gasBefore, DefaultCronGasLimit)
+
+	// when due
+	k.EndBlocker(ctx.WithBlockHeight(dueHeight))
+	// then
+	assert.Equal(t, 2, migrated)
+	assert.Equal(t, 0, sudoCalled)
+	assert.Len(t, collectPendingMigrations(ctx, k), 1)
+	assert.Len(t, collectPendingOperatorSudos(ctx, k), 1)
+
+	// and when next block
+	k.EndBlocker(ctx.WithBlockHeight(dueHeight + 1))
+	// then
+	assert.Equal(t, 3, migrated)
+	assert.Equal(t, 1, sudoCalled)
+	assert.Empty(t, collectPendingMigrations(ctx, k))
+	assert.Empty(t, collectPendingOperatorSudos(ctx, k))
+}
+
+func TestAdminTimelockUpdateOperator(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithAdminTimelock(10))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	operator := RandomAccountAddress(t)
+	dueHeight := ctx.BlockHeight() + 10
+	em := sdk.NewEventManager()
+
+	// when
+	_, err := NewMsgServerImpl(NewDefaultPermissionKeeper(k), k).UpdateOperator(sdk.WrapSDKContext(ctx.WithEventManager(em)), &types.MsgUpdateOperator{
+		Sender:   example.CreatorAddr.String(),
+		Contract: example.Contract.String(),
+		Operator: operator.String(),
+		SudoMsgs: []string{"foo"},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, k.GetContractInfo(ctx, example.Contract).Operator)
+	expEvt := sdk.NewEvent("pending_operator_change",
+		sdk.NewAttribute("_contract_address", example.Contract.String()),
+		sdk.NewAttribute("operator", operator.String()),
+		sdk.NewAttribute("height", strconv.FormatInt(dueHeight, 10)),
+	)
+	assert.Contains(t, em.Events(), expEvt)
+
+	// and when not due yet
+	k.EndBlocker(ctx.WithBlockHeight(dueHeight - 1))
+	// then
+	assert.Len(t, collectPendingOperatorChanges(ctx, k), 1)
+
+	// and when due
+	k.EndBlocker(ctx.WithBlockHeight(dueHeight))
+	// then
+	assert.Empty(t, collectPendingOperatorChanges(ctx, k))
+	info := k.GetContractInfo(ctx, example.Contract)
+	assert.Equal(t, operator.String(), info.Operator)
+	assert.Equal(t, []string{"foo"}, info.OperatorSudoMsgs)
+
+	// and when removed
+	_, err = NewMsgServerImpl(NewDefaultPermissionKeeper(k), k).UpdateOperator(sdk.WrapSDKContext(ctx), &types.MsgUpdateOperator{
+		Sender:   example.CreatorAddr.String(),
+		Contract: example.Contract.String(),
+	})
+	// then it is not timelocked
+	require.NoError(t, err)
+	assert.Empty(t, k.GetContractInfo(ctx, example.Contract).Operator)
+	assert.Empty(t, collectPendingOperatorChanges(ctx, k))
+}
+
+func TestAdminTimelockOperatorSudo(t *testing.T) {
+	specs := map[string]struct {
+		srcClearOperator bool
+		expCalled        bool
+	}{
+		"executed": {
+			expCalled: true,
+		},
+		"dropped when sender is not operator anymore": {
+			srcClearOperator: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			var gotSudoMsg []byte
+			mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+				gotSudoMsg = sudoMsg
+				return &wasmvmtypes.Response{}, 0, nil
+			}
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithAdminTimelock(10))
+			k := keepers.WasmKeeper
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			operator := RandomAccountAddress(t)
+			require.NoError(t, keepers.ContractKeeper.UpdateContractOperator(ctx, example.Contract, example.CreatorAddr, operator, []string{"set_params"}))
+			dueHeight := ctx.BlockHeight() + 10
+
+			// when
+			rsp, err := NewMsgServerImpl(NewDefaultPermissionKeeper(k), k).OperatorSudo(sdk.WrapSDKContext(ctx), &types.MsgOperatorSudo{
+				Sender:   operator.String(),
+				Contract: example.Contract.String(),
+				Msg:      []byte(`{"set_params":{}}`),
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Nil(t, rsp.Data)
+			assert.Nil(t, gotSudoMsg)
+			exp := []types.PendingOperatorSudo{{
+				Contract: example.Contract.String(),
+				Sender:   operator.String(),
+				Msg:      []byte(`{"set_params":{}}`),
+				Height:   uint64(dueHeight),
+			}}
+			assert.Equal(t, exp, collectPendingOperatorSudos(ctx, k))
+
+			if spec.srcClearOperator {
+				require.NoError(t, keepers.ContractKeeper.UpdateContractOperator(ctx, example.Contract, example.CreatorAddr, nil, nil))
+			}
+			// and when due
+			k.EndBlocker(ctx.WithBlockHeight(dueHeight))
+
+			// then
+			assert.Empty(t, collectPendingOperatorSudos(ctx, k))
+			if spec.expCalled {
+				assert.Equal(t, []byte(`{"set_params":{}}`), gotSudoMsg)
+			} else {
+				assert.Nil(t, gotSudoMsg)
+			}
+		})
+	}
+}
+
+func TestScheduleContractChangesUnauthorized(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithAdminTimelock(10))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	other := RandomAccountAddress(t)
+
+	_, err := keepers.ContractKeeper.ScheduleContractAdminUpdate(ctx, example.Contract, other, other)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+	_, err = keepers.ContractKeeper.ScheduleMigrate(ctx, example.Contract, other, example.CodeID, []byte(`{}`))
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+	_, err = keepers.ContractKeeper.ScheduleContractOperatorUpdate(ctx, example.Contract, other, other, nil)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+	_, err = keepers.ContractKeeper.ScheduleOperatorSudo(ctx, example.Contract, other, []byte(`{"foo":{}}`))
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+	assert.Empty(t, collectPendingAdminChanges(ctx, k))
+	assert.Empty(t, collectPendingMigrations(ctx, k))
+	assert.Empty(t, collectPendingOperatorChanges(ctx, k))
+	assert.Empty(t, collectPendingOperatorSudos(ctx, k))
+}
+
+func TestAdminTimelockReplacePending(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithAdminTimelock(10))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	firstAdmin, secondAdmin := RandomAccountAddress(t), RandomAccountAddress(t)
+	startHeight := ctx.BlockHeight()
+
+	_, err := keepers.ContractKeeper.ScheduleContractAdminUpdate(ctx, example.Contract, example.CreatorAddr, firstAdmin)
+	require.NoError(t, err)
+	_, err = keepers.ContractKeeper.ScheduleMigrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, []byte(`{"first":{}}`))
+	require.NoError(t, err)
+
+	// when replaced in a later block
+	ctx = ctx.WithBlockHeight(startHeight + 5)
+	_, err = keepers.ContractKeeper.ScheduleContractAdminUpdate(ctx, example.Contract, example.CreatorAddr, secondAdmin)
+	require.NoError(t, err)
+	_, err = keepers.ContractKeeper.ScheduleMigrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, []byte(`{"second":{}}`))
+	require.NoError(t, err)
+
+	// then the replaced changes are not applied at their height
+	k.EndBlocker(ctx.WithBlockHeight(startHeight + 10))
+	assert.Equal(t, example.CreatorAddr.String(), k.GetContractInfo(ctx, example.Contract).Admin)
+	require.Len(t, collectPendingAdminChanges(ctx, k), 1)
+	assert.Equal(t, secondAdmin.String(), collectPendingAdminChanges(ctx, k)[0].NewAdmin)
+	require.Len(t, collectPendingMigrations(ctx, k), 1)
+	assert.Equal(t, []byte(`{"second":{}}`), collectPendingMigrations(ctx, k)[0].Msg.Bytes())
+
+	// and when the new height is reached
+	var gotMigrateMsg []byte
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		gotMigrateMsg = migrateMsg
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	k.EndBlocker(ctx.WithBlockHeight(startHeight + 15))
+	// then
+	assert.Equal(t, secondAdmin.String(), k.GetContractInfo(ctx, example.Contract).Admin)
+	assert.Nil(t, gotMigrateMsg, "second admin is not authorized for the migration of the first admin")
+	assert.Empty(t, collectPendingAdminChanges(ctx, k))
+	assert.Empty(t, collectPendingMigrations(ctx, k))
+	// and no index entries are left
+	for _, p := range [][]byte{types.PendingAdminChangeByHeightPrefix, types.PendingMigrationByHeightPrefix} {
+		iter := prefix.NewStore(ctx.KVStore(k.storeKey), p).Iterator(nil, nil)
+		assert.False(t, iter.Valid())
+		iter.Close()
+	}
+}
+
+func collectPendingAdminChanges(ctx sdk.Context, k *Keeper) []types.PendingAdminChange {
+	var r []types.PendingAdminChange
+	k.IteratePendingAdminChanges(ctx, func(change types.PendingAdminChange) bool {
+		r = append(r, change)
+		return false
+	})
+	return r
+}
+
+func collectPendingMigrations(ctx sdk.Context, k *Keeper) []types.PendingMigration {
+	var r []types.PendingMigration
+	k.IteratePendingMigrations(ctx, func(migration types.PendingMigration) bool {
+		r = append(r, migration)
+		return false
+	})
+	return r
+}
+
+func collectPendingOperatorChanges(ctx sdk.Context, k *Keeper) []types.PendingOperatorChange {
+	var r []types.PendingOperatorChange
+	k.IteratePendingOperatorChanges(ctx, func(change types.PendingOperatorChange) bool {
+		r = append(r, change)
+		return false
+	})
+	return r
+}
+
+func collectPendingOperatorSudos(ctx sdk.Context, k *Keeper) []types.PendingOperatorSudo {
+	var r []types.PendingOperatorSudo
+	k.IteratePendingOperatorSudos(ctx, func(call types.PendingOperatorSudo) bool {
+		r = append(r, call)
+		return false
+	})
+	return r
+}
@@ -4,6 +4,8 @@ import (
 	wasmvm "github.com/CosmWasm/wasmvm"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 const (
@@ -16,28 +18,70 @@ const (
 	DefaultDeserializationCostPerByte = 1
 )
 
-var (
-	costHumanize            = DefaultGasCostHumanAddress * DefaultGasMultiplier
-	costCanonical           = DefaultGasCostCanonicalAddress * DefaultGasMultiplier
-	costJSONDeserialization = wasmvmtypes.UFraction{
-		Numerator:   DefaultDeserializationCostPerByte * DefaultGasMultiplier,
+// apiCosts are the wasmvm gas costs of the address conversions
+type apiCosts struct {
+	humanize  uint64
+	canonical uint64
+}
+
+// jsonDeserializationCosts returns the wasmvm gas charged per byte when the VM deserializes JSON data.
+// It is converted with the gas multiplier of the keeper's gas register so that custom registers apply.
+func (k Keeper) jsonDeserializationCosts() wasmvmtypes.UFraction {
+	return wasmvmtypes.UFraction{
+		Numerator:   k.gasRegister.ToWasmVMGas(DefaultDeserializationCostPerByte),
 		Denominator: 1,
 	}
-)
+}
 
-func humanAddress(canon []byte) (string, uint64, error) {
-	if err := sdk.VerifyAddressFormat(canon); err != nil {
-		return "", costHumanize, err
+// addressAPICosts returns the wasmvm gas charged for the address conversions. Without custom api costs, the
+// default sdk gas costs are converted with the gas multiplier of the keeper's gas register.
+func (k Keeper) addressAPICosts() apiCosts {
+	if k.customAPICosts != nil {
+		return *k.customAPICosts
+	}
+	return apiCosts{
+		humanize:  k.gasRegister.ToWasmVMGas(DefaultGasCostHumanAddress),
+		canonical: k.gasRegister.ToWasmVMGas(DefaultGasCostCanonicalAddress),
 	}
-	return sdk.AccAddress(canon).String(), costHumanize, nil
 }
 
-func canonicalAddress(human string) ([]byte, uint64, error) {
+// cosmwasmAPI returns the address API for the VM with the costs of this keeper
+func (k Keeper) cosmwasmAPI() wasmvm.GoAPI {
+	costs := k.addressAPICosts()
+	return wasmvm.GoAPI{
+		HumanAddress: func(canon []byte) (string, uint64, error) {
+			return humanAddress(canon, costs.humanize)
+		},
+		CanonicalAddress: func(human string) ([]byte, uint64, error) {
+			return canonicalAddress(human, costs.canonical)
+		},
+	}
+}
+
+func humanAddress(canon []byte, cost uint64) (string, uint64, error) {
+	if err := verifyAPIAddressFormat(canon); err != nil {
+		return "", cost, err
+	}
+	return sdk.AccAddress(canon).String(), cost, nil
+}
+
+func canonicalAddress(human string, cost uint64) ([]byte, uint64, error) {
 	bz, err := sdk.AccAddressFromBech32(human)
-	return bz, costCanonical, err
+	if err != nil {
+		return nil, cost, err
+	}
+	if err := verifyAPIAddressFormat(bz); err != nil {
+		return nil, cost, err
+	}
+	return bz, cost, nil
 }
 
-var cosmwasmAPI = wasmvm.GoAPI{
-	HumanAddress:     humanAddress,
-	CanonicalAddress: canonicalAddress,
+// verifyAPIAddressFormat checks the length of a canonical address with the address verifier of the chain config.
+// Without a verifier, the 20 byte account and 32 byte contract addresses are accepted, as contracts store
+// canonical addresses and must not see other lengths.
+func verifyAPIAddressFormat(canon []byte) error {
+	if sdk.GetConfig().GetAddressVerifier() != nil {
+		return sdk.VerifyAddressFormat(canon)
+	}
+	return types.VerifyAddressLen()(canon)
 }
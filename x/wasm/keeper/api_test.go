@@ -0,0 +1,72 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIAddressConversion(t *testing.T) {
+	only32Bytes := func(addr []byte) error {
+		if len(addr) != 32 {
+			return sdkerrors.ErrInvalidAddress
+		}
+		return nil
+	}
+	specs := map[string]struct {
+		srcVerifier func([]byte) error
+		srcLen      int
+		expErr      bool
+	}{
+		"20 bytes without verifier": {
+			srcLen: 20,
+		},
+		"32 bytes without verifier": {
+			srcLen: 32,
+		},
+		"33 bytes without verifier": {
+			srcLen: 33,
+			expErr: true,
+		},
+		"32 bytes with chain verifier": {
+			srcVerifier: only32Bytes,
+			srcLen:      32,
+		},
+		"20 bytes rejected by chain verifier": {
+			srcVerifier: only32Bytes,
+			srcLen:      20,
+			expErr:      true,
+		},
+	}
+	cfg := sdk.GetConfig()
+	prevVerifier := cfg.GetAddressVerifier()
+	t.Cleanup(func() { cfg.SetAddressVerifier(prevVerifier) })
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cfg.SetAddressVerifier(spec.srcVerifier)
+			canon := bytes.Repeat([]byte{1}, spec.srcLen)
+			human, err := bech32.ConvertAndEncode(sdk.GetConfig().GetBech32AccountAddrPrefix(), canon)
+			require.NoError(t, err)
+
+			// when
+			gotHuman, _, humanErr := humanAddress(canon, 0)
+			gotCanon, _, canonErr := canonicalAddress(human, 0)
+
+			// then
+			if spec.expErr {
+				assert.Error(t, humanErr)
+				assert.Error(t, canonErr)
+				return
+			}
+			require.NoError(t, humanErr)
+			require.NoError(t, canonErr)
+			assert.Equal(t, human, gotHuman)
+			assert.Equal(t, canon, gotCanon)
+		})
+	}
+}
@@ -10,6 +10,7 @@ type AuthorizationPolicy interface {
 	CanCreateCode(c types.AccessConfig, creator sdk.AccAddress) bool
 	CanInstantiateContract(c types.AccessConfig, actor sdk.AccAddress) bool
 	CanModifyContract(admin, actor sdk.AccAddress) bool
+	CanOperateContract(admin, operator, actor sdk.AccAddress) bool
 }
 
 type DefaultAuthorizationPolicy struct {
@@ -27,6 +28,10 @@ func (p DefaultAuthorizationPolicy) CanModifyContract(admin, actor sdk.AccAddres
 	return admin != nil && admin.Equals(actor)
 }
 
+func (p DefaultAuthorizationPolicy) CanOperateContract(admin, operator, actor sdk.AccAddress) bool {
+	return p.CanModifyContract(admin, actor) || operator != nil && operator.Equals(actor)
+}
+
 type GovAuthorizationPolicy struct {
 }
 
@@ -41,3 +46,7 @@ func (p GovAuthorizationPolicy) CanInstantiateContract(types.AccessConfig, sdk.A
 func (p GovAuthorizationPolicy) CanModifyContract(sdk.AccAddress, sdk.AccAddress) bool {
 	return true
 }
+
+func (p GovAuthorizationPolicy) CanOperateContract(sdk.AccAddress, sdk.AccAddress, sdk.AccAddress) bool {
+	return true
+}
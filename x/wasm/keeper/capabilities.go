@@ -0,0 +1,126 @@
+package keeper
+
+import (
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// grantContractCapability adds the privileged capability to the contract. Granting it twice is a no-op.
+func (k Keeper) grantContractCapability(ctx sdk.Context, contractAddr sdk.AccAddress, capability string) error {
+	if err := types.ValidateCapability(capability); err != nil {
+		return err
+	}
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	store := ctx.KVStore(k.storeKey)
+	// store 1 byte to not run into `nil` debugging issues
+	store.Set(types.GetContractCapabilityKey(contractAddr, capability), []byte{1})
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeGrantCapability,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyCapability, capability),
+	))
+	return nil
+}
+
+// revokeContractCapability removes the privileged capability from the contract
+func (k Keeper) revokeContractCapability(ctx sdk.Context, contractAddr sdk.AccAddress, capability string) error {
+	if err := types.ValidateCapability(capability); err != nil {
+		return err
+	}
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetContractCapabilityKey(contractAddr, capability))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRevokeCapability,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyCapability, capability),
+	))
+	return nil
+}
+
+// revokeAllContractCapabilities removes all privileged capabilities from the contract
+func (k Keeper) revokeAllContractCapabilities(ctx sdk.Context, contractAddr sdk.AccAddress) {
+	for _, capability := range k.GetContractCapabilities(ctx, contractAddr) {
+		// capabilities are validated when granted
+		_ = k.revokeContractCapability(ctx, contractAddr, capability)
+	}
+}
+
+// HasContractCapability returns true when the capability was granted to the contract by governance
+func (k Keeper) HasContractCapability(ctx sdk.Context, contractAddr sdk.AccAddress, capability string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetContractCapabilityKey(contractAddr, capability))
+}
+
+// GetContractCapabilities returns the capabilities granted to the contract in lexicographic order
+func (k Keeper) GetContractCapabilities(ctx sdk.Context, contractAddr sdk.AccAddress) []string {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractCapabilitiesPrefix(contractAddr))
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+
+	var r []string
+	for ; iter.Valid(); iter.Next() {
+		r = append(r, string(iter.Key()))
+	}
+	return r
+}
+
+// IterateContractsWithCapability iterates over all contracts that were granted the capability.
+// Iteration stops when the callback returns true.
+func (k Keeper) IterateContractsWithCapability(ctx sdk.Context, capability string, cb func(sdk.AccAddress) bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.ContractCapabilityPrefix)
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		// key: <contractAddress length><contractAddress><capability>
+		key := iter.Key()
+		addrLen := int(key[0])
+		if string(key[1+addrLen:]) != capability {
+			continue
+		}
+		if cb(key[1 : 1+addrLen]) {
+			return
+		}
+	}
+}
+
+// CapabilityRequirement returns the capability that a contract must be granted to dispatch the message.
+// An empty string is returned for messages that are not privileged.
+type CapabilityRequirement func(msg wasmvmtypes.CosmosMsg) string
+
+// capabilityChecker is a subset of the keeper to check the capabilities of a contract
+type capabilityChecker interface {
+	HasContractCapability(ctx sdk.Context, contractAddr sdk.AccAddress, capability string) bool
+}
+
+// CapabilityMessageHandler is a decorator that rejects privileged messages from contracts without the
+// required capability before they are dispatched to the nested message handler
+type CapabilityMessageHandler struct {
+	nested      Messenger
+	checker     capabilityChecker
+	requirement CapabilityRequirement
+}
+
+// NewCapabilityMessageHandler constructor
+func NewCapabilityMessageHandler(nested Messenger, checker capabilityChecker, requirement CapabilityRequirement) CapabilityMessageHandler {
+	return CapabilityMessageHandler{nested: nested, checker: checker, requirement: requirement}
+}
+
+// DispatchMsg checks the capability that is required for the message before it is passed to the nested handler
+func (h CapabilityMessageHandler) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+	if capability := h.requirement(msg); capability != "" && !h.checker.HasContractCapability(ctx, contractAddr, capability) {
+		return nil, nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "contract requires capability: %s", capability)
+	}
+	return h.nested.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
+}
@@ -0,0 +1,156 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractCapabilities(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	example1 := SeedNewContractInstance(t, ctx, keepers, &mock)
+	example2 := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	// when granted
+	em := sdk.NewEventManager()
+	require.NoError(t, k.grantContractCapability(ctx.WithEventManager(em), example1.Contract, "mint"))
+	require.NoError(t, k.grantContractCapability(ctx, example1.Contract, "gov_hooks"))
+	require.NoError(t, k.grantContractCapability(ctx, example2.Contract, "mint"))
+
+	// then
+	assert.True(t, k.HasContractCapability(ctx, example1.Contract, "mint"))
+	assert.False(t, k.HasContractCapability(ctx, example2.Contract, "gov_hooks"))
+	assert.Equal(t, []string{"gov_hooks", "mint"}, k.GetContractCapabilities(ctx, example1.Contract))
+	exp := sdk.Events{sdk.NewEvent("grant_contract_capability",
+		sdk.NewAttribute("_contract_address", example1.Contract.String()),
+		sdk.NewAttribute("capability", "mint"),
+	)}
+	assert.Equal(t, exp, em.Events())
+	assert.ElementsMatch(t, []sdk.AccAddress{example1.Contract, example2.Contract}, collectContractsWithCapability(ctx, k, "mint"))
+	assert.Equal(t, []sdk.AccAddress{example1.Contract}, collectContractsWithCapability(ctx, k, "gov_hooks"))
+
+	// when revoked
+	require.NoError(t, k.revokeContractCapability(ctx, example1.Contract, "mint"))
+
+	// then
+	assert.False(t, k.HasContractCapability(ctx, example1.Contract, "mint"))
+	assert.Equal(t, []string{"gov_hooks"}, k.GetContractCapabilities(ctx, example1.Contract))
+	assert.Equal(t, []sdk.AccAddress{example2.Contract}, collectContractsWithCapability(ctx, k, "mint"))
+
+	// and unknown contracts or invalid names are rejected
+	err := k.grantContractCapability(ctx, RandomAccountAddress(t), "mint")
+	assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+	err = k.grantContractCapability(ctx, example1.Contract, "Mint")
+	assert.True(t, types.ErrInvalid.Is(err), "got %+v", err)
+}
+
+func TestMigrateRevokesContractCapabilities(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	other := SeedNewContractInstance(t, ctx, keepers, &mock)
+	newCodeID := StoreRandomContract(t, ctx, keepers, &mock).CodeID
+	require.NoError(t, k.grantContractCapability(ctx, example.Contract, "mint"))
+	require.NoError(t, k.grantContractCapability(ctx, example.Contract, "gov_hooks"))
+	require.NoError(t, k.grantContractCapability(ctx, other.Contract, "mint"))
+	em := sdk.NewEventManager()
+
+	// when
+	_, err := keepers.ContractKeeper.Migrate(ctx.WithEventManager(em), example.Contract, example.CreatorAddr, newCodeID, []byte(`{}`))
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, k.GetContractCapabilities(ctx, example.Contract))
+	assert.Equal(t, []string{"mint"}, k.GetContractCapabilities(ctx, other.Contract))
+	for _, c := range []string{"gov_hooks", "mint"} {
+		assert.Contains(t, em.Events(), sdk.NewEvent("revoke_contract_capability",
+			sdk.NewAttribute("_contract_address", example.Contract.String()),
+			sdk.NewAttribute("capability", c),
+		))
+	}
+}
+
+func TestCapabilityMessageHandler(t *testing.T) {
+	mintMsg := wasmvmtypes.CosmosMsg{Custom: []byte(`{"mint":{}}`)}
+	bankMsg := wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Burn: &wasmvmtypes.BurnMsg{}}}
+	requirement := func(msg wasmvmtypes.CosmosMsg) string {
+		if msg.Custom != nil {
+			return "mint"
+		}
+		return ""
+	}
+	specs := map[string]struct {
+		srcMsg       wasmvmtypes.CosmosMsg
+		srcGranted   bool
+		expErr       *sdkerrors.Error
+		expForwarded bool
+	}{
+		"privileged msg with capability": {
+			srcMsg:       mintMsg,
+			srcGranted:   true,
+			expForwarded: true,
+		},
+		"privileged msg without capability": {
+			srcMsg: mintMsg,
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"other msg without capability": {
+			srcMsg:       bankMsg,
+			expForwarded: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			k := keepers.WasmKeeper
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			if spec.srcGranted {
+				require.NoError(t, k.grantContractCapability(ctx, example.Contract, "mint"))
+			}
+			nested, gotMsgs := wasmtesting.NewCapturingMessageHandler()
+			h := NewCapabilityMessageHandler(nested, k, requirement)
+
+			// when
+			_, _, gotErr := h.DispatchMsg(ctx, example.Contract, "", spec.srcMsg)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+			} else {
+				require.NoError(t, gotErr)
+			}
+			if spec.expForwarded {
+				assert.Equal(t, []wasmvmtypes.CosmosMsg{spec.srcMsg}, *gotMsgs)
+			} else {
+				assert.Empty(t, *gotMsgs)
+			}
+		})
+	}
+}
+
+func collectContractsWithCapability(ctx sdk.Context, k *Keeper, capability string) []sdk.AccAddress {
+	var r []sdk.AccAddress
+	k.IterateContractsWithCapability(ctx, capability, func(addr sdk.AccAddress) bool {
+		r = append(r, addr)
+		return false
+	})
+	return r
+}
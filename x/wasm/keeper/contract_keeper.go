@@ -11,14 +11,33 @@ var _ types.ContractOpsKeeper = PermissionedKeeper{}
 // decoratedKeeper contains a subset of the wasm keeper that are already or can be guarded by an authorization policy in the future
 type decoratedKeeper interface {
 	create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ AuthorizationPolicy) (codeID uint64, err error)
-	instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error)
+	instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, addressGenerator AddressGenerator, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error)
+	ClassicAddressGenerator() AddressGenerator
 	migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) ([]byte, error)
 	setContractAdmin(ctx sdk.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ AuthorizationPolicy) error
+	scheduleAdminChange(ctx sdk.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ AuthorizationPolicy) (uint64, error)
+	scheduleMigration(ctx sdk.Context, contractAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) (uint64, error)
 	pinCode(ctx sdk.Context, codeID uint64) error
 	unpinCode(ctx sdk.Context, codeID uint64) error
+	pruneCode(ctx sdk.Context, codeID uint64) error
+	grantContractCapability(ctx sdk.Context, contractAddr sdk.AccAddress, capability string) error
+	revokeContractCapability(ctx sdk.Context, contractAddr sdk.AccAddress, capability string) error
+	deactivateContract(ctx sdk.Context, contractAddr sdk.AccAddress) error
+	activateContract(ctx sdk.Context, contractAddr sdk.AccAddress) error
+	registerCronContract(ctx sdk.Context, contractAddr sdk.AccAddress) error
+	unregisterCronContract(ctx sdk.Context, contractAddr sdk.AccAddress) error
+	scheduleContractCall(ctx sdk.Context, contractAddr sdk.AccAddress, msg []byte, height, time uint64) (uint64, error)
+	depositRent(ctx sdk.Context, sender, contractAddr sdk.AccAddress, amount sdk.Coin) error
+	setExecuteGasLimit(ctx sdk.Context, contractAddress, caller sdk.AccAddress, gasLimit uint64, authZ AuthorizationPolicy) error
+	setContractOperator(ctx sdk.Context, contractAddress, caller, operator sdk.AccAddress, sudoMsgs []string, authZ AuthorizationPolicy) error
+	setContractLabel(ctx sdk.Context, contractAddress, caller sdk.AccAddress, label string, authZ AuthorizationPolicy) error
+	operatorSudo(ctx sdk.Context, contractAddress, caller sdk.AccAddress, msg []byte, authZ AuthorizationPolicy) ([]byte, error)
+	scheduleOperatorChange(ctx sdk.Context, contractAddress, caller, operator sdk.AccAddress, sudoMsgs []string, authZ AuthorizationPolicy) (uint64, error)
+	scheduleOperatorSudo(ctx sdk.Context, contractAddress, caller sdk.AccAddress, msg []byte, authZ AuthorizationPolicy) (uint64, error)
 	execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error)
 	Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error)
 	setContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra types.ContractInfoExtension) error
+	setCodeSource(ctx sdk.Context, codeID uint64, caller sdk.AccAddress, source, builder string) error
 }
 
 type PermissionedKeeper struct {
@@ -43,7 +62,7 @@ func (p PermissionedKeeper) Create(ctx sdk.Context, creator sdk.AccAddress, wasm
 }
 
 func (p PermissionedKeeper) Instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins) (sdk.AccAddress, []byte, error) {
-	return p.nested.instantiate(ctx, codeID, creator, admin, initMsg, label, deposit, p.authZPolicy)
+	return p.nested.instantiate(ctx, codeID, creator, admin, initMsg, label, deposit, p.nested.ClassicAddressGenerator(), p.authZPolicy)
 }
 
 func (p PermissionedKeeper) Execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
@@ -54,6 +73,11 @@ func (p PermissionedKeeper) Migrate(ctx sdk.Context, contractAddress sdk.AccAddr
 	return p.nested.migrate(ctx, contractAddress, caller, newCodeID, msg, p.authZPolicy)
 }
 
+// ScheduleMigrate stores a migration that takes effect after the admin timelock
+func (p PermissionedKeeper) ScheduleMigrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte) (uint64, error) {
+	return p.nested.scheduleMigration(ctx, contractAddress, caller, newCodeID, msg, p.authZPolicy)
+}
+
 func (p PermissionedKeeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
 	return p.nested.Sudo(ctx, contractAddress, msg)
 }
@@ -62,6 +86,11 @@ func (p PermissionedKeeper) UpdateContractAdmin(ctx sdk.Context, contractAddress
 	return p.nested.setContractAdmin(ctx, contractAddress, caller, newAdmin, p.authZPolicy)
 }
 
+// ScheduleContractAdminUpdate stores an admin change that takes effect after the admin timelock
+func (p PermissionedKeeper) ScheduleContractAdminUpdate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newAdmin sdk.AccAddress) (uint64, error) {
+	return p.nested.scheduleAdminChange(ctx, contractAddress, caller, newAdmin, p.authZPolicy)
+}
+
 func (p PermissionedKeeper) ClearContractAdmin(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress) error {
 	return p.nested.setContractAdmin(ctx, contractAddress, caller, nil, p.authZPolicy)
 }
@@ -74,7 +103,87 @@ func (p PermissionedKeeper) UnpinCode(ctx sdk.Context, codeID uint64) error {
 	return p.nested.unpinCode(ctx, codeID)
 }
 
-// SetExtraContractAttributes updates the extra attributes that can be stored with the contract info
+// PruneCode marks an unused code id as pruned so that it can not be used anymore
+func (p PermissionedKeeper) PruneCode(ctx sdk.Context, codeID uint64) error {
+	return p.nested.pruneCode(ctx, codeID)
+}
+
+// GrantContractCapability grants a privileged capability to the contract
+func (p PermissionedKeeper) GrantContractCapability(ctx sdk.Context, contractAddr sdk.AccAddress, capability string) error {
+	return p.nested.grantContractCapability(ctx, contractAddr, capability)
+}
+
+// RevokeContractCapability removes a privileged capability from the contract
+func (p PermissionedKeeper) RevokeContractCapability(ctx sdk.Context, contractAddr sdk.AccAddress, capability string) error {
+	return p.nested.revokeContractCapability(ctx, contractAddr, capability)
+}
+
+// DeactivateContract marks the contract inactive
+func (p PermissionedKeeper) DeactivateContract(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	return p.nested.deactivateContract(ctx, contractAddr)
+}
+
+// ActivateContract removes the inactive mark from the contract
+func (p PermissionedKeeper) ActivateContract(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	return p.nested.activateContract(ctx, contractAddr)
+}
+
+// RegisterCronContract adds the contract to the set of contracts that are called in begin and end block
+func (p PermissionedKeeper) RegisterCronContract(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	return p.nested.registerCronContract(ctx, contractAddr)
+}
+
+// UnregisterCronContract removes the contract from the set of contracts that are called in begin and end block
+func (p PermissionedKeeper) UnregisterCronContract(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	return p.nested.unregisterCronContract(ctx, contractAddr)
+}
+
+// ScheduleContractCall stores a message that is delivered to the sudo entry point of the contract in a future block
+func (p PermissionedKeeper) ScheduleContractCall(ctx sdk.Context, contractAddr sdk.AccAddress, msg []byte, height, time uint64) (uint64, error) {
+	return p.nested.scheduleContractCall(ctx, contractAddr, msg, height, time)
+}
+
+// DepositRent adds the amount to the state rent deposit of the contract
+func (p PermissionedKeeper) DepositRent(ctx sdk.Context, sender, contractAddr sdk.AccAddress, amount sdk.Coin) error {
+	return p.nested.depositRent(ctx, sender, contractAddr, amount)
+}
+
+// UpdateExecuteGasLimit sets the max gas for a single execution of the contract
+func (p PermissionedKeeper) UpdateExecuteGasLimit(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, gasLimit uint64) error {
+	return p.nested.setExecuteGasLimit(ctx, contractAddress, caller, gasLimit, p.authZPolicy)
+}
+
+// UpdateContractOperator sets the operator of the contract and the sudo messages it is allowed to send
+func (p PermissionedKeeper) UpdateContractOperator(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, operator sdk.AccAddress, sudoMsgs []string) error {
+	return p.nested.setContractOperator(ctx, contractAddress, caller, operator, sudoMsgs, p.authZPolicy)
+}
+
+// ScheduleContractOperatorUpdate stores an operator change that takes effect after the admin timelock
+func (p PermissionedKeeper) ScheduleContractOperatorUpdate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, operator sdk.AccAddress, sudoMsgs []string) (uint64, error) {
+	return p.nested.scheduleOperatorChange(ctx, contractAddress, caller, operator, sudoMsgs, p.authZPolicy)
+}
+
+// UpdateContractLabel sets a new label for the contract
+func (p PermissionedKeeper) UpdateContractLabel(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, label string) error {
+	return p.nested.setContractLabel(ctx, contractAddress, caller, label, p.authZPolicy)
+}
+
+// OperatorSudo calls the sudo entry point of the contract with a message that is allowed for the operator
+func (p PermissionedKeeper) OperatorSudo(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte) ([]byte, error) {
+	return p.nested.operatorSudo(ctx, contractAddress, caller, msg, p.authZPolicy)
+}
+
+// ScheduleOperatorSudo stores an operator sudo call that is executed after the admin timelock
+func (p PermissionedKeeper) ScheduleOperatorSudo(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte) (uint64, error) {
+	return p.nested.scheduleOperatorSudo(ctx, contractAddress, caller, msg, p.authZPolicy)
+}
+
+// SetContractInfoExtension updates the extension point data that is stored with the contract info
 func (p PermissionedKeeper) SetContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra types.ContractInfoExtension) error {
 	return p.nested.setContractInfoExtension(ctx, contract, extra)
 }
+
+// SetCodeSource stores the source url and builder image with the code info
+func (p PermissionedKeeper) SetCodeSource(ctx sdk.Context, codeID uint64, caller sdk.AccAddress, source, builder string) error {
+	return p.nested.setCodeSource(ctx, codeID, caller, source, builder)
+}
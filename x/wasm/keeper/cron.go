@@ -0,0 +1,135 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// DefaultCronGasLimit is the default max gas for a single begin or end block call to a contract.
+const DefaultCronGasLimit uint64 = 1_000_000
+
+var (
+	// beginBlockSudoMsg is sent to the sudo entry point of cron contracts in begin block
+	beginBlockSudoMsg = []byte(`{"begin_block":{}}`)
+	// endBlockSudoMsg is sent to the sudo entry point of cron contracts in end block
+	endBlockSudoMsg = []byte(`{"end_block":{}}`)
+)
+
+// registerCronContract adds the contract to the set of contracts that are called in begin and end block
+func (k Keeper) registerCronContract(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	store := ctx.KVStore(k.storeKey)
+	// store 1 byte to not run into `nil` debugging issues
+	store.Set(types.GetCronContractKey(contractAddr), []byte{1})
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRegisterCron,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+	))
+	return nil
+}
+
+// unregisterCronContract removes the contract from the set of contracts that are called in begin and end block
+func (k Keeper) unregisterCronContract(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetCronContractKey(contractAddr))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUnregisterCron,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+	))
+	return nil
+}
+
+// IsCronContract returns true when the contract was registered by governance to be called in begin and end block
+func (k Keeper) IsCronContract(ctx sdk.Context, contractAddr sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetCronContractKey(contractAddr))
+}
+
+// IterateCronContracts iterates over all cron contracts. Iteration stops when the callback returns true.
+func (k Keeper) IterateCronContracts(ctx sdk.Context, cb func(sdk.AccAddress) bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.CronContractPrefix)
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if cb(iter.Key()) {
+			return
+		}
+	}
+}
+
+// BeginBlocker calls the sudo entry point of all cron contracts with a `begin_block` message
+func (k Keeper) BeginBlocker(ctx sdk.Context) {
+	k.callCronContracts(ctx, beginBlockSudoMsg)
+}
+
+// EndBlocker calls the sudo entry point of all cron contracts with an `end_block` message,
+// delivers the deferred calls and applies the timelocked contract changes that are due and
+// charges the state rent when enabled.
+func (k Keeper) EndBlocker(ctx sdk.Context) {
+	k.callCronContracts(ctx, endBlockSudoMsg)
+	k.deliverDeferredCalls(ctx)
+	k.applyPendingContractChanges(ctx)
+	k.chargeStateRent(ctx)
+}
+
+func (k Keeper) callCronContracts(ctx sdk.Context, msg []byte) {
+	var contracts []sdk.AccAddress
+	// collect first to not write into the store while iterating
+	k.IterateCronContracts(ctx, func(contractAddr sdk.AccAddress) bool {
+		contracts = append(contracts, contractAddr)
+		return false
+	})
+	for _, contractAddr := range contracts {
+		if k.IsInactiveContract(ctx, contractAddr) {
+			continue
+		}
+		if err := k.sudoWithGasLimit(ctx, contractAddr, msg); err != nil {
+			// a failing contract must not halt the chain, the state changes of the call are discarded
+			k.Logger(ctx).Error("cron contract call failed", "contract", contractAddr.String(), "error", err)
+		}
+	}
+}
+
+// sudoWithGasLimit calls the sudo entry point of the contract with the cron gas limit applied.
+// The state changes and events are only committed when the call succeeds.
+func (k Keeper) sudoWithGasLimit(ctx sdk.Context, contractAddr sdk.AccAddress, msg []byte) error {
+	return k.runWithGasLimit(ctx, func(ctx sdk.Context) error {
+		_, err := k.Sudo(ctx, contractAddr, msg)
+		return err
+	})
+}
+
+// runWithGasLimit runs the callback in a cached context with the cron gas limit applied.
+// The state changes and events are only committed when the callback succeeds.
+func (k Keeper) runWithGasLimit(ctx sdk.Context, cb func(sdk.Context) error) (err error) {
+	cacheCtx, commit := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(sdk.NewGasMeter(k.cronGasLimit))
+
+	// catch out of gas panic
+	defer func() {
+		if r := recover(); r != nil {
+			// if it's not an OutOfGas error, raise it again
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			err = sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "call hit gas limit")
+		}
+	}()
+	if err = cb(cacheCtx); err != nil {
+		return err
+	}
+	commit()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return nil
+}
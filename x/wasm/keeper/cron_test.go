@@ -0,0 +1,128 @@
+package keeper
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestCallCronContracts(t *testing.T) {
+	var (
+		myKey   = []byte("foo")
+		myValue = []byte("bar")
+	)
+	specs := map[string]struct {
+		srcBlocker    func(k Keeper, ctx sdk.Context)
+		srcRegistered bool
+		srcInactive   bool
+		srcErr        error
+		srcGasUsed    uint64
+		expMsg        []byte
+		expStored     bool
+	}{
+		"begin block": {
+			srcBlocker:    Keeper.BeginBlocker,
+			srcRegistered: true,
+			expMsg:        []byte(`{"begin_block":{}}`),
+			expStored:     true,
+		},
+		"end block": {
+			srcBlocker:    Keeper.EndBlocker,
+			srcRegistered: true,
+			expMsg:        []byte(`{"end_block":{}}`),
+			expStored:     true,
+		},
+		"contract fails": {
+			srcBlocker:    Keeper.EndBlocker,
+			srcRegistered: true,
+			srcErr:        errors.New("testing"),
+			expMsg:        []byte(`{"end_block":{}}`),
+		},
+		"out of gas": {
+			srcBlocker:    Keeper.EndBlocker,
+			srcRegistered: true,
+			srcGasUsed:    math.MaxUint64,
+			expMsg:        []byte(`{"end_block":{}}`),
+		},
+		"inactive contract": {
+			srcBlocker:    Keeper.EndBlocker,
+			srcRegistered: true,
+			srcInactive:   true,
+		},
+		"not registered": {
+			srcBlocker: Keeper.EndBlocker,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			k := keepers.WasmKeeper
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			if spec.srcRegistered {
+				require.NoError(t, k.registerCronContract(ctx, example.Contract))
+			}
+			if spec.srcInactive {
+				require.NoError(t, k.deactivateContract(ctx, example.Contract))
+			}
+			var gotMsg []byte
+			mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+				gotMsg = sudoMsg
+				store.Set(myKey, myValue)
+				return &wasmvmtypes.Response{}, spec.srcGasUsed, spec.srcErr
+			}
+
+			// when
+			spec.srcBlocker(*k, ctx)
+
+			// then
+			assert.Equal(t, spec.expMsg, gotMsg)
+			if spec.expStored {
+				assert.Equal(t, myValue, k.QueryRaw(ctx, example.Contract, myKey))
+			} else {
+				assert.Nil(t, k.QueryRaw(ctx, example.Contract, myKey))
+			}
+		})
+	}
+}
+
+func TestRegisterCronContract(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	// when
+	em := sdk.NewEventManager()
+	require.NoError(t, k.registerCronContract(ctx.WithEventManager(em), example.Contract))
+
+	// then
+	assert.True(t, k.IsCronContract(ctx, example.Contract))
+	var got []sdk.AccAddress
+	k.IterateCronContracts(ctx, func(contractAddr sdk.AccAddress) bool {
+		got = append(got, contractAddr)
+		return false
+	})
+	assert.Equal(t, []sdk.AccAddress{example.Contract}, got)
+	exp := sdk.Events{sdk.NewEvent("register_cron_contract", sdk.NewAttribute("_contract_address", example.Contract.String()))}
+	assert.Equal(t, exp, em.Events())
+
+	// and when unregistered
+	require.NoError(t, k.unregisterCronContract(ctx, example.Contract))
+
+	// then
+	assert.False(t, k.IsCronContract(ctx, example.Contract))
+
+	// and unknown contracts are rejected
+	err := k.registerCronContract(ctx, RandomAccountAddress(t))
+	assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+}
@@ -0,0 +1,219 @@
+package keeper
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	// DefaultMaxDeferredCallsPerBlock is the default max number of deferred calls that are delivered in a single
+	// block. Calls that are due by height and by time share this limit, see deliverDeferredCalls.
+	DefaultMaxDeferredCallsPerBlock uint32 = 100
+	// DefaultMaxDeferredCallsPerContract is the default max number of pending deferred calls of a single contract
+	DefaultMaxDeferredCallsPerContract uint32 = 10
+)
+
+// deferredCallSudoMsg is the message that is sent to the sudo entry point of the contract for a deferred call.
+// The envelope ensures that a scheduled payload can not be confused with any other sudo message of the contract.
+type deferredCallSudoMsg struct {
+	DeferredCall deferredCallPayload `json:"deferred_call"`
+}
+
+type deferredCallPayload struct {
+	ID  uint64          `json:"id"`
+	Msg json.RawMessage `json:"msg"`
+}
+
+// scheduleContractCall stores a message that is delivered to the sudo entry point of the contract at the given
+// block height or time. Either height or time must be set and be in the future.
+// The cron gas limit for the execution is charged upfront as the call is delivered in end block where nobody
+// pays for gas. As this equals the gas limit of the delivery, a deferred call can not schedule a new call itself.
+// A contract can not have more than the max deferred calls per contract pending.
+func (k Keeper) scheduleContractCall(ctx sdk.Context, contractAddr sdk.AccAddress, msg []byte, height, time uint64) (uint64, error) {
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return 0, sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	if n := k.pendingDeferredCalls(ctx, contractAddr); n >= uint64(k.maxDeferredCallsPerContract) {
+		return 0, sdkerrors.Wrapf(types.ErrInvalid, "max %d pending deferred calls per contract", k.maxDeferredCallsPerContract)
+	}
+	if height != 0 && height <= uint64(ctx.BlockHeight()) {
+		return 0, sdkerrors.Wrap(types.ErrInvalid, "height must be in the future")
+	}
+	if time != 0 && time <= uint64(ctx.BlockTime().UnixNano()) {
+		return 0, sdkerrors.Wrap(types.ErrInvalid, "time must be in the future")
+	}
+	call := types.DeferredCall{
+		ID:       k.autoIncrementID(ctx, types.KeyLastDeferredCallID),
+		Contract: contractAddr.String(),
+		Msg:      msg,
+		Height:   height,
+		Time:     time,
+	}
+	if err := call.ValidateBasic(); err != nil {
+		return 0, err
+	}
+	ctx.GasMeter().ConsumeGas(k.cronGasLimit, "deferred contract call")
+	k.storeDeferredCall(ctx, contractAddr, call)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeScheduleCall,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, call.Contract),
+		sdk.NewAttribute(types.AttributeKeyDeferredID, strconv.FormatUint(call.ID, 10)),
+	))
+	return call.ID, nil
+}
+
+// importDeferredCall stores a deferred call from genesis
+func (k Keeper) importDeferredCall(ctx sdk.Context, call types.DeferredCall) error {
+	if err := call.ValidateBasic(); err != nil {
+		return err
+	}
+	if store := ctx.KVStore(k.storeKey); store.Has(deferredCallKey(call)) {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "deferred call id: %d", call.ID)
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(call.Contract)
+	if err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	k.storeDeferredCall(ctx, contractAddr, call)
+	return nil
+}
+
+func (k Keeper) storeDeferredCall(ctx sdk.Context, contractAddr sdk.AccAddress, call types.DeferredCall) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(deferredCallKey(call), k.cdc.MustMarshal(&call))
+	k.setPendingDeferredCalls(ctx, contractAddr, k.pendingDeferredCalls(ctx, contractAddr)+1)
+}
+
+// deleteDeferredCall removes the call from the queue
+func (k Keeper) deleteDeferredCall(ctx sdk.Context, contractAddr sdk.AccAddress, call types.DeferredCall) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(deferredCallKey(call))
+	if n := k.pendingDeferredCalls(ctx, contractAddr); n > 0 {
+		k.setPendingDeferredCalls(ctx, contractAddr, n-1)
+	}
+}
+
+// pendingDeferredCalls returns the number of deferred calls of the contract that were not delivered yet
+func (k Keeper) pendingDeferredCalls(ctx sdk.Context, contractAddr sdk.AccAddress) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetDeferredCallCountKey(contractAddr))
+	if bz == nil {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+func (k Keeper) setPendingDeferredCalls(ctx sdk.Context, contractAddr sdk.AccAddress, n uint64) {
+	store := ctx.KVStore(k.storeKey)
+	if n == 0 {
+		store.Delete(types.GetDeferredCallCountKey(contractAddr))
+		return
+	}
+	store.Set(types.GetDeferredCallCountKey(contractAddr), sdk.Uint64ToBigEndian(n))
+}
+
+// IterateDeferredCalls iterates over all deferred calls that were not delivered yet. This includes calls that are
+// due but were moved to a later block by the max deferred calls per block. The calls due by height are returned
+// first, ordered by height and id, followed by the calls due by time, ordered by time and id.
+// Iteration stops when the callback returns true.
+func (k Keeper) IterateDeferredCalls(ctx sdk.Context, cb func(types.DeferredCall) bool) {
+	for _, p := range [][]byte{types.DeferredCallByHeightPrefix, types.DeferredCallByTimePrefix} {
+		if k.iterateDeferredCalls(ctx, p, nil, cb) {
+			return
+		}
+	}
+}
+
+// iterateDeferredCalls iterates over the queue with the given prefix up to the optional end key.
+// It returns true when the iteration was stopped by the callback.
+func (k Keeper) iterateDeferredCalls(ctx sdk.Context, queuePrefix []byte, end []byte, cb func(types.DeferredCall) bool) bool {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), queuePrefix)
+	iter := store.Iterator(nil, end)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var call types.DeferredCall
+		k.cdc.MustUnmarshal(iter.Value(), &call)
+		if cb(call) {
+			return true
+		}
+	}
+	return false
+}
+
+// deliverDeferredCalls sends the deferred calls that are due to the sudo entry point of the contracts. Not more
+// than the max deferred calls per block are delivered, the others stay in the queue for the next blocks. The limit
+// is shared between the calls due by height and the calls due by time so that one queue can not starve the other:
+// each queue gets half of it and any share that is not used by one queue goes to the other.
+// Delivered calls are removed from the queue, a failing call is not retried.
+func (k Keeper) deliverDeferredCalls(ctx sdk.Context) {
+	max := int(k.maxDeferredCallsPerBlock)
+	if max == 0 {
+		return
+	}
+	// collect first to not write into the store while iterating
+	heightEnd := sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight()) + 1)
+	byHeight := k.collectDueDeferredCalls(ctx, types.DeferredCallByHeightPrefix, heightEnd, max)
+	timeEnd := sdk.Uint64ToBigEndian(uint64(ctx.BlockTime().UnixNano()) + 1)
+	byTime := k.collectDueDeferredCalls(ctx, types.DeferredCallByTimePrefix, timeEnd, max)
+
+	heightShare := max - max/2
+	if len(byHeight) < heightShare {
+		heightShare = len(byHeight)
+	}
+	nTime := len(byTime)
+	if nTime > max-heightShare {
+		nTime = max - heightShare
+	}
+	nHeight := len(byHeight)
+	if nHeight > max-nTime {
+		nHeight = max - nTime
+	}
+
+	for _, call := range append(byHeight[:nHeight], byTime[:nTime]...) {
+		contractAddr, err := sdk.AccAddressFromBech32(call.Contract)
+		if err != nil { // should never happen as calls are validated before they are stored
+			k.Logger(ctx).Error("deferred call with invalid contract address", "id", call.ID, "error", err)
+			ctx.KVStore(k.storeKey).Delete(deferredCallKey(call))
+			continue
+		}
+		k.deleteDeferredCall(ctx, contractAddr, call)
+		if k.IsInactiveContract(ctx, contractAddr) {
+			k.Logger(ctx).Info("deferred call to inactive contract dropped", "id", call.ID, "contract", call.Contract)
+			continue
+		}
+		msg, err := json.Marshal(deferredCallSudoMsg{DeferredCall: deferredCallPayload{ID: call.ID, Msg: json.RawMessage(call.Msg)}})
+		if err != nil { // should never happen as the payload is validated before it is stored
+			k.Logger(ctx).Error("deferred call with invalid payload", "id", call.ID, "error", err)
+			continue
+		}
+		if err := k.sudoWithGasLimit(ctx, contractAddr, msg); err != nil {
+			// a failing contract must not halt the chain, the state changes of the call are discarded
+			k.Logger(ctx).Error("deferred contract call failed", "id", call.ID, "contract", call.Contract, "error", err)
+		}
+	}
+}
+
+// collectDueDeferredCalls returns up to max calls from the queue with the given prefix up to the end key
+func (k Keeper) collectDueDeferredCalls(ctx sdk.Context, queuePrefix []byte, end []byte, max int) []types.DeferredCall {
+	var calls []types.DeferredCall
+	k.iterateDeferredCalls(ctx, queuePrefix, end, func(call types.DeferredCall) bool {
+		calls = append(calls, call)
+		return len(calls) >= max
+	})
+	return calls
+}
+
+// deferredCallKey returns the queue key of the call by height or by time
+func deferredCallKey(call types.DeferredCall) []byte {
+	if call.Height != 0 {
+		return types.GetDeferredCallByHeightKey(call.Height, call.ID)
+	}
+	return types.GetDeferredCallByTimeKey(call.Time, call.ID)
+}
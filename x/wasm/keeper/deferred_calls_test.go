@@ -0,0 +1,259 @@
+package keeper
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestScheduleContractCall(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	now := uint64(ctx.BlockTime().UnixNano())
+	height := uint64(ctx.BlockHeight())
+
+	specs := map[string]struct {
+		srcContract sdk.AccAddress
+		srcHeight   uint64
+		srcTime     uint64
+		expErr      *sdkerrors.Error
+	}{
+		"by height": {
+			srcContract: example.Contract,
+			srcHeight:   height + 1,
+		},
+		"by time": {
+			srcContract: example.Contract,
+			srcTime:     now + 1,
+		},
+		"current height": {
+			srcContract: example.Contract,
+			srcHeight:   height,
+			expErr:      types.ErrInvalid,
+		},
+		"current time": {
+			srcContract: example.Contract,
+			srcTime:     now,
+			expErr:      types.ErrInvalid,
+		},
+		"height and time": {
+			srcContract: example.Contract,
+			srcHeight:   height + 1,
+			srcTime:     now + 1,
+			expErr:      types.ErrInvalid,
+		},
+		"unknown contract": {
+			srcContract: RandomAccountAddress(t),
+			srcHeight:   height + 1,
+			expErr:      types.ErrNotFound,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			tCtx, _ := ctx.CacheContext()
+			em := sdk.NewEventManager()
+			gasBefore := tCtx.GasMeter().GasConsumed()
+			// when
+			gotID, gotErr := k.scheduleContractCall(tCtx.WithEventManager(em), spec.srcContract, []byte(`{}`), spec.srcHeight, spec.srcTime)
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, uint64(1), gotID)
+			assert.GreaterOrEqual(t, tCtx.GasMeter().GasConsumed()-gasBefore, DefaultCronGasLimit)
+			var got []types.DeferredCall
+			k.IterateDeferredCalls(tCtx, func(call types.DeferredCall) bool {
+				got = append(got, call)
+				return false
+			})
+			exp := []types.DeferredCall{{
+				ID:       1,
+				Contract: spec.srcContract.String(),
+				Msg:      []byte(`{}`),
+				Height:   spec.srcHeight,
+				Time:     spec.srcTime,
+			}}
+			assert.Equal(t, exp, got)
+			expEvts := sdk.Events{sdk.NewEvent("schedule_contract_call",
+				sdk.NewAttribute("_contract_address", spec.srcContract.String()),
+				sdk.NewAttribute("deferred_call_id", "1"),
+			)}
+			assert.Equal(t, expEvts, em.Events())
+		})
+	}
+}
+
+func TestScheduleContractCallMaxPerContract(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithMaxDeferredCallsPerContract(1))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	other := SeedNewContractInstance(t, ctx, keepers, &mock)
+	nextHeight := uint64(ctx.BlockHeight() + 1)
+
+	_, err := k.scheduleContractCall(ctx, example.Contract, []byte(`{}`), nextHeight, 0)
+	require.NoError(t, err)
+	// when limit reached
+	_, err = k.scheduleContractCall(ctx, example.Contract, []byte(`{}`), nextHeight, 0)
+	// then
+	assert.True(t, types.ErrInvalid.Is(err), "got %+v", err)
+	// and other contracts are not affected
+	_, err = k.scheduleContractCall(ctx, other.Contract, []byte(`{}`), nextHeight, 0)
+	require.NoError(t, err)
+
+	// when delivered
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	k.EndBlocker(ctx)
+	// then a new call can be scheduled
+	_, err = k.scheduleContractCall(ctx, example.Contract, []byte(`{}`), nextHeight+1, 0)
+	require.NoError(t, err)
+}
+
+func TestDeliverDeferredCalls(t *testing.T) {
+	var (
+		myKey   = []byte("foo")
+		myValue = []byte("bar")
+	)
+	specs := map[string]struct {
+		srcHeightDelta  int64
+		srcTimeDelta    time.Duration
+		srcByTime       bool
+		srcInactive     bool
+		srcErr          error
+		expCalled       bool
+		expStored       bool
+		expStillPending bool
+	}{
+		"due by height": {
+			srcHeightDelta: 1,
+			expCalled:      true,
+			expStored:      true,
+		},
+		"due by time": {
+			srcByTime:    true,
+			srcTimeDelta: time.Second,
+			expCalled:    true,
+			expStored:    true,
+		},
+		"height not reached": {
+			expStillPending: true,
+		},
+		"time not reached": {
+			srcByTime:       true,
+			expStillPending: true,
+		},
+		"contract fails": {
+			srcHeightDelta: 1,
+			srcErr:         errors.New("testing"),
+			expCalled:      true,
+		},
+		"inactive contract": {
+			srcHeightDelta: 1,
+			srcInactive:    true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			k := keepers.WasmKeeper
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			var err error
+			if spec.srcByTime {
+				_, err = k.scheduleContractCall(ctx, example.Contract, []byte(`{"my":"msg"}`), 0, uint64(ctx.BlockTime().Add(time.Second).UnixNano()))
+			} else {
+				_, err = k.scheduleContractCall(ctx, example.Contract, []byte(`{"my":"msg"}`), uint64(ctx.BlockHeight()+1), 0)
+			}
+			require.NoError(t, err)
+			if spec.srcInactive {
+				require.NoError(t, k.deactivateContract(ctx, example.Contract))
+			}
+			var gotMsg []byte
+			mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+				gotMsg = sudoMsg
+				store.Set(myKey, myValue)
+				return &wasmvmtypes.Response{}, 0, spec.srcErr
+			}
+			ctx = ctx.WithBlockHeight(ctx.BlockHeight() + spec.srcHeightDelta).
+				WithBlockTime(ctx.BlockTime().Add(spec.srcTimeDelta))
+
+			// when
+			k.EndBlocker(ctx)
+
+			// then
+			if spec.expCalled {
+				assert.JSONEq(t, `{"deferred_call":{"id":1,"msg":{"my":"msg"}}}`, string(gotMsg))
+			} else {
+				assert.Nil(t, gotMsg)
+			}
+			if spec.expStored {
+				assert.Equal(t, myValue, k.QueryRaw(ctx, example.Contract, myKey))
+			} else {
+				assert.Nil(t, k.QueryRaw(ctx, example.Contract, myKey))
+			}
+			var pending int
+			k.IterateDeferredCalls(ctx, func(types.DeferredCall) bool {
+				pending++
+				return false
+			})
+			if spec.expStillPending {
+				assert.Equal(t, 1, pending)
+			} else {
+				assert.Equal(t, 0, pending)
+			}
+		})
+	}
+}
+
+func TestDeliverDeferredCallsMaxPerBlock(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithMaxDeferredCallsPerBlock(2))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	var gotMsgs []string
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		gotMsgs = append(gotMsgs, string(sudoMsg))
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	for _, msg := range []string{`{"n":1}`, `{"n":2}`} {
+		_, err := k.scheduleContractCall(ctx, example.Contract, []byte(msg), uint64(ctx.BlockHeight()+1), 0)
+		require.NoError(t, err)
+	}
+	_, err := k.scheduleContractCall(ctx, example.Contract, []byte(`{"n":3}`), 0, uint64(ctx.BlockTime().UnixNano()+1))
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1).WithBlockTime(ctx.BlockTime().Add(time.Second))
+
+	// when
+	k.EndBlocker(ctx)
+	// then both queues get their share
+	exp := []string{
+		`{"deferred_call":{"id":1,"msg":{"n":1}}}`,
+		`{"deferred_call":{"id":3,"msg":{"n":3}}}`,
+	}
+	assert.Equal(t, exp, gotMsgs)
+
+	// and when next block
+	k.EndBlocker(ctx.WithBlockHeight(ctx.BlockHeight() + 1))
+	// then
+	exp = append(exp, `{"deferred_call":{"id":2,"msg":{"n":2}}}`)
+	assert.Equal(t, exp, gotMsgs)
+}
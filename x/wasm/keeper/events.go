@@ -65,3 +65,50 @@ func contractSDKEventAttributes(customAttributes []wasmvmtypes.EventAttribute, c
 	}
 	return attrs, nil
 }
+
+// validateContractEventLimits ensures that the attributes and custom events of a contract response do not exceed
+// the number and size limits. The limits are checked on the raw data as returned by the contract.
+func validateContractEventLimits(attrs []wasmvmtypes.EventAttribute, evts wasmvmtypes.Events) error {
+	if len(evts) > types.MaxContractEvents {
+		return sdkerrors.Wrapf(types.ErrInvalidEvent, "number of custom events exceeds limit of %d", types.MaxContractEvents)
+	}
+	attrCount := len(attrs)
+	payloadSize, err := eventAttributesSize(attrs)
+	if err != nil {
+		return err
+	}
+	for _, e := range evts {
+		if len(e.Type) > types.MaxEventAttributeKeySize {
+			return sdkerrors.Wrapf(types.ErrInvalidEvent, "event type exceeds limit of %d bytes", types.MaxEventAttributeKeySize)
+		}
+		attrCount += len(e.Attributes)
+		size, err := eventAttributesSize(e.Attributes)
+		if err != nil {
+			return err
+		}
+		payloadSize += len(e.Type) + size
+	}
+	if attrCount > types.MaxContractEventAttributes {
+		return sdkerrors.Wrapf(types.ErrInvalidEvent, "number of event attributes exceeds limit of %d", types.MaxContractEventAttributes)
+	}
+	if payloadSize > types.MaxContractEventPayloadSize {
+		return sdkerrors.Wrapf(types.ErrInvalidEvent, "event payload exceeds limit of %d bytes", types.MaxContractEventPayloadSize)
+	}
+	return nil
+}
+
+// eventAttributesSize returns the summed up length of all keys and values. It fails when a single key or
+// value exceeds its limit.
+func eventAttributesSize(attrs []wasmvmtypes.EventAttribute) (int, error) {
+	var size int
+	for _, a := range attrs {
+		if len(a.Key) > types.MaxEventAttributeKeySize {
+			return 0, sdkerrors.Wrapf(types.ErrInvalidEvent, "attribute key exceeds limit of %d bytes", types.MaxEventAttributeKeySize)
+		}
+		if len(a.Value) > types.MaxEventAttributeValueSize {
+			return 0, sdkerrors.Wrapf(types.ErrInvalidEvent, "attribute value exceeds limit of %d bytes", types.MaxEventAttributeValueSize)
+		}
+		size += len(a.Key) + len(a.Value)
+	}
+	return size, nil
+}
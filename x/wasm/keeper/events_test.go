@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
@@ -283,3 +284,87 @@ func hasWasmModuleEvent(ctx sdk.Context, contractAddr sdk.AccAddress) bool {
 	}
 	return false
 }
+
+func TestValidateContractEventLimits(t *testing.T) {
+	attrs := func(n int) []wasmvmtypes.EventAttribute {
+		r := make([]wasmvmtypes.EventAttribute, n)
+		for i := range r {
+			r[i] = wasmvmtypes.EventAttribute{Key: "myKey", Value: "myVal"}
+		}
+		return r
+	}
+	events := func(n int) wasmvmtypes.Events {
+		r := make(wasmvmtypes.Events, n)
+		for i := range r {
+			r[i] = wasmvmtypes.Event{Type: "myType", Attributes: attrs(1)}
+		}
+		return r
+	}
+	longValue := strings.Repeat("a", types.MaxEventAttributeValueSize)
+	specs := map[string]struct {
+		attrs  []wasmvmtypes.EventAttribute
+		evts   wasmvmtypes.Events
+		expErr bool
+	}{
+		"empty": {},
+		"max events": {
+			evts: events(types.MaxContractEvents),
+		},
+		"too many events": {
+			evts:   events(types.MaxContractEvents + 1),
+			expErr: true,
+		},
+		"max attributes": {
+			attrs: attrs(types.MaxContractEventAttributes - 1),
+			evts:  events(1),
+		},
+		"too many attributes": {
+			attrs:  attrs(types.MaxContractEventAttributes),
+			evts:   events(1),
+			expErr: true,
+		},
+		"max key size": {
+			attrs: []wasmvmtypes.EventAttribute{{Key: strings.Repeat("a", types.MaxEventAttributeKeySize), Value: "myVal"}},
+		},
+		"key too long": {
+			attrs:  []wasmvmtypes.EventAttribute{{Key: strings.Repeat("a", types.MaxEventAttributeKeySize+1), Value: "myVal"}},
+			expErr: true,
+		},
+		"key in custom event too long": {
+			evts:   wasmvmtypes.Events{{Type: "myType", Attributes: []wasmvmtypes.EventAttribute{{Key: strings.Repeat("a", types.MaxEventAttributeKeySize+1), Value: "myVal"}}}},
+			expErr: true,
+		},
+		"event type too long": {
+			evts:   wasmvmtypes.Events{{Type: strings.Repeat("a", types.MaxEventAttributeKeySize+1)}},
+			expErr: true,
+		},
+		"max value size": {
+			attrs: []wasmvmtypes.EventAttribute{{Key: "myKey", Value: longValue}},
+		},
+		"value too long": {
+			attrs:  []wasmvmtypes.EventAttribute{{Key: "myKey", Value: longValue + "a"}},
+			expErr: true,
+		},
+		"total payload too large": {
+			attrs: func() []wasmvmtypes.EventAttribute {
+				n := types.MaxContractEventPayloadSize/types.MaxEventAttributeValueSize + 1
+				r := make([]wasmvmtypes.EventAttribute, n)
+				for i := range r {
+					r[i] = wasmvmtypes.EventAttribute{Key: "myKey", Value: longValue}
+				}
+				return r
+			}(),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			err := validateContractEventLimits(spec.attrs, spec.evts)
+			if spec.expErr {
+				assert.True(t, types.ErrInvalidEvent.Is(err), err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
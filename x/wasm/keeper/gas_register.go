@@ -32,11 +32,13 @@ const (
 	DefaultGasMultiplier uint64 = 140_000_000
 	// DefaultInstanceCost is how much SDK gas we charge each time we load a WASM instance.
 	// Creating a new instance is costly, and this helps put a recursion limit to contracts calling contracts.
+	// The value is used with the `instance_cost` param that chains can adjust.
 	// Benchmarks and numbers were discussed in: https://github.com/CosmWasm/wasmd/pull/634#issuecomment-938056803
-	DefaultInstanceCost uint64 = 60_000
+	DefaultInstanceCost = types.DefaultInstanceCost
 	// DefaultCompileCost is how much SDK gas is charged *per byte* for compiling WASM code.
+	// The value is used with the `compile_cost` param that chains can adjust.
 	// Benchmarks and numbers were discussed in: https://github.com/CosmWasm/wasmd/pull/634#issuecomment-938056803
-	DefaultCompileCost uint64 = 3
+	DefaultCompileCost = types.DefaultCompileCost
 	// DefaultEventAttributeDataCost is how much SDK gas is charged *per byte* for attribute data in events.
 	// This is used with len(key) + len(value)
 	DefaultEventAttributeDataCost uint64 = 1
@@ -72,11 +74,23 @@ type GasRegister interface {
 	FromWasmVMGas(source uint64) sdk.Gas
 }
 
+// ParamsGasRegister is a GasRegister that takes the instance and compile costs from the module params.
+// The keeper applies the current param values before the costs are calculated.
+type ParamsGasRegister interface {
+	GasRegister
+	// WithInstanceCost returns a gas register with the given instance cost
+	WithInstanceCost(cost sdk.Gas) GasRegister
+	// WithCompileCost returns a gas register with the given compile cost per byte
+	WithCompileCost(cost sdk.Gas) GasRegister
+}
+
 // WasmGasRegisterConfig config type
 type WasmGasRegisterConfig struct {
-	// InstanceCost costs when interacting with a wasm contract
+	// InstanceCost costs when interacting with a wasm contract.
+	// The keeper replaces it with the `instance_cost` param value.
 	InstanceCost sdk.Gas
-	// CompileCosts costs to persist and "compile" a new wasm contract
+	// CompileCosts costs to persist and "compile" a new wasm contract.
+	// The keeper replaces it with the `compile_cost` param value.
 	CompileCost sdk.Gas
 	// GasMultiplier is how many cosmwasm gas points = 1 sdk gas point
 	// SDK reference costs can be found here: https://github.com/cosmos/cosmos-sdk/blob/02c6c9fafd58da88550ab4d7d494724a477c8a68/store/types/gas.go#L153-L164
@@ -130,6 +144,20 @@ func NewWasmGasRegister(c WasmGasRegisterConfig) WasmGasRegister {
 	}
 }
 
+// WithInstanceCost returns a copy of the register with the given instance cost
+func (g WasmGasRegister) WithInstanceCost(cost sdk.Gas) GasRegister {
+	c := g.c
+	c.InstanceCost = cost
+	return WasmGasRegister{c: c}
+}
+
+// WithCompileCost returns a copy of the register with the given compile cost per byte
+func (g WasmGasRegister) WithCompileCost(cost sdk.Gas) GasRegister {
+	c := g.c
+	c.CompileCost = cost
+	return WasmGasRegister{c: c}
+}
+
 // NewContractInstanceCosts costs to crate a new contract instance from code
 func (g WasmGasRegister) NewContractInstanceCosts(pinned bool, msgLen int) storetypes.Gas {
 	return g.InstantiateContractCosts(pinned, msgLen)
@@ -21,7 +21,12 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, staki
 	keeper.SetParams(ctx, data.Params)
 	var maxCodeID uint64
 	for i, code := range data.Codes {
-		err := keeper.importCode(ctx, code.CodeID, code.CodeInfo, code.CodeBytes)
+		var err error
+		if code.Pruned {
+			err = keeper.importPrunedCode(ctx, code.CodeID, code.CodeInfo)
+		} else {
+			err = keeper.importCode(ctx, code.CodeID, code.CodeInfo, code.CodeBytes)
+		}
 		if err != nil {
 			return nil, sdkerrors.Wrapf(err, "code %d with id: %d", i, code.CodeID)
 		}
@@ -41,13 +46,62 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, staki
 		if err != nil {
 			return nil, sdkerrors.Wrapf(err, "address in contract number %d", i)
 		}
-		err = keeper.importContract(ctx, contractAddr, &contract.ContractInfo, contract.ContractState)
+		err = keeper.importContract(ctx, contractAddr, &contract.ContractInfo, contract.ContractState, contract.ContractCodeHistory)
 		if err != nil {
 			return nil, sdkerrors.Wrapf(err, "contract number %d", i)
 		}
+		if contract.Inactive {
+			if err := contractKeeper.DeactivateContract(ctx, contractAddr); err != nil {
+				return nil, sdkerrors.Wrapf(err, "contract number %d", i)
+			}
+		}
+		if contract.Cron {
+			if err := contractKeeper.RegisterCronContract(ctx, contractAddr); err != nil {
+				return nil, sdkerrors.Wrapf(err, "contract number %d", i)
+			}
+		}
+		if contract.Rent != nil {
+			keeper.setContractRent(ctx, contractAddr, *contract.Rent)
+		}
+		for _, c := range contract.Capabilities {
+			if err := contractKeeper.GrantContractCapability(ctx, contractAddr, c); err != nil {
+				return nil, sdkerrors.Wrapf(err, "contract number %d", i)
+			}
+		}
 		maxContractID = i + 1 // not ideal but max(contractID) is not persisted otherwise
 	}
 
+	var maxDeferredCallID uint64
+	for i, call := range data.DeferredCalls {
+		if err := keeper.importDeferredCall(ctx, call); err != nil {
+			return nil, sdkerrors.Wrapf(err, "deferred call number %d", i)
+		}
+		if call.ID > maxDeferredCallID {
+			maxDeferredCallID = call.ID
+		}
+	}
+
+	for i, change := range data.PendingAdminChanges {
+		if err := keeper.importPendingAdminChange(ctx, change); err != nil {
+			return nil, sdkerrors.Wrapf(err, "pending admin change number %d", i)
+		}
+	}
+	for i, migration := range data.PendingMigrations {
+		if err := keeper.importPendingMigration(ctx, migration); err != nil {
+			return nil, sdkerrors.Wrapf(err, "pending migration number %d", i)
+		}
+	}
+	for i, change := range data.PendingOperatorChanges {
+		if err := keeper.importPendingOperatorChange(ctx, change); err != nil {
+			return nil, sdkerrors.Wrapf(err, "pending operator change number %d", i)
+		}
+	}
+	for i, call := range data.PendingOperatorSudos {
+		if err := keeper.importPendingOperatorSudo(ctx, call); err != nil {
+			return nil, sdkerrors.Wrapf(err, "pending operator sudo number %d", i)
+		}
+	}
+
 	for i, seq := range data.Sequences {
 		err := keeper.importAutoIncrementID(ctx, seq.IDKey, seq.Value)
 		if err != nil {
@@ -64,23 +118,50 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, staki
 	if seqVal <= uint64(maxContractID) {
 		return nil, sdkerrors.Wrapf(types.ErrInvalid, "seq %s with value: %d must be greater than: %d ", string(types.KeyLastInstanceID), seqVal, maxContractID)
 	}
+	seqVal = keeper.PeekAutoIncrementID(ctx, types.KeyLastDeferredCallID)
+	if seqVal <= maxDeferredCallID {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "seq %s with value: %d must be greater than: %d ", string(types.KeyLastDeferredCallID), seqVal, maxDeferredCallID)
+	}
 
 	if len(data.GenMsgs) == 0 {
 		return nil, nil
 	}
-	for _, genTx := range data.GenMsgs {
+	for i, genTx := range data.GenMsgs {
 		msg := genTx.AsMsg()
 		if msg == nil {
-			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidType, "unknown message")
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidType, "unknown genesis message %d", i)
+		}
+		var err error
+		if m := genTx.GetInstantiateContract(); m != nil {
+			err = genesisInstantiate(ctx, keeper, m)
+		} else {
+			_, err = msgHandler(ctx, msg)
 		}
-		_, err := msgHandler(ctx, msg)
 		if err != nil {
-			return nil, sdkerrors.Wrap(err, "genesis")
+			return nil, sdkerrors.Wrapf(err, "genesis message %d", i)
 		}
 	}
 	return stakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
 }
 
+// genesisInstantiate instantiates a contract from a genesis message. Unlike instantiations in a tx, the
+// contract address is derived from the code checksum and label so that it can be referenced before the
+// chain exists.
+func genesisInstantiate(ctx sdk.Context, keeper *Keeper, msg *types.MsgInstantiateContract) error {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	var adminAddr sdk.AccAddress
+	if msg.Admin != "" {
+		if adminAddr, err = sdk.AccAddressFromBech32(msg.Admin); err != nil {
+			return sdkerrors.Wrap(err, "admin")
+		}
+	}
+	_, _, err = keeper.instantiate(ctx, msg.CodeID, senderAddr, adminAddr, msg.Msg, msg.Label, msg.Funds, GenesisAddressGenerator(msg.Label), DefaultAuthorizationPolicy{})
+	return err
+}
+
 // ExportGenesis returns a GenesisState for a given context and keeper.
 func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 	var genState types.GenesisState
@@ -97,6 +178,7 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 			CodeInfo:  info,
 			CodeBytes: bytecode,
 			Pinned:    keeper.IsPinnedCode(ctx, codeID),
+			Pruned:    keeper.IsPrunedCode(ctx, codeID),
 		})
 		return false
 	})
@@ -107,17 +189,49 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 			state = append(state, types.Model{Key: key, Value: value})
 			return false
 		})
-		// redact contract info
+		// redact contract info, the created position is restored from the history on import
 		contract.Created = nil
+		var rent *types.ContractRent
+		if keeper.hasContractRent(ctx, addr) {
+			r := keeper.GetContractRent(ctx, addr)
+			rent = &r
+		}
 		genState.Contracts = append(genState.Contracts, types.Contract{
-			ContractAddress: addr.String(),
-			ContractInfo:    contract,
-			ContractState:   state,
+			ContractAddress:     addr.String(),
+			ContractInfo:        contract,
+			ContractState:       state,
+			ContractCodeHistory: keeper.GetContractHistory(ctx, addr),
+			Inactive:            keeper.IsInactiveContract(ctx, addr),
+			Cron:                keeper.IsCronContract(ctx, addr),
+			Rent:                rent,
+			Capabilities:        keeper.GetContractCapabilities(ctx, addr),
 		})
 		return false
 	})
 
-	for _, k := range [][]byte{types.KeyLastCodeID, types.KeyLastInstanceID} {
+	keeper.IterateDeferredCalls(ctx, func(call types.DeferredCall) bool {
+		genState.DeferredCalls = append(genState.DeferredCalls, call)
+		return false
+	})
+
+	keeper.IteratePendingAdminChanges(ctx, func(change types.PendingAdminChange) bool {
+		genState.PendingAdminChanges = append(genState.PendingAdminChanges, change)
+		return false
+	})
+	keeper.IteratePendingMigrations(ctx, func(migration types.PendingMigration) bool {
+		genState.PendingMigrations = append(genState.PendingMigrations, migration)
+		return false
+	})
+	keeper.IteratePendingOperatorChanges(ctx, func(change types.PendingOperatorChange) bool {
+		genState.PendingOperatorChanges = append(genState.PendingOperatorChanges, change)
+		return false
+	})
+	keeper.IteratePendingOperatorSudos(ctx, func(call types.PendingOperatorSudo) bool {
+		genState.PendingOperatorSudos = append(genState.PendingOperatorSudos, call)
+		return false
+	})
+
+	for _, k := range [][]byte{types.KeyLastCodeID, types.KeyLastInstanceID, types.KeyLastDeferredCallID} {
 		genState.Sequences = append(genState.Sequences, types.Sequence{
 			IDKey: k,
 			Value: keeper.PeekAutoIncrementID(ctx, k),
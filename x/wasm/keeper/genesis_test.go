@@ -12,8 +12,8 @@ import (
 	"testing"
 	"time"
 
+	wasmvm "github.com/CosmWasm/wasmvm"
 	"github.com/cosmos/cosmos-sdk/store"
-	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	distributionkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
@@ -30,6 +30,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	wasmTypes "github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -82,13 +83,57 @@ func TestGenesisExportImport(t *testing.T) {
 		}
 
 		contract.CodeID = codeID
-		contractAddr := wasmKeeper.generateContractAddress(srcCtx, codeID)
+		contractAddr := wasmKeeper.ClassicAddressGenerator()(srcCtx, codeID, nil)
 		wasmKeeper.storeContractInfo(srcCtx, contractAddr, &contract)
 		wasmKeeper.appendToContractHistory(srcCtx, contractAddr, history...)
 		wasmKeeper.importContractState(srcCtx, contractAddr, stateModels)
+		if i%2 == 0 {
+			_, err = wasmKeeper.scheduleContractCall(srcCtx, contractAddr, []byte(`{}`), uint64(srcCtx.BlockHeight()+1), 0)
+		} else {
+			_, err = wasmKeeper.scheduleContractCall(srcCtx, contractAddr, []byte(`{}`), 0, uint64(srcCtx.BlockTime().UnixNano()+1))
+		}
+		require.NoError(t, err)
+		if i%4 == 0 {
+			require.NoError(t, wasmKeeper.grantContractCapability(srcCtx, contractAddr, "mint"))
+		}
+		if i%3 == 0 {
+			require.NoError(t, wasmKeeper.importPendingAdminChange(srcCtx, types.PendingAdminChange{
+				Contract: contractAddr.String(),
+				Sender:   creatorAddr.String(),
+				NewAdmin: creatorAddr.String(),
+				Height:   uint64(srcCtx.BlockHeight() + 1),
+			}))
+			require.NoError(t, wasmKeeper.importPendingMigration(srcCtx, types.PendingMigration{
+				Contract: contractAddr.String(),
+				Sender:   creatorAddr.String(),
+				CodeID:   codeID,
+				Msg:      []byte(`{}`),
+				Height:   uint64(srcCtx.BlockHeight() + 1),
+			}))
+			require.NoError(t, wasmKeeper.importPendingOperatorChange(srcCtx, types.PendingOperatorChange{
+				Contract: contractAddr.String(),
+				Sender:   creatorAddr.String(),
+				Operator: creatorAddr.String(),
+				SudoMsgs: []string{"foo"},
+				Height:   uint64(srcCtx.BlockHeight() + 1),
+			}))
+			require.NoError(t, wasmKeeper.importPendingOperatorSudo(srcCtx, types.PendingOperatorSudo{
+				Contract: contractAddr.String(),
+				Sender:   creatorAddr.String(),
+				Msg:      []byte(`{"foo":{}}`),
+				Height:   uint64(srcCtx.BlockHeight() + 1),
+			}))
+		}
 	}
+	// and an unused code that was pruned
+	prunedCodeID, err := contractKeeper.Create(srcCtx, RandomAccountAddress(t), wasmCode, nil)
+	require.NoError(t, err)
+	require.NoError(t, contractKeeper.PruneCode(srcCtx, prunedCodeID))
+
 	var wasmParams types.Params
 	f.NilChance(0).Fuzz(&wasmParams)
+	wasmParams.MaxContractMsgSize = uint64(rand.Intn(types.MaxContractMsgSize)) + 1
+	wasmParams.MaxContractResponseDataSize = wasmParams.MaxContractResponseDataSize%(1024*1024) + 1
 	wasmKeeper.SetParams(srcCtx, wasmParams)
 
 	// export
@@ -100,6 +145,9 @@ func TestGenesisExportImport(t *testing.T) {
 	rand.Shuffle(len(exportedState.Contracts), func(i, j int) {
 		exportedState.Contracts[i], exportedState.Contracts[j] = exportedState.Contracts[j], exportedState.Contracts[i]
 	})
+	rand.Shuffle(len(exportedState.DeferredCalls), func(i, j int) {
+		exportedState.DeferredCalls[i], exportedState.DeferredCalls[j] = exportedState.DeferredCalls[j], exportedState.DeferredCalls[i]
+	})
 	rand.Shuffle(len(exportedState.Sequences), func(i, j int) {
 		exportedState.Sequences[i], exportedState.Sequences[j] = exportedState.Sequences[j], exportedState.Sequences[i]
 	})
@@ -109,21 +157,19 @@ func TestGenesisExportImport(t *testing.T) {
 	// setup new instances
 	dstKeeper, dstCtx, dstStoreKeys := setupKeeper(t)
 
-	// reset contract code index in source DB for comparison with dest DB
+	// reset contract created position and indexes in source DB for comparison with dest DB
 	wasmKeeper.IterateContractInfo(srcCtx, func(address sdk.AccAddress, info wasmTypes.ContractInfo) bool {
-		wasmKeeper.removeFromContractCodeSecondaryIndex(srcCtx, address, wasmKeeper.getLastContractHistoryEntry(srcCtx, address))
-		prefixStore := prefix.NewStore(srcCtx.KVStore(wasmKeeper.storeKey), types.GetContractCodeHistoryElementPrefix(address))
-		iter := prefixStore.Iterator(nil, nil)
-
-		for ; iter.Valid(); iter.Next() {
-			prefixStore.Delete(iter.Key())
-		}
+		history := wasmKeeper.GetContractHistory(srcCtx, address)
 		x := &info
-		newHistory := x.ResetFromGenesis(dstCtx)
+		x.Created = history[0].Updated
 		wasmKeeper.storeContractInfo(srcCtx, address, x)
-		wasmKeeper.addToContractCodeSecondaryIndex(srcCtx, address, newHistory)
-		wasmKeeper.appendToContractHistory(srcCtx, address, newHistory)
-		iter.Close()
+		wasmKeeper.addToContractCodeSecondaryIndex(srcCtx, address, history[len(history)-1])
+		creatorAddress, err := sdk.AccAddressFromBech32(info.Creator)
+		require.NoError(t, err)
+		wasmKeeper.addToContractCreatorSecondaryIndex(srcCtx, creatorAddress, x.Created, address)
+		if adminAddress := x.AdminAddr(); adminAddress != nil {
+			wasmKeeper.addToContractAdminSecondaryIndex(srcCtx, adminAddress, x.Created, address)
+		}
 		return false
 	})
 
@@ -151,6 +197,9 @@ func TestGenesisExportImport(t *testing.T) {
 		srcIT.Close()
 		dstIT.Close()
 	}
+
+	// and export from the new instance gives the same genesis
+	assert.Equal(t, ExportGenesis(srcCtx, wasmKeeper), ExportGenesis(dstCtx, dstKeeper))
 }
 
 func TestGenesisInit(t *testing.T) {
@@ -260,6 +309,41 @@ func TestGenesisInit(t *testing.T) {
 				},
 				Params: types.DefaultParams(),
 			}},
+		"pruned code without byte code": {
+			src: types.GenesisState{
+				Codes: []types.Code{{
+					CodeID:   firstCodeID,
+					CodeInfo: myCodeInfo,
+					Pruned:   true,
+				}},
+				Sequences: []types.Sequence{
+					{IDKey: types.KeyLastCodeID, Value: 2},
+					{IDKey: types.KeyLastInstanceID, Value: 1},
+				},
+				Params: types.DefaultParams(),
+			},
+			expSuccess: true,
+		},
+		"prevent contract with pruned code": {
+			src: types.GenesisState{
+				Codes: []types.Code{{
+					CodeID:   firstCodeID,
+					CodeInfo: myCodeInfo,
+					Pruned:   true,
+				}},
+				Contracts: []types.Contract{
+					{
+						ContractAddress: BuildContractAddress(1, 1).String(),
+						ContractInfo:    types.ContractInfoFixture(func(c *wasmTypes.ContractInfo) { c.CodeID = 1 }, types.OnlyGenesisFields),
+					},
+				},
+				Sequences: []types.Sequence{
+					{IDKey: types.KeyLastCodeID, Value: 2},
+					{IDKey: types.KeyLastInstanceID, Value: 2},
+				},
+				Params: types.DefaultParams(),
+			},
+		},
 		"happy path: code id in info and contract do match": {
 			src: types.GenesisState{
 				Codes: []types.Code{{
@@ -463,7 +547,9 @@ func TestImportContractWithCodeHistoryReset(t *testing.T) {
 		"code_upload_access": {
 			"permission": "Everybody"
 		},
-		"instantiate_default_permission": "Everybody"
+		"instantiate_default_permission": "Everybody",
+		"max_contract_msg_size": "1048576",
+		"max_contract_response_data_size": "65536"
 	},
   "codes": [
     {
@@ -560,6 +646,155 @@ func TestImportContractWithCodeHistoryReset(t *testing.T) {
 	assert.Equal(t, expHistory, keeper.GetContractHistory(ctx, contractAddr))
 	assert.Equal(t, uint64(2), keeper.PeekAutoIncrementID(ctx, types.KeyLastCodeID))
 	assert.Equal(t, uint64(3), keeper.PeekAutoIncrementID(ctx, types.KeyLastInstanceID))
+
+	// and new codes and contracts continue with the imported sequences
+	newCodeID, err := contractKeeper.Create(ctx, RandomAccountAddress(t), wasmCode, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), newCodeID)
+	assert.Equal(t, BuildContractAddress(newCodeID, 3), keeper.ClassicAddressGenerator()(ctx, newCodeID, nil))
+}
+
+func TestGenesisInitGenMsgFails(t *testing.T) {
+	keeper, ctx, _ := setupKeeper(t)
+	src := types.GenesisState{
+		Params: types.DefaultParams(),
+		GenMsgs: []types.GenesisState_GenMsgs{
+			{Sum: &types.GenesisState_GenMsgs_StoreCode{StoreCode: types.MsgStoreCodeFixture()}},
+			{Sum: &types.GenesisState_GenMsgs_StoreCode{StoreCode: types.MsgStoreCodeFixture()}},
+			{Sum: &types.GenesisState_GenMsgs_StoreCode{StoreCode: types.MsgStoreCodeFixture()}},
+		},
+	}
+	var gotCalls int
+	msgHandler := func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		gotCalls++
+		if gotCalls == 2 {
+			return nil, errors.New("test error response")
+		}
+		return &sdk.Result{}, nil
+	}
+	stakingMock := StakingKeeperMock{expCalls: 0}
+
+	// when
+	_, gotErr := InitGenesis(ctx, keeper, src, &stakingMock, msgHandler)
+
+	// then
+	require.Error(t, gotErr)
+	assert.Contains(t, gotErr.Error(), "genesis message 1")
+	assert.Equal(t, 2, gotCalls, "must stop at first failure")
+	stakingMock.verifyCalls(t)
+}
+
+func TestImportCodeVerifiesChecksumBeforeCompile(t *testing.T) {
+	wasmCode := []byte("any wasm code")
+	checksum := sha256.Sum256(wasmCode)
+	specs := map[string]struct {
+		codeHash   []byte
+		expCompile bool
+		expErr     bool
+	}{
+		"checksum matches": {
+			codeHash:   checksum[:],
+			expCompile: true,
+		},
+		"checksum mismatch": {
+			codeHash: make([]byte, sha256.Size),
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var compiled bool
+			mock := &wasmtesting.MockWasmer{CreateFn: func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+				compiled = true
+				return wasmtesting.HashOnlyCreateFn(code)
+			}}
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(mock))
+			codeInfo := types.CodeInfoFixture(func(i *types.CodeInfo) { i.CodeHash = spec.codeHash })
+
+			// when
+			gotErr := keepers.WasmKeeper.importCode(ctx, 1, codeInfo, wasmCode)
+
+			// then
+			assert.Equal(t, spec.expCompile, compiled)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestImportContractWithCodeHistoryPreserved(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	example1 := StoreHackatomExampleContract(t, ctx, keepers)
+	example2 := StoreHackatomExampleContract(t, ctx, keepers)
+
+	contractAddr := BuildContractAddress(example1.CodeID, 1)
+	contractInfo := types.ContractInfoFixture(func(c *types.ContractInfo) {
+		c.CodeID = example2.CodeID
+		c.Created = nil
+	})
+	history := []types.ContractCodeHistoryEntry{
+		{
+			Operation: types.ContractCodeHistoryOperationTypeInit,
+			CodeID:    example1.CodeID,
+			Updated:   &types.AbsoluteTxPosition{BlockHeight: 10, TxIndex: 1},
+			Msg:       []byte(`{"init":{}}`),
+		},
+		{
+			Operation: types.ContractCodeHistoryOperationTypeMigrate,
+			CodeID:    example2.CodeID,
+			Updated:   &types.AbsoluteTxPosition{BlockHeight: 20, TxIndex: 2},
+			Msg:       []byte(`{"migrate":{}}`),
+		},
+	}
+
+	// when
+	err := k.importContract(ctx, contractAddr, &contractInfo, nil, history)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, history, k.GetContractHistory(ctx, contractAddr))
+	gotContractInfo := k.GetContractInfo(ctx, contractAddr)
+	require.NotNil(t, gotContractInfo)
+	assert.Equal(t, history[0].Updated, gotContractInfo.Created)
+
+	var gotContracts []sdk.AccAddress
+	k.IterateContractsByCode(ctx, example2.CodeID, func(addr sdk.AccAddress) bool {
+		gotContracts = append(gotContracts, addr)
+		return false
+	})
+	assert.Equal(t, []sdk.AccAddress{contractAddr}, gotContracts)
+
+	// and new entries are appended at the end
+	newEntry := types.ContractCodeHistoryEntry{
+		Operation: types.ContractCodeHistoryOperationTypeMigrate,
+		CodeID:    example1.CodeID,
+		Updated:   &types.AbsoluteTxPosition{BlockHeight: 30, TxIndex: 3},
+	}
+	k.appendToContractHistory(ctx, contractAddr, newEntry)
+	assert.Equal(t, append(history, newEntry), k.GetContractHistory(ctx, contractAddr))
+}
+
+func TestImportContractWithInvalidCodeHistory(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	contractInfo := types.ContractInfoFixture(func(c *types.ContractInfo) {
+		c.CodeID = example.CodeID
+		c.Created = nil
+	})
+	history := []types.ContractCodeHistoryEntry{{
+		Operation: types.ContractCodeHistoryOperationTypeInit,
+		CodeID:    example.CodeID,
+	}}
+
+	// when
+	err := keepers.WasmKeeper.importContract(ctx, BuildContractAddress(example.CodeID, 1), &contractInfo, nil, history)
+
+	// then
+	require.Error(t, err)
 }
 
 func TestSupportedGenMsgTypes(t *testing.T) {
@@ -571,6 +806,8 @@ func TestSupportedGenMsgTypes(t *testing.T) {
 		verifierAddress    sdk.AccAddress = bytes.Repeat([]byte{2}, types.ContractAddrLen)
 		beneficiaryAddress sdk.AccAddress = bytes.Repeat([]byte{3}, types.ContractAddrLen)
 	)
+	checksum := sha256.Sum256(wasmCode)
+	contractAddr := BuildGenesisContractAddress(checksum[:], "testing")
 	const denom = "stake"
 	importState := types.GenesisState{
 		Params: types.DefaultParams(),
@@ -601,7 +838,7 @@ func TestSupportedGenMsgTypes(t *testing.T) {
 				Sum: &types.GenesisState_GenMsgs_ExecuteContract{
 					ExecuteContract: &types.MsgExecuteContract{
 						Sender:   verifierAddress.String(),
-						Contract: BuildContractAddress(1, 1).String(),
+						Contract: contractAddr.String(),
 						Msg:      []byte(`{"release":{}}`),
 					},
 				},
@@ -626,14 +863,54 @@ func TestSupportedGenMsgTypes(t *testing.T) {
 	require.NotNil(t, codeInfo)
 
 	// verify contract instantiated
-	cInfo := keeper.GetContractInfo(ctx, BuildContractAddress(1, 1))
+	cInfo := keeper.GetContractInfo(ctx, contractAddr)
 	require.NotNil(t, cInfo)
+	assert.Equal(t, uint64(1), keeper.PeekAutoIncrementID(ctx, types.KeyLastInstanceID), "instance sequence not used")
 
 	// verify contract executed
 	gotBalance := keepers.BankKeeper.GetBalance(ctx, beneficiaryAddress, denom)
 	assert.Equal(t, sdk.NewCoin(denom, sdk.NewInt(10)), gotBalance)
 }
 
+func TestGenesisInstantiateRejectsDuplicateAddress(t *testing.T) {
+	SkipIfM1(t)
+	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+	var myAddress sdk.AccAddress = bytes.Repeat([]byte{1}, types.ContractAddrLen)
+	instantiateMsg := types.GenesisState_GenMsgs{
+		Sum: &types.GenesisState_GenMsgs_InstantiateContract{
+			InstantiateContract: &types.MsgInstantiateContract{
+				Sender: myAddress.String(),
+				CodeID: 1,
+				Label:  "testing",
+				Msg: HackatomExampleInitMsg{
+					Verifier:    RandomAccountAddress(t),
+					Beneficiary: RandomAccountAddress(t),
+				}.GetBytes(t),
+			},
+		},
+	}
+	importState := types.GenesisState{
+		Params: types.DefaultParams(),
+		GenMsgs: []types.GenesisState_GenMsgs{
+			{Sum: &types.GenesisState_GenMsgs_StoreCode{StoreCode: &types.MsgStoreCode{Sender: myAddress.String(), WASMByteCode: wasmCode}}},
+			instantiateMsg,
+			instantiateMsg,
+		},
+	}
+	require.NoError(t, importState.ValidateBasic())
+	ctx, keepers := CreateDefaultTestInput(t)
+	ctx = ctx.WithBlockHeight(0).WithGasMeter(sdk.NewInfiniteGasMeter())
+
+	// when
+	_, err = InitGenesis(ctx, keepers.WasmKeeper, importState, &StakingKeeperMock{}, TestHandler(keepers.ContractKeeper))
+
+	// then
+	require.Error(t, err)
+	assert.True(t, types.ErrAccountExists.Is(err), err)
+	assert.Contains(t, err.Error(), "genesis message 2")
+}
+
 func setupKeeper(t *testing.T) (*Keeper, sdk.Context, []sdk.StoreKey) {
 	t.Helper()
 	tempDir, err := ioutil.TempDir("", "wasm")
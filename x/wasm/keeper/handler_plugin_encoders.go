@@ -234,6 +234,10 @@ func EncodeWasmMsg(sender sdk.AccAddress, msg *wasmvmtypes.WasmMsg) ([]sdk.Msg,
 			Admin:  msg.Instantiate.Admin,
 			Funds:  coins,
 		}
+		if sdkMsg.Admin == "" {
+			// contracts set the admin explicitly, the default creator admin param applies to txs only
+			sdkMsg.AdminMode = types.InstantiateAdminModeNone
+		}
 		return []sdk.Msg{&sdkMsg}, nil
 	case msg.Migrate != nil:
 		sdkMsg := types.MsgMigrateContract{
@@ -265,6 +269,9 @@ func EncodeIBCMsg(portSource types.ICS20TransferPortSource) func(ctx sdk.Context
 	return func(ctx sdk.Context, sender sdk.AccAddress, contractIBCPortID string, msg *wasmvmtypes.IBCMsg) ([]sdk.Msg, error) {
 		switch {
 		case msg.CloseChannel != nil:
+			if contractIBCPortID == "" {
+				return nil, sdkerrors.Wrapf(types.ErrUnsupportedForContract, "ibc not supported")
+			}
 			return []sdk.Msg{&channeltypes.MsgChannelCloseInit{
 				PortId:    PortIDForContract(sender),
 				ChannelId: msg.CloseChannel.ChannelID,
@@ -189,6 +189,27 @@ func TestEncoding(t *testing.T) {
 				},
 			},
 		},
+		"wasm instantiate without admin": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Wasm: &wasmvmtypes.WasmMsg{
+					Instantiate: &wasmvmtypes.InstantiateMsg{
+						CodeID: 7,
+						Msg:    jsonMsg,
+						Label:  "myLabel",
+					},
+				},
+			},
+			output: []sdk.Msg{
+				&types.MsgInstantiateContract{
+					Sender:    addr1.String(),
+					CodeID:    7,
+					Label:     "myLabel",
+					Msg:       jsonMsg,
+					AdminMode: types.InstantiateAdminModeNone,
+				},
+			},
+		},
 		"wasm migrate": {
 			sender: addr2,
 			srcMsg: wasmvmtypes.CosmosMsg{
@@ -500,6 +521,17 @@ func TestEncoding(t *testing.T) {
 				},
 			},
 		},
+		"IBC close channel - non ibc contract": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				IBC: &wasmvmtypes.IBCMsg{
+					CloseChannel: &wasmvmtypes.CloseChannelMsg{
+						ChannelID: "channel-1",
+					},
+				},
+			},
+			isError: true,
+		},
 		"Gov vote: yes": {
 			sender:             addr1,
 			srcContractIBCPort: "myIBCPort",
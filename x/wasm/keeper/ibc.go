@@ -19,7 +19,7 @@ func (k Keeper) bindIbcPort(ctx sdk.Context, portID string) error {
 	return k.ClaimCapability(ctx, cap, host.PortPath(portID))
 }
 
-// ensureIbcPort is like registerIbcPort, but it checks if we already hold the port
+// ensureIbcPort is like bindIbcPort, but it checks if we already hold the port
 // before calling register, so this is safe to call multiple times.
 // Returns success if we already registered or just registered and error if we cannot
 // (lack of permissions or someone else has it)
@@ -33,10 +33,12 @@ func (k Keeper) ensureIbcPort(ctx sdk.Context, contractAddr sdk.AccAddress) (str
 
 const portIDPrefix = "wasm."
 
+// PortIDForContract returns the unique IBC port ID of a contract in the format `wasm.<contract-address>`
 func PortIDForContract(addr sdk.AccAddress) string {
 	return portIDPrefix + addr.String()
 }
 
+// ContractFromPortID returns the contract address for the given IBC port ID. It is the reverse of PortIDForContract.
 func ContractFromPortID(portID string) (sdk.AccAddress, error) {
 	if !strings.HasPrefix(portID, portIDPrefix) {
 		return nil, sdkerrors.Wrapf(types.ErrInvalid, "without prefix")
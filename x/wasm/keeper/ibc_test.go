@@ -20,7 +20,9 @@ func TestDontBindPortNonIBCContract(t *testing.T) {
 func TestBindingPortForIBCContractOnInstantiate(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	example := InstantiateIBCReflectContract(t, ctx, keepers) // ensure we bound the port
-	owner, _, err := keepers.IBCKeeper.PortKeeper.LookupModuleByPort(ctx, keepers.WasmKeeper.GetContractInfo(ctx, example.Contract).IBCPortID)
+	portID := keepers.WasmKeeper.GetContractInfo(ctx, example.Contract).IBCPortID
+	assert.Equal(t, PortIDForContract(example.Contract), portID)
+	owner, _, err := keepers.IBCKeeper.PortKeeper.LookupModuleByPort(ctx, portID)
 	require.NoError(t, err)
 	require.Equal(t, "wasm", owner)
 
@@ -35,11 +37,33 @@ func TestBindingPortForIBCContractOnInstantiate(t *testing.T) {
 	require.NotEqual(t, example.Contract, addr)
 
 	portID2 := PortIDForContract(addr)
+	assert.Equal(t, portID2, keepers.WasmKeeper.GetContractInfo(ctx, addr).IBCPortID)
+	assert.NotEqual(t, portID, portID2)
 	owner, _, err = keepers.IBCKeeper.PortKeeper.LookupModuleByPort(ctx, portID2)
 	require.NoError(t, err)
 	require.Equal(t, "wasm", owner)
 }
 
+func TestEnsureIbcPort(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	contractAddr := RandomAccountAddress(t)
+
+	// when bound the first time
+	portID, err := keepers.WasmKeeper.ensureIbcPort(ctx, contractAddr)
+	require.NoError(t, err)
+	assert.Equal(t, PortIDForContract(contractAddr), portID)
+
+	// then calling again is a no-op
+	gotPortID, err := keepers.WasmKeeper.ensureIbcPort(ctx, contractAddr)
+	require.NoError(t, err)
+	assert.Equal(t, portID, gotPortID)
+
+	// and the contract address can be restored from the port
+	gotAddr, err := ContractFromPortID(portID)
+	require.NoError(t, err)
+	assert.Equal(t, contractAddr, gotAddr)
+}
+
 func TestContractFromPortID(t *testing.T) {
 	contractAddr := BuildContractAddress(1, 100)
 	specs := map[string]struct {
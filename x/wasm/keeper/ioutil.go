@@ -51,3 +51,17 @@ func (l *LimitedReader) Read(p []byte) (n int, err error) {
 	}
 	return l.r.Read(p)
 }
+
+// gzipIt compresses the input ([]byte)
+func gzipIt(input []byte) ([]byte, error) {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	if _, err := w.Write(input); err != nil {
+		return nil, err
+	}
+	// close first to flush the bytes to the buffer
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
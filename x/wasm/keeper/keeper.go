@@ -2,7 +2,9 @@ package keeper
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"math"
 	"path/filepath"
@@ -21,6 +23,7 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	"github.com/tendermint/tendermint/libs/log"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -29,6 +32,11 @@ import (
 // constant value so all nodes run with the same limit.
 const contractMemoryLimit = 32
 
+// DefaultMaxCallDepth is the default max depth of nested message dispatches. A contract that dispatches
+// messages starts a new level, so that a contract calling back into itself fails deterministically
+// at this depth, independent of the gas left.
+const DefaultMaxCallDepth = 20
+
 // Option is an extension point to instantiate keeper with non default values
 type Option interface {
 	apply(*Keeper)
@@ -57,12 +65,18 @@ type WasmVMResponseHandler interface {
 	) ([]byte, error)
 }
 
+var (
+	_ types.ViewKeeper = Keeper{}
+	_ decoratedKeeper  = Keeper{}
+)
+
 // Keeper will have a reference to Wasmer with it's own data directory.
 type Keeper struct {
 	storeKey              sdk.StoreKey
 	cdc                   codec.Codec
 	accountKeeper         types.AccountKeeper
 	bank                  CoinTransferrer
+	burner                types.Burner
 	portKeeper            types.PortKeeper
 	capabilityKeeper      types.CapabilityKeeper
 	wasmVM                types.WasmerEngine
@@ -73,6 +87,37 @@ type Keeper struct {
 	queryGasLimit uint64
 	paramSpace    paramtypes.Subspace
 	gasRegister   GasRegister
+	// customAPICosts are optional wasmvm gas costs of the address API, see WithAPICosts
+	customAPICosts *apiCosts
+	// maxCallDepth is the max depth of nested message dispatches from contracts
+	maxCallDepth uint32
+	// cronGasLimit is the max gas for a single begin or end block call to a contract
+	cronGasLimit uint64
+	// maxDeferredCallsPerBlock is the max number of deferred calls that are delivered in a single block
+	maxDeferredCallsPerBlock uint32
+	// maxDeferredCallsPerContract is the max number of pending deferred calls of a single contract
+	maxDeferredCallsPerContract uint32
+	// capabilityRequirement is optional and decorates the messenger with a capability check when set
+	capabilityRequirement CapabilityRequirement
+	// stateRent is optional and charges contracts for their state size when set
+	stateRent *StateRentConfig
+	// adminTimelock is the number of blocks after which admin changes and migrations by msg take effect
+	adminTimelock uint64
+	// maxTimelockedCallsPerBlock is the max number of timelocked migrations and operator sudo calls in a single block
+	maxTimelockedCallsPerBlock uint32
+	// hooks are optional and called on contract lifecycle events
+	hooks types.WasmHooks
+	// contractDebugMode logs each VM call so that the contract debug output can be attributed
+	contractDebugMode bool
+	// smartQueryDisabled rejects smart queries on the query server. This is node config and not consensus relevant.
+	smartQueryDisabled bool
+	// smartQueryRateLimit is the max number of smart queries per second and client on the query server
+	smartQueryRateLimit uint32
+	// tracer records spans for the VM calls
+	tracer trace.Tracer
+	// metricsContracts are the bech32 addresses that get their own contract label in the VM call metrics.
+	// This is node config and not consensus relevant.
+	metricsContracts map[string]struct{}
 }
 
 // NewKeeper creates a new contract Keeper instance
@@ -106,22 +151,41 @@ func NewKeeper(
 	}
 
 	keeper := &Keeper{
-		storeKey:         storeKey,
-		cdc:              cdc,
-		wasmVM:           wasmer,
-		accountKeeper:    accountKeeper,
-		bank:             NewBankCoinTransferrer(bankKeeper),
-		portKeeper:       portKeeper,
-		capabilityKeeper: capabilityKeeper,
-		messenger:        NewDefaultMessageHandler(router, channelKeeper, capabilityKeeper, bankKeeper, cdc, portSource),
-		queryGasLimit:    wasmConfig.SmartQueryGasLimit,
-		paramSpace:       paramSpace,
-		gasRegister:      NewDefaultWasmGasRegister(),
+		storeKey:                    storeKey,
+		cdc:                         cdc,
+		wasmVM:                      wasmer,
+		accountKeeper:               accountKeeper,
+		bank:                        NewBankCoinTransferrer(bankKeeper),
+		burner:                      bankKeeper,
+		portKeeper:                  portKeeper,
+		capabilityKeeper:            capabilityKeeper,
+		messenger:                   NewDefaultMessageHandler(router, channelKeeper, capabilityKeeper, bankKeeper, cdc, portSource),
+		queryGasLimit:               wasmConfig.SmartQueryGasLimit,
+		paramSpace:                  paramSpace,
+		gasRegister:                 NewDefaultWasmGasRegister(),
+		maxCallDepth:                DefaultMaxCallDepth,
+		cronGasLimit:                DefaultCronGasLimit,
+		maxDeferredCallsPerBlock:    DefaultMaxDeferredCallsPerBlock,
+		maxDeferredCallsPerContract: DefaultMaxDeferredCallsPerContract,
+		maxTimelockedCallsPerBlock:  DefaultMaxTimelockedCallsPerBlock,
+		metricsContracts:            make(map[string]struct{}, len(wasmConfig.MetricsContracts)),
+		contractDebugMode:           wasmConfig.ContractDebugMode,
+		tracer:                      defaultTracer(),
+
+		smartQueryDisabled:  wasmConfig.SmartQueryDisabled,
+		smartQueryRateLimit: wasmConfig.SmartQueryRateLimit,
+	}
+	for _, c := range wasmConfig.MetricsContracts {
+		keeper.metricsContracts[c] = struct{}{}
 	}
 	keeper.wasmVMQueryHandler = DefaultQueryPlugins(bankKeeper, stakingKeeper, distKeeper, channelKeeper, queryRouter, keeper)
 	for _, o := range opts {
 		o.apply(keeper)
 	}
+	// decorate last so that the check is not replaced by a message handler option
+	if keeper.capabilityRequirement != nil {
+		keeper.messenger = NewCapabilityMessageHandler(keeper.messenger, keeper, keeper.capabilityRequirement)
+	}
 	// not updateable, yet
 	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(keeper.messenger, keeper))
 	return *keeper
@@ -139,6 +203,79 @@ func (k Keeper) getInstantiateAccessConfig(ctx sdk.Context) types.AccessType {
 	return a
 }
 
+func (k Keeper) getMaxContractMsgSize(ctx sdk.Context) uint64 {
+	var a uint64
+	k.paramSpace.Get(ctx, types.ParamStoreKeyMaxContractMsgSize, &a)
+	return a
+}
+
+func (k Keeper) getMaxContractResponseDataSize(ctx sdk.Context) uint64 {
+	var a uint64
+	k.paramSpace.Get(ctx, types.ParamStoreKeyMaxContractResponseDataSize, &a)
+	return a
+}
+
+func (k Keeper) getDeniedFundsDenoms(ctx sdk.Context) []string {
+	var a []string
+	k.paramSpace.Get(ctx, types.ParamStoreKeyDeniedFundsDenoms, &a)
+	return a
+}
+
+func (k Keeper) getInstanceCost(ctx sdk.Context) sdk.Gas {
+	var a uint64
+	k.paramSpace.Get(ctx, types.ParamStoreKeyInstanceCost, &a)
+	return a
+}
+
+func (k Keeper) getCompileCost(ctx sdk.Context) sdk.Gas {
+	var a uint64
+	k.paramSpace.Get(ctx, types.ParamStoreKeyCompileCost, &a)
+	return a
+}
+
+// IsDefaultCreatorAdmin returns true when the creator becomes admin of a new contract without an explicit admin
+func (k Keeper) IsDefaultCreatorAdmin(ctx sdk.Context) bool {
+	var a bool
+	k.paramSpace.Get(ctx, types.ParamStoreKeyDefaultCreatorAdmin, &a)
+	return a
+}
+
+// IsDeniedFundsDenom returns true when the denom can not be sent as funds to a contract
+func (k Keeper) IsDeniedFundsDenom(ctx sdk.Context, denom string) bool {
+	for _, d := range k.getDeniedFundsDenoms(ctx) {
+		if d == denom {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAdminTimelock returns true when admin changes and migrations by msg are scheduled instead of applied
+func (k Keeper) HasAdminTimelock() bool {
+	return k.adminTimelock != 0
+}
+
+// compileGasRegister returns the gas register with the compile cost from the params applied.
+// The param is read without charging gas as it is part of the costs that are charged.
+func (k Keeper) compileGasRegister(ctx sdk.Context) GasRegister {
+	r, ok := k.gasRegister.(ParamsGasRegister)
+	if !ok {
+		return k.gasRegister
+	}
+	return r.WithCompileCost(k.getCompileCost(ctx.WithGasMeter(sdk.NewInfiniteGasMeter())))
+}
+
+// instanceGasRegister returns the gas register with the instance cost from the params applied.
+// The param is read on every contract call so that it is read without charging gas as it is
+// part of the costs that are charged. Pinned code is not charged the instance cost.
+func (k Keeper) instanceGasRegister(ctx sdk.Context, pinned bool) GasRegister {
+	r, ok := k.gasRegister.(ParamsGasRegister)
+	if !ok || pinned {
+		return k.gasRegister
+	}
+	return r.WithInstanceCost(k.getInstanceCost(ctx.WithGasMeter(sdk.NewInfiniteGasMeter())))
+}
+
 // GetParams returns the total set of wasm parameters.
 func (k Keeper) GetParams(ctx sdk.Context) types.Params {
 	var params types.Params
@@ -162,7 +299,10 @@ func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte,
 	if err != nil {
 		return 0, sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
 	}
-	ctx.GasMeter().ConsumeGas(k.gasRegister.CompileCosts(len(wasmCode)), "Compiling WASM Bytecode")
+	ctx.GasMeter().ConsumeGas(k.compileGasRegister(ctx).CompileCosts(len(wasmCode)), "Compiling WASM Bytecode")
+	if err := validateWasmCode(wasmCode); err != nil {
+		return 0, sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
+	}
 
 	checksum, err := k.wasmVM.Create(wasmCode)
 	if err != nil {
@@ -184,6 +324,7 @@ func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte,
 	evt := sdk.NewEvent(
 		types.EventTypeStoreCode,
 		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(checksum)),
 	)
 	for _, f := range strings.Split(report.RequiredFeatures, ",") {
 		evt.AppendAttributes(sdk.NewAttribute(types.AttributeKeyFeature, strings.TrimSpace(f)))
@@ -193,6 +334,28 @@ func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte,
 	return codeID, nil
 }
 
+// setCodeSource stores the optional source url and builder image with the code info so that the byte code can be
+// verified against a reproducible build. Only the creator of the code can set them.
+func (k Keeper) setCodeSource(ctx sdk.Context, codeID uint64, caller sdk.AccAddress, source, builder string) error {
+	if err := types.ValidateSource(source); err != nil {
+		return sdkerrors.Wrap(err, "source")
+	}
+	if err := types.ValidateBuilder(builder); err != nil {
+		return sdkerrors.Wrap(err, "builder")
+	}
+	codeInfo := k.GetCodeInfo(ctx, codeID)
+	if codeInfo == nil {
+		return sdkerrors.Wrap(types.ErrNotFound, "code info")
+	}
+	if codeInfo.Creator != caller.String() {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "not the code creator")
+	}
+	codeInfo.Source = source
+	codeInfo.Builder = builder
+	k.storeCodeInfo(ctx, codeID, *codeInfo)
+	return nil
+}
+
 func (k Keeper) storeCodeInfo(ctx sdk.Context, codeID uint64, codeInfo types.CodeInfo) {
 	store := ctx.KVStore(k.storeKey)
 	// 0x01 | codeID (uint64) -> ContractInfo
@@ -204,6 +367,10 @@ func (k Keeper) importCode(ctx sdk.Context, codeID uint64, codeInfo types.CodeIn
 	if err != nil {
 		return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
 	}
+	// fail fast on corrupted or tampered byte code before compiling it
+	if checksum := sha256.Sum256(wasmCode); !bytes.Equal(codeInfo.CodeHash, checksum[:]) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "code hashes not same: expected %X, got %X", codeInfo.CodeHash, checksum)
+	}
 	newCodeHash, err := k.wasmVM.Create(wasmCode)
 	if err != nil {
 		return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
@@ -222,14 +389,46 @@ func (k Keeper) importCode(ctx sdk.Context, codeID uint64, codeInfo types.CodeIn
 	return nil
 }
 
-func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error) {
+// importPrunedCode stores the code info of a pruned code without byte code
+func (k Keeper) importPrunedCode(ctx sdk.Context, codeID uint64, codeInfo types.CodeInfo) error {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetCodeKey(codeID)
+	if store.Has(key) {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "duplicate code: %d", codeID)
+	}
+	// 0x01 | codeID (uint64) -> ContractInfo
+	store.Set(key, k.cdc.MustMarshal(&codeInfo))
+	k.storePrunedCode(ctx, codeID)
+	return nil
+}
+
+func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, addressGenerator AddressGenerator, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "instantiate")
+	if err := types.ValidateLabel(label); err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "label")
+	}
+	if err := k.checkContractMsgSize(ctx, initMsg); err != nil {
+		return nil, nil, err
+	}
 
-	instanceCosts := k.gasRegister.NewContractInstanceCosts(k.IsPinnedCode(ctx, codeID), len(initMsg))
+	pinned := k.IsPinnedCode(ctx, codeID)
+	instanceCosts := k.instanceGasRegister(ctx, pinned).NewContractInstanceCosts(pinned, len(initMsg))
 	ctx.GasMeter().ConsumeGas(instanceCosts, "Loading CosmWasm module: instantiate")
 
+	// get contact info
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetCodeKey(codeID))
+	if bz == nil {
+		return nil, nil, sdkerrors.Wrap(types.ErrNotFound, "code")
+	}
+	var codeInfo types.CodeInfo
+	k.cdc.MustUnmarshal(bz, &codeInfo)
+	if k.IsPrunedCode(ctx, codeID) {
+		return nil, nil, sdkerrors.Wrapf(types.ErrPrunedCode, "code %d", codeID)
+	}
+
 	// create contract address
-	contractAddress := k.generateContractAddress(ctx, codeID)
+	contractAddress := addressGenerator(ctx, codeID, codeInfo.CodeHash)
 	existingAcct := k.accountKeeper.GetAccount(ctx, contractAddress)
 	if existingAcct != nil {
 		return nil, nil, sdkerrors.Wrap(types.ErrAccountExists, existingAcct.GetAddress().String())
@@ -248,15 +447,6 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 		k.accountKeeper.SetAccount(ctx, contractAccount)
 	}
 
-	// get contact info
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(types.GetCodeKey(codeID))
-	if bz == nil {
-		return nil, nil, sdkerrors.Wrap(types.ErrNotFound, "code")
-	}
-	var codeInfo types.CodeInfo
-	k.cdc.MustUnmarshal(bz, &codeInfo)
-
 	if !authZ.CanInstantiateContract(codeInfo.InstantiateConfig, creator) {
 		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not instantiate")
 	}
@@ -266,19 +456,23 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 	info := types.NewInfo(creator, deposit)
 
 	// create prefixed data store
-	// 0x03 | BuildContractAddress (sdk.AccAddress)
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
+	prefixStore := k.contractStateStore(ctx, contractAddress)
+
+	ctx, span := k.startContractSpan(ctx, "instantiate", codeID, contractAddress, initMsg)
+	defer span.End()
 
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
 
 	// instantiate wasm contract
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, err := k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	start := time.Now()
+	res, gasUsed, err := k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, prefixStore, k.cosmwasmAPI(), querier, k.gasMeter(ctx), gas, k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "instantiate", codeID, contractAddress, start, gasUsed, err)
+	k.traceVMCall(span, gasUsed, err)
 	if err != nil {
-		return nil, nil, sdkerrors.Wrap(types.ErrInstantiateFailed, err.Error())
+		return nil, nil, types.NewErrContractFailure(contractAddress, err, types.ErrInstantiateFailed)
 	}
 
 	// persist instance first
@@ -302,6 +496,10 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 	// store contract before dispatch so that contract could be called back
 	historyEntry := contractInfo.InitialHistory(initMsg)
 	k.addToContractCodeSecondaryIndex(ctx, contractAddress, historyEntry)
+	k.addToContractCreatorSecondaryIndex(ctx, creator, historyEntry.Updated, contractAddress)
+	if admin != nil {
+		k.addToContractAdminSecondaryIndex(ctx, admin, contractInfo.Created, contractAddress)
+	}
 	k.appendToContractHistory(ctx, contractAddress, historyEntry)
 	k.storeContractInfo(ctx, contractAddress, &contractInfo)
 
@@ -315,6 +513,11 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 	if err != nil {
 		return nil, nil, sdkerrors.Wrap(err, "dispatch")
 	}
+	if k.hooks != nil {
+		if err := k.hooks.AfterContractInstantiated(ctx, contractAddress, creator, codeID); err != nil {
+			return nil, nil, sdkerrors.Wrap(err, "after instantiate hook")
+		}
+	}
 
 	return contractAddress, data, nil
 }
@@ -322,12 +525,53 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 // Execute executes the contract instance
 func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "execute")
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
+	if err := k.checkContractMsgSize(ctx, msg); err != nil {
+		return nil, err
+	}
+	contractInfo, codeInfo, prefixStore, err := k.activeContractInstance(ctx, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if contractInfo.ExecuteGasLimit != 0 {
+		return k.executeWithGasLimit(ctx, contractAddress, contractInfo, codeInfo, caller, msg, coins)
+	}
+	return k.executeContract(ctx, contractAddress, contractInfo, codeInfo, prefixStore, caller, msg, coins)
+}
+
+// executeWithGasLimit runs the contract execution in a cached context with a gas meter that is bounded by the
+// execute gas limit of the contract. Running out of gas in the limited meter fails the execution but does not abort
+// the transaction. The state changes and events are only committed when the execution succeeds.
+func (k Keeper) executeWithGasLimit(ctx sdk.Context, contractAddress sdk.AccAddress, contractInfo types.ContractInfo, codeInfo types.CodeInfo, caller sdk.AccAddress, msg []byte, coins sdk.Coins) (data []byte, err error) {
+	gasLimit := contractInfo.ExecuteGasLimit
+	subCtx, commit := ctx.CacheContext()
+	subCtx = subCtx.WithGasMeter(sdk.NewGasMeter(gasLimit))
+	// catch out of gas panic and charge the entire gas limit
+	defer func() {
+		if r := recover(); r != nil {
+			// if it's not an OutOfGas error, raise it again
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			ctx.GasMeter().ConsumeGas(gasLimit, "Contract execute gas limit")
+			data, err = nil, sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "contract execute gas limit of %d exceeded", gasLimit)
+		}
+	}()
+	// the contract store must be bound to the limited gas meter
+	prefixStore := k.contractStateStore(subCtx, contractAddress)
+	data, err = k.executeContract(subCtx, contractAddress, contractInfo, codeInfo, prefixStore, caller, msg, coins)
+	// make sure we charge the parent what was spent
+	ctx.GasMeter().ConsumeGas(subCtx.GasMeter().GasConsumed(), "From limited contract execute")
 	if err != nil {
 		return nil, err
 	}
+	commit()
+	ctx.EventManager().EmitEvents(subCtx.EventManager().Events())
+	return data, nil
+}
 
-	executeCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
+func (k Keeper) executeContract(ctx sdk.Context, contractAddress sdk.AccAddress, contractInfo types.ContractInfo, codeInfo types.CodeInfo, prefixStore contractStateStore, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
+	pinned := k.IsPinnedCode(ctx, contractInfo.CodeID)
+	executeCosts := k.instanceGasRegister(ctx, pinned).InstantiateContractCosts(pinned, len(msg))
 	ctx.GasMeter().ConsumeGas(executeCosts, "Loading CosmWasm module: execute")
 
 	// add more funds
@@ -340,13 +584,19 @@ func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	env := types.NewEnv(ctx, contractAddress)
 	info := types.NewInfo(caller, coins)
 
+	ctx, span := k.startContractSpan(ctx, "execute", contractInfo.CodeID, contractAddress, msg)
+	defer span.End()
+
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	start := time.Now()
+	res, gasUsed, execErr := k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, prefixStore, k.cosmwasmAPI(), querier, k.gasMeter(ctx), gas, k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "execute", contractInfo.CodeID, contractAddress, start, gasUsed, execErr)
+	k.traceVMCall(span, gasUsed, execErr)
 	if execErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, types.NewErrContractFailure(contractAddress, execErr, types.ErrExecuteFailed)
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -358,13 +608,22 @@ func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "dispatch")
 	}
+	if k.hooks != nil {
+		if err := k.hooks.AfterContractExecuted(ctx, contractAddress, caller, msg, coins); err != nil {
+			return nil, sdkerrors.Wrap(err, "after execute hook")
+		}
+	}
 
 	return data, nil
 }
 
 func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "migrate")
-	migrateSetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, newCodeID), len(msg))
+	if err := k.checkContractMsgSize(ctx, msg); err != nil {
+		return nil, err
+	}
+	pinned := k.IsPinnedCode(ctx, newCodeID)
+	migrateSetupCosts := k.instanceGasRegister(ctx, pinned).InstantiateContractCosts(pinned, len(msg))
 	ctx.GasMeter().ConsumeGas(migrateSetupCosts, "Loading CosmWasm module: migrate")
 
 	contractInfo := k.GetContractInfo(ctx, contractAddress)
@@ -374,11 +633,17 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not migrate")
 	}
+	if k.IsInactiveContract(ctx, contractAddress) {
+		return nil, sdkerrors.Wrap(types.ErrInactiveContract, contractAddress.String())
+	}
 
 	newCodeInfo := k.GetCodeInfo(ctx, newCodeID)
 	if newCodeInfo == nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown code")
 	}
+	if k.IsPrunedCode(ctx, newCodeID) {
+		return nil, sdkerrors.Wrapf(types.ErrPrunedCode, "code %d", newCodeID)
+	}
 
 	// check for IBC flag
 	switch report, err := k.wasmVM.AnalyzeCode(newCodeInfo.CodeHash); {
@@ -398,18 +663,24 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 
 	env := types.NewEnv(ctx, contractAddress)
 
+	ctx, span := k.startContractSpan(ctx, "migrate", newCodeID, contractAddress, msg)
+	defer span.End()
+
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
 
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
+	prefixStore := k.contractStateStore(ctx, contractAddress)
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, err := k.wasmVM.Migrate(newCodeInfo.CodeHash, env, msg, &prefixStore, cosmwasmAPI, &querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	start := time.Now()
+	res, gasUsed, err := k.wasmVM.Migrate(newCodeInfo.CodeHash, env, msg, &prefixStore, k.cosmwasmAPI(), &querier, k.gasMeter(ctx), gas, k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "migrate", newCodeID, contractAddress, start, gasUsed, err)
+	k.traceVMCall(span, gasUsed, err)
 	if err != nil {
-		return nil, sdkerrors.Wrap(types.ErrMigrationFailed, err.Error())
+		return nil, types.NewErrContractFailure(contractAddress, err, types.ErrMigrationFailed)
 	}
 
+	oldCodeID := contractInfo.CodeID
 	// delete old secondary index entry
 	k.removeFromContractCodeSecondaryIndex(ctx, contractAddress, k.getLastContractHistoryEntry(ctx, contractAddress))
 	// persist migration updates
@@ -417,6 +688,8 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	k.appendToContractHistory(ctx, contractAddress, historyEntry)
 	k.addToContractCodeSecondaryIndex(ctx, contractAddress, historyEntry)
 	k.storeContractInfo(ctx, contractAddress, contractInfo)
+	// governance granted the capabilities for the old code
+	k.revokeAllContractCapabilities(ctx, contractAddress)
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeMigrate,
@@ -428,6 +701,11 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "dispatch")
 	}
+	if k.hooks != nil {
+		if err := k.hooks.AfterContractMigrated(ctx, contractAddress, oldCodeID, newCodeID); err != nil {
+			return nil, sdkerrors.Wrap(err, "after migrate hook")
+		}
+	}
 
 	return data, nil
 }
@@ -442,18 +720,25 @@ func (k Keeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte
 		return nil, err
 	}
 
-	sudoSetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
+	pinned := k.IsPinnedCode(ctx, contractInfo.CodeID)
+	sudoSetupCosts := k.instanceGasRegister(ctx, pinned).InstantiateContractCosts(pinned, len(msg))
 	ctx.GasMeter().ConsumeGas(sudoSetupCosts, "Loading CosmWasm module: sudo")
 
 	env := types.NewEnv(ctx, contractAddress)
 
+	ctx, span := k.startContractSpan(ctx, "sudo", contractInfo.CodeID, contractAddress, msg)
+	defer span.End()
+
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	start := time.Now()
+	res, gasUsed, execErr := k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, prefixStore, k.cosmwasmAPI(), querier, k.gasMeter(ctx), gas, k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "sudo", contractInfo.CodeID, contractAddress, start, gasUsed, execErr)
+	k.traceVMCall(span, gasUsed, execErr)
 	if execErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, types.NewErrContractFailure(contractAddress, execErr, types.ErrExecuteFailed)
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -482,13 +767,19 @@ func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply was
 
 	env := types.NewEnv(ctx, contractAddress)
 
+	ctx, span := k.startContractSpan(ctx, "reply", contractInfo.CodeID, contractAddress, nil)
+	defer span.End()
+
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Reply(codeInfo.CodeHash, env, reply, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	start := time.Now()
+	res, gasUsed, execErr := k.wasmVM.Reply(codeInfo.CodeHash, env, reply, prefixStore, k.cosmwasmAPI(), querier, k.gasMeter(ctx), gas, k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "reply", contractInfo.CodeID, contractAddress, start, gasUsed, execErr)
+	k.traceVMCall(span, gasUsed, execErr)
 	if execErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, types.NewErrContractFailure(contractAddress, execErr, types.ErrExecuteFailed)
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -529,6 +820,53 @@ func (k Keeper) IterateContractsByCode(ctx sdk.Context, codeID uint64, cb func(a
 	}
 }
 
+// addToContractCreatorSecondaryIndex adds element to the index for contracts-by-creator queries
+func (k Keeper) addToContractCreatorSecondaryIndex(ctx sdk.Context, creatorAddress sdk.AccAddress, position *types.AbsoluteTxPosition, contractAddress sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetContractByCreatorSecondaryIndexKey(creatorAddress, position, contractAddress), []byte{})
+}
+
+// IterateContractsByCreator iterates over all contracts with given creator address ASC on creation time.
+func (k Keeper) IterateContractsByCreator(ctx sdk.Context, creator sdk.AccAddress, cb func(address sdk.AccAddress) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractsByCreatorPrefix(creator))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if cb(key[types.AbsoluteTxPositionLen:]) {
+			return
+		}
+	}
+}
+
+// addToContractAdminSecondaryIndex adds element to the index for contracts-by-admin queries
+func (k Keeper) addToContractAdminSecondaryIndex(ctx sdk.Context, adminAddress sdk.AccAddress, created *types.AbsoluteTxPosition, contractAddress sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetContractByAdminSecondaryIndexKey(adminAddress, created, contractAddress), []byte{})
+}
+
+// removeFromContractAdminSecondaryIndex removes element from the index for contracts-by-admin queries
+func (k Keeper) removeFromContractAdminSecondaryIndex(ctx sdk.Context, adminAddress sdk.AccAddress, created *types.AbsoluteTxPosition, contractAddress sdk.AccAddress) {
+	ctx.KVStore(k.storeKey).Delete(types.GetContractByAdminSecondaryIndexKey(adminAddress, created, contractAddress))
+}
+
+// IterateContractsByAdmin iterates over all contracts with given admin address ASC on creation time.
+func (k Keeper) IterateContractsByAdmin(ctx sdk.Context, admin sdk.AccAddress, cb func(address sdk.AccAddress) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractsByAdminPrefix(admin))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if cb(key[types.AbsoluteTxPositionLen:]) {
+			return
+		}
+	}
+}
+
+// setContractAdmin sets a new admin or clears it when nil. The operator of the contract was appointed by the
+// previous admin and is removed.
 func (k Keeper) setContractAdmin(ctx sdk.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ AuthorizationPolicy) error {
 	contractInfo := k.GetContractInfo(ctx, contractAddress)
 	if contractInfo == nil {
@@ -537,8 +875,56 @@ func (k Keeper) setContractAdmin(ctx sdk.Context, contractAddress, caller, newAd
 	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
 		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
 	}
+	if oldAdmin := contractInfo.AdminAddr(); oldAdmin != nil {
+		k.removeFromContractAdminSecondaryIndex(ctx, oldAdmin, contractInfo.Created, contractAddress)
+	}
 	contractInfo.Admin = newAdmin.String()
+	// the operator was set by the previous admin
+	hadOperator := contractInfo.Operator != ""
+	contractInfo.Operator, contractInfo.OperatorSudoMsgs = "", nil
+	k.storeContractInfo(ctx, contractAddress, contractInfo)
+	if hadOperator {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeUpdateOperator,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyOperator, ""),
+		))
+	}
+	if newAdmin == nil {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeClearAdmin,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		))
+		return nil
+	}
+	k.addToContractAdminSecondaryIndex(ctx, newAdmin, contractInfo.Created, contractAddress)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateAdmin,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyAdmin, newAdmin.String()),
+	))
+	return nil
+}
+
+// setExecuteGasLimit sets the max gas a single execution of the contract may consume. Zero removes the limit.
+// It can be set by the admin or the operator of the contract.
+func (k Keeper) setExecuteGasLimit(ctx sdk.Context, contractAddress, caller sdk.AccAddress, gasLimit uint64, authZ AuthorizationPolicy) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanOperateContract(contractInfo.AdminAddr(), contractInfo.OperatorAddr(), caller) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	contractInfo.ExecuteGasLimit = gasLimit
 	k.storeContractInfo(ctx, contractAddress, contractInfo)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeExecuteGasLimit,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyGasLimit, strconv.FormatUint(gasLimit, 10)),
+	))
 	return nil
 }
 
@@ -551,7 +937,9 @@ func (k Keeper) appendToContractHistory(ctx sdk.Context, contractAddr sdk.AccAdd
 	defer iter.Close()
 
 	if iter.Valid() {
-		pos = sdk.BigEndianToUint64(iter.Value())
+		// the position is the key of the last element. Reading it from the value, as done before, returned the
+		// leading bytes of the encoded entry so that similar entries got the same position and overwrote each other.
+		pos = sdk.BigEndianToUint64(iter.Key())
 	}
 	// then store with incrementing position
 	for _, e := range newEntries {
@@ -598,17 +986,24 @@ func (k Keeper) QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []b
 		return nil, err
 	}
 
-	smartQuerySetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(req))
+	pinned := k.IsPinnedCode(ctx, contractInfo.CodeID)
+	smartQuerySetupCosts := k.instanceGasRegister(ctx, pinned).InstantiateContractCosts(pinned, len(req))
 	ctx.GasMeter().ConsumeGas(smartQuerySetupCosts, "Loading CosmWasm module: query")
 
+	ctx, span := k.startContractSpan(ctx, "query-smart", contractInfo.CodeID, contractAddr, req)
+	defer span.End()
+
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	env := types.NewEnv(ctx, contractAddr)
-	queryResult, gasUsed, qErr := k.wasmVM.Query(codeInfo.CodeHash, env, req, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), k.runtimeGasForContract(ctx), costJSONDeserialization)
+	start := time.Now()
+	queryResult, gasUsed, qErr := k.wasmVM.Query(codeInfo.CodeHash, env, req, prefixStore, k.cosmwasmAPI(), querier, k.gasMeter(ctx), k.runtimeGasForContract(ctx), k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "query-smart", contractInfo.CodeID, contractAddr, start, gasUsed, qErr)
+	k.traceVMCall(span, gasUsed, qErr)
 	if qErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrQueryFailed, qErr.Error())
+		return nil, types.NewErrContractFailure(contractAddr, qErr, types.ErrQueryFailed)
 	}
 	return queryResult, nil
 }
@@ -624,24 +1019,42 @@ func (k Keeper) QueryRaw(ctx sdk.Context, contractAddress sdk.AccAddress, key []
 	return prefixStore.Get(key)
 }
 
-func (k Keeper) contractInstance(ctx sdk.Context, contractAddress sdk.AccAddress) (types.ContractInfo, types.CodeInfo, prefix.Store, error) {
+// checkContractMsgSize returns an error when the message is longer than the max contract message size param
+func (k Keeper) checkContractMsgSize(ctx sdk.Context, msg []byte) error {
+	if max := k.getMaxContractMsgSize(ctx); uint64(len(msg)) > max {
+		return sdkerrors.Wrapf(types.ErrLimit, "contract message cannot be longer than %d bytes", max)
+	}
+	return nil
+}
+
+func (k Keeper) contractInstance(ctx sdk.Context, contractAddress sdk.AccAddress) (types.ContractInfo, types.CodeInfo, contractStateStore, error) {
 	store := ctx.KVStore(k.storeKey)
 
 	contractBz := store.Get(types.GetContractAddressKey(contractAddress))
 	if contractBz == nil {
-		return types.ContractInfo{}, types.CodeInfo{}, prefix.Store{}, sdkerrors.Wrap(types.ErrNotFound, "contract")
+		return types.ContractInfo{}, types.CodeInfo{}, contractStateStore{}, sdkerrors.Wrap(types.ErrNotFound, "contract")
 	}
 	var contractInfo types.ContractInfo
 	k.cdc.MustUnmarshal(contractBz, &contractInfo)
 
 	codeInfoBz := store.Get(types.GetCodeKey(contractInfo.CodeID))
 	if codeInfoBz == nil {
-		return contractInfo, types.CodeInfo{}, prefix.Store{}, sdkerrors.Wrap(types.ErrNotFound, "code info")
+		return contractInfo, types.CodeInfo{}, contractStateStore{}, sdkerrors.Wrap(types.ErrNotFound, "code info")
 	}
 	var codeInfo types.CodeInfo
 	k.cdc.MustUnmarshal(codeInfoBz, &codeInfo)
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
+	return contractInfo, codeInfo, k.contractStateStore(ctx, contractAddress), nil
+}
+
+// activeContractInstance is like contractInstance but fails when the contract was deactivated
+func (k Keeper) activeContractInstance(ctx sdk.Context, contractAddress sdk.AccAddress) (types.ContractInfo, types.CodeInfo, contractStateStore, error) {
+	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
+	if err != nil {
+		return contractInfo, codeInfo, prefixStore, err
+	}
+	if k.IsInactiveContract(ctx, contractAddress) {
+		return contractInfo, codeInfo, prefixStore, sdkerrors.Wrap(types.ErrInactiveContract, contractAddress.String())
+	}
 	return contractInfo, codeInfo, prefixStore, nil
 }
 
@@ -698,8 +1111,7 @@ func (k Keeper) IterateContractState(ctx sdk.Context, contractAddress sdk.AccAdd
 }
 
 func (k Keeper) importContractState(ctx sdk.Context, contractAddress sdk.AccAddress, models []types.Model) error {
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
+	prefixStore := k.contractStateStore(ctx, contractAddress)
 	for _, model := range models {
 		if model.Value == nil {
 			model.Value = []byte{}
@@ -743,6 +1155,31 @@ func (k Keeper) IterateCodeInfos(ctx sdk.Context, cb func(uint64, types.CodeInfo
 	}
 }
 
+// GetChecksumsInUse returns the checksums of all codes that are pinned or have at least one contract instance.
+// Duplicates are returned only once.
+func (k Keeper) GetChecksumsInUse(ctx sdk.Context) [][]byte {
+	var result [][]byte
+	seen := make(map[string]bool)
+	k.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+		if seen[string(info.CodeHash)] {
+			return false
+		}
+		inUse := k.IsPinnedCode(ctx, codeID)
+		if !inUse {
+			k.IterateContractsByCode(ctx, codeID, func(_ sdk.AccAddress) bool {
+				inUse = true
+				return true
+			})
+		}
+		if inUse {
+			seen[string(info.CodeHash)] = true
+			result = append(result, info.CodeHash)
+		}
+		return false
+	})
+	return result
+}
+
 func (k Keeper) GetByteCode(ctx sdk.Context, codeID uint64) ([]byte, error) {
 	store := ctx.KVStore(k.storeKey)
 	var codeInfo types.CodeInfo
@@ -751,6 +1188,9 @@ func (k Keeper) GetByteCode(ctx sdk.Context, codeID uint64) ([]byte, error) {
 		return nil, nil
 	}
 	k.cdc.MustUnmarshal(codeInfoBz, &codeInfo)
+	if k.IsPrunedCode(ctx, codeID) {
+		return nil, nil
+	}
 	return k.wasmVM.GetCode(codeInfo.CodeHash)
 }
 
@@ -760,6 +1200,9 @@ func (k Keeper) pinCode(ctx sdk.Context, codeID uint64) error {
 	if codeInfo == nil {
 		return sdkerrors.Wrap(types.ErrNotFound, "code info")
 	}
+	if k.IsPrunedCode(ctx, codeID) {
+		return sdkerrors.Wrapf(types.ErrPrunedCode, "code %d", codeID)
+	}
 
 	if err := k.wasmVM.Pin(codeInfo.CodeHash); err != nil {
 		return sdkerrors.Wrap(types.ErrPinContractFailed, err.Error())
@@ -801,6 +1244,51 @@ func (k Keeper) IsPinnedCode(ctx sdk.Context, codeID uint64) bool {
 	return store.Has(types.GetPinnedCodeIndexPrefix(codeID))
 }
 
+// pruneCode marks the byte code of an unused and unpinned code id as deleted. The code info is kept.
+func (k Keeper) pruneCode(ctx sdk.Context, codeID uint64) error {
+	if !k.containsCodeInfo(ctx, codeID) {
+		return sdkerrors.Wrap(types.ErrNotFound, "code info")
+	}
+	if k.IsPrunedCode(ctx, codeID) {
+		return sdkerrors.Wrapf(types.ErrPrunedCode, "code %d", codeID)
+	}
+	if k.IsPinnedCode(ctx, codeID) {
+		return sdkerrors.Wrap(types.ErrInvalid, "code is pinned")
+	}
+	var used bool
+	k.IterateContractsByCode(ctx, codeID, func(sdk.AccAddress) bool {
+		used = true
+		return true
+	})
+	if used {
+		return sdkerrors.Wrap(types.ErrInvalid, "code has contracts")
+	}
+	k.storePrunedCode(ctx, codeID)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypePruneCode,
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+	))
+	return nil
+}
+
+func (k Keeper) storePrunedCode(ctx sdk.Context, codeID uint64) {
+	store := ctx.KVStore(k.storeKey)
+	// store 1 byte to not run into `nil` debugging issues
+	store.Set(types.GetPrunedCodeIndexKey(codeID), []byte{1})
+}
+
+// IsPrunedCode returns true when the byte code of the codeID was pruned
+func (k Keeper) IsPrunedCode(ctx sdk.Context, codeID uint64) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetPrunedCodeIndexKey(codeID))
+}
+
+// GetVMCacheMetrics returns the cache statistics of the wasm VM of this node
+func (k Keeper) GetVMCacheMetrics() (*wasmvmtypes.Metrics, error) {
+	return k.wasmVM.GetMetrics()
+}
+
 // InitializePinnedCodes updates wasmvm to pin to cache all contracts marked as pinned
 func (k Keeper) InitializePinnedCodes(ctx sdk.Context) error {
 	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.PinnedCodeIndexPrefix)
@@ -819,19 +1307,77 @@ func (k Keeper) InitializePinnedCodes(ctx sdk.Context) error {
 	return nil
 }
 
-// setContractInfoExtension updates the extension point data that is stored with the contract info
+// setContractInfoExtension updates the extension point data that is stored with the contract info.
+// The extension is validated when it implements `ValidateBasic() error`. A nil extension removes the data.
 func (k Keeper) setContractInfoExtension(ctx sdk.Context, contractAddr sdk.AccAddress, ext types.ContractInfoExtension) error {
 	info := k.GetContractInfo(ctx, contractAddr)
 	if info == nil {
 		return sdkerrors.Wrap(types.ErrNotFound, "contract info")
 	}
 	if err := info.SetExtension(ext); err != nil {
-		return err
+		return sdkerrors.Wrap(err, "extension")
 	}
 	k.storeContractInfo(ctx, contractAddr, info)
+
+	var extType string
+	if info.Extension != nil {
+		extType = info.Extension.TypeUrl
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateContractInfoExtension,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyExtensionType, extType),
+	))
+	return nil
+}
+
+// ReadContractInfoExtension copies the extension data that is stored with the contract info to the pointer passed
+// as argument. The value is not modified when the contract has no extension data.
+func (k Keeper) ReadContractInfoExtension(ctx sdk.Context, contractAddr sdk.AccAddress, ext types.ContractInfoExtension) error {
+	info := k.GetContractInfo(ctx, contractAddr)
+	if info == nil {
+		return sdkerrors.Wrap(types.ErrNotFound, "contract info")
+	}
+	return info.ReadExtension(ext)
+}
+
+// deactivateContract marks the contract as inactive so that it can not be executed, migrated or called via IBC
+func (k Keeper) deactivateContract(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	store := ctx.KVStore(k.storeKey)
+	// store 1 byte to not run into `nil` debugging issues
+	store.Set(types.GetInactiveContractKey(contractAddr), []byte{1})
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeDeactivate,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+	))
+	return nil
+}
+
+// activateContract removes the inactive mark from the contract
+func (k Keeper) activateContract(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetInactiveContractKey(contractAddr))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeActivate,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+	))
 	return nil
 }
 
+// IsInactiveContract returns true when the contract was deactivated by governance
+func (k Keeper) IsInactiveContract(ctx sdk.Context, contractAddr sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetInactiveContractKey(contractAddr))
+}
+
 // handleContractResponse processes the contract response data by emitting events and sending sub-/messages.
 func (k *Keeper) handleContractResponse(
 	ctx sdk.Context,
@@ -842,8 +1388,17 @@ func (k *Keeper) handleContractResponse(
 	data []byte,
 	evts wasmvmtypes.Events,
 ) ([]byte, error) {
+	// the param is read only when there is data to not charge gas for the common case
+	if len(data) != 0 {
+		if max := k.getMaxContractResponseDataSize(ctx); uint64(len(data)) > max {
+			return nil, sdkerrors.Wrapf(types.ErrLimit, "contract response data cannot be longer than %d bytes", max)
+		}
+	}
 	attributeGasCost := k.gasRegister.EventCosts(attrs, evts)
 	ctx.GasMeter().ConsumeGas(attributeGasCost, "Custom contract event attributes")
+	if err := validateContractEventLimits(attrs, evts); err != nil {
+		return nil, err
+	}
 	// emit all events from this contract itself
 	if len(attrs) != 0 {
 		wasmEvents, err := newWasmModuleEvent(attrs, contractAddr)
@@ -859,6 +1414,13 @@ func (k *Keeper) handleContractResponse(
 		}
 		ctx.EventManager().EmitEvents(customEvents)
 	}
+	if len(msgs) != 0 {
+		depth := types.CallDepth(ctx) + 1
+		if depth > k.maxCallDepth {
+			return nil, sdkerrors.Wrapf(types.ErrLimit, "max call depth of %d exceeded", k.maxCallDepth)
+		}
+		ctx = types.WithCallDepth(ctx, depth)
+	}
 	return k.wasmVMResponseHandler.Handle(ctx, contractAddr, ibcPort, msgs, data)
 }
 
@@ -882,10 +1444,24 @@ func (k Keeper) consumeRuntimeGas(ctx sdk.Context, gas uint64) {
 	}
 }
 
-// generates a contract address from codeID + instanceID
-func (k Keeper) generateContractAddress(ctx sdk.Context, codeID uint64) sdk.AccAddress {
-	instanceID := k.autoIncrementID(ctx, types.KeyLastInstanceID)
-	return BuildContractAddress(codeID, instanceID)
+// AddressGenerator generates the address for a new contract instance
+type AddressGenerator func(ctx sdk.Context, codeID uint64, checksum []byte) sdk.AccAddress
+
+// ClassicAddressGenerator generates a contract address from codeID + instanceID
+func (k Keeper) ClassicAddressGenerator() AddressGenerator {
+	return func(ctx sdk.Context, codeID uint64, _ []byte) sdk.AccAddress {
+		instanceID := k.autoIncrementID(ctx, types.KeyLastInstanceID)
+		return BuildContractAddress(codeID, instanceID)
+	}
+}
+
+// GenesisAddressGenerator generates a contract address from the code checksum and label.
+// It is used for contracts instantiated by genesis messages so that their addresses are
+// known before the chain starts.
+func GenesisAddressGenerator(label string) AddressGenerator {
+	return func(_ sdk.Context, _ uint64, checksum []byte) sdk.AccAddress {
+		return BuildGenesisContractAddress(checksum, label)
+	}
 }
 
 // BuildContractAddress builds an sdk account address for a contract.
@@ -896,6 +1472,16 @@ func BuildContractAddress(codeID, instanceID uint64) sdk.AccAddress {
 	return address.Module(types.ModuleName, contractID)[:types.ContractAddrLen]
 }
 
+// BuildGenesisContractAddress builds an sdk account address for a contract instantiated at genesis.
+// The checksum has a fixed length so that the key can not collide with the 16 byte key used
+// by BuildContractAddress.
+func BuildGenesisContractAddress(checksum []byte, label string) sdk.AccAddress {
+	key := make([]byte, 0, len(checksum)+len(label))
+	key = append(key, checksum...)
+	key = append(key, label...)
+	return address.Module(types.ModuleName, key)[:types.ContractAddrLen]
+}
+
 func (k Keeper) autoIncrementID(ctx sdk.Context, lastIDKey []byte) uint64 {
 	store := ctx.KVStore(k.storeKey)
 	bz := store.Get(lastIDKey)
@@ -929,18 +1515,40 @@ func (k Keeper) importAutoIncrementID(ctx sdk.Context, lastIDKey []byte, val uin
 	return nil
 }
 
-func (k Keeper) importContract(ctx sdk.Context, contractAddr sdk.AccAddress, c *types.ContractInfo, state []types.Model) error {
+// importContract stores the contract with its state. When no history entries are given, a new genesis entry is
+// created. Otherwise the contract's created position is restored from the first entry.
+func (k Keeper) importContract(ctx sdk.Context, contractAddr sdk.AccAddress, c *types.ContractInfo, state []types.Model, entries []types.ContractCodeHistoryEntry) error {
 	if !k.containsCodeInfo(ctx, c.CodeID) {
 		return sdkerrors.Wrapf(types.ErrNotFound, "code id: %d", c.CodeID)
 	}
+	if k.IsPrunedCode(ctx, c.CodeID) {
+		return sdkerrors.Wrapf(types.ErrPrunedCode, "code id: %d", c.CodeID)
+	}
 	if k.HasContractInfo(ctx, contractAddr) {
 		return sdkerrors.Wrapf(types.ErrDuplicate, "contract: %s", contractAddr)
 	}
 
-	historyEntry := c.ResetFromGenesis(ctx)
-	k.appendToContractHistory(ctx, contractAddr, historyEntry)
+	creatorAddress, err := sdk.AccAddressFromBech32(c.Creator)
+	if err != nil {
+		return sdkerrors.Wrap(err, "creator")
+	}
+
+	if len(entries) == 0 {
+		entries = []types.ContractCodeHistoryEntry{c.ResetFromGenesis(ctx)}
+	}
+	for i, e := range entries {
+		if err := e.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "contract code history %d", i)
+		}
+	}
+	c.Created = entries[0].Updated
+	k.appendToContractHistory(ctx, contractAddr, entries...)
 	k.storeContractInfo(ctx, contractAddr, c)
-	k.addToContractCodeSecondaryIndex(ctx, contractAddr, historyEntry)
+	k.addToContractCodeSecondaryIndex(ctx, contractAddr, entries[len(entries)-1])
+	k.addToContractCreatorSecondaryIndex(ctx, creatorAddress, c.Created, contractAddr)
+	if adminAddress := c.AdminAddr(); adminAddress != nil {
+		k.addToContractAdminSecondaryIndex(ctx, adminAddress, c.Created, contractAddr)
+	}
 	return k.importContractState(ctx, contractAddr, state)
 }
 
@@ -979,7 +1587,12 @@ func moduleLogger(ctx sdk.Context) log.Logger {
 
 // Querier creates a new grpc querier instance
 func Querier(k *Keeper) *grpcQuerier { //nolint:revive
-	return NewGrpcQuerier(k.cdc, k.storeKey, k, k.queryGasLimit)
+	q := NewGrpcQuerier(k.cdc, k.storeKey, k, k.queryGasLimit)
+	q.smartQueryDisabled = k.smartQueryDisabled
+	if k.smartQueryRateLimit != 0 {
+		q.rateLimiter = newQueryRateLimiter(k.smartQueryRateLimit)
+	}
+	return q
 }
 
 // QueryGasLimit returns the gas limit for smart queries.
@@ -2,14 +2,20 @@ package keeper
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"math"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	wasmvm "github.com/CosmWasm/wasmvm"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
@@ -63,7 +69,11 @@ func TestCreateSuccess(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, hackatomWasm, storedCode)
 	// and events emitted
-	exp := sdk.Events{sdk.NewEvent("store_code", sdk.NewAttribute("code_id", "1"))}
+	codeHash := sha256.Sum256(hackatomWasm)
+	exp := sdk.Events{sdk.NewEvent("store_code",
+		sdk.NewAttribute("code_id", "1"),
+		sdk.NewAttribute("code_checksum", hex.EncodeToString(codeHash[:])),
+	)}
 	assert.Equal(t, exp, em.Events())
 }
 
@@ -126,6 +136,8 @@ func TestCreateStoresInstantiatePermission(t *testing.T) {
 			keepers.WasmKeeper.SetParams(ctx, types.Params{
 				CodeUploadAccess:             types.AllowEverybody,
 				InstantiateDefaultPermission: spec.srcPermission,
+				MaxContractMsgSize:           types.DefaultMaxContractMsgSize,
+				MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
 			})
 			fundAccounts(t, ctx, accKeeper, bankKeeper, myAddr, deposit)
 
@@ -209,6 +221,54 @@ func TestCreateDuplicate(t *testing.T) {
 	require.Equal(t, hackatomWasm, storedCode)
 }
 
+func TestSetCodeSource(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	keeper := keepers.ContractKeeper
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
+	creator := keepers.Faucet.NewFundedAccount(ctx, deposit...)
+	codeID, err := keeper.Create(ctx, creator, hackatomWasm, nil)
+	require.NoError(t, err)
+
+	// only the creator can set the source
+	err = keeper.SetCodeSource(ctx, codeID, RandomAccountAddress(t), "https://example.com/other", "other/builder:1.0.0")
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+	err = keeper.SetCodeSource(ctx, codeID+1, creator, "https://example.com/code", "cosmwasm/rust-optimizer:0.12.6")
+	assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+
+	require.NoError(t, keeper.SetCodeSource(ctx, codeID, creator, "https://example.com/code", "cosmwasm/rust-optimizer:0.12.6"))
+	codeInfo := keepers.WasmKeeper.GetCodeInfo(ctx, codeID)
+	assert.Equal(t, "https://example.com/code", codeInfo.Source)
+	assert.Equal(t, "cosmwasm/rust-optimizer:0.12.6", codeInfo.Builder)
+}
+
+func TestAppendToContractHistory(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	contractAddr := RandomAccountAddress(t)
+	pos := &types.AbsoluteTxPosition{BlockHeight: 1, TxIndex: 1}
+	entries := []types.ContractCodeHistoryEntry{
+		{Operation: types.ContractCodeHistoryOperationTypeInit, CodeID: 1, Updated: pos, Msg: []byte(`{"init":{}}`)},
+		{Operation: types.ContractCodeHistoryOperationTypeMigrate, CodeID: 2, Updated: pos, Msg: []byte(`{"first":{}}`)},
+		{Operation: types.ContractCodeHistoryOperationTypeMigrate, CodeID: 2, Updated: pos, Msg: []byte(`{"second":{}}`)},
+		{Operation: types.ContractCodeHistoryOperationTypeMigrate, CodeID: 2, Updated: pos, Msg: []byte(`{"third":{}}`)},
+	}
+	// the former implementation read the last position from the value of the last entry. The migration entries
+	// start with the same bytes, so the third one got the key of the second one and replaced it.
+	assert.Equal(t, sdk.BigEndianToUint64(k.cdc.MustMarshal(&entries[1])), sdk.BigEndianToUint64(k.cdc.MustMarshal(&entries[2])))
+
+	// when appended one by one
+	for _, e := range entries {
+		k.appendToContractHistory(ctx, contractAddr, e)
+	}
+
+	// then
+	assert.Equal(t, entries, k.GetContractHistory(ctx, contractAddr))
+	for i := range entries {
+		assert.True(t, ctx.KVStore(k.storeKey).Has(types.GetContractCodeHistoryElementKey(contractAddr, uint64(i+1))))
+	}
+}
+
 func TestCreateWithSimulation(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 
@@ -312,7 +372,7 @@ func TestInstantiate(t *testing.T) {
 
 	gasAfter := ctx.GasMeter().GasConsumed()
 	if types.EnableGasVerification {
-		require.Equal(t, uint64(0x18db5), gasAfter-gasBefore)
+		require.Equal(t, uint64(0x1bad5), gasAfter-gasBefore)
 	}
 
 	// ensure it is stored properly
@@ -340,6 +400,31 @@ func TestInstantiate(t *testing.T) {
 	assert.Equal(t, expEvt, em.Events())
 }
 
+func TestInstantiateCostsFromParams(t *testing.T) {
+	instantiateGas := func(t *testing.T, instanceCost, compileCost uint64) (storeGas, initGas sdk.Gas) {
+		ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+		params := types.DefaultParams()
+		params.InstanceCost, params.CompileCost = instanceCost, compileCost
+		keepers.WasmKeeper.SetParams(ctx, params)
+		creator := keepers.Faucet.NewFundedAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+
+		gasBefore := ctx.GasMeter().GasConsumed()
+		codeID, err := keepers.ContractKeeper.Create(ctx, creator, hackatomWasm, nil)
+		require.NoError(t, err)
+		storeGas = ctx.GasMeter().GasConsumed() - gasBefore
+
+		initMsgBz := HackatomExampleInitMsg{Verifier: creator, Beneficiary: creator}.GetBytes(t)
+		gasBefore = ctx.GasMeter().GasConsumed()
+		_, _, err = keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, initMsgBz, "label", nil)
+		require.NoError(t, err)
+		return storeGas, ctx.GasMeter().GasConsumed() - gasBefore
+	}
+	defaultStoreGas, defaultInitGas := instantiateGas(t, types.DefaultInstanceCost, types.DefaultCompileCost)
+	gotStoreGas, gotInitGas := instantiateGas(t, types.DefaultInstanceCost+1, types.DefaultCompileCost+1)
+	assert.Equal(t, defaultStoreGas+uint64(len(hackatomWasm)), gotStoreGas)
+	assert.Equal(t, defaultInitGas+1, gotInitGas)
+}
+
 func TestInstantiateWithDeposit(t *testing.T) {
 	var (
 		bob  = bytes.Repeat([]byte{1}, types.SDKAddrLen)
@@ -469,6 +554,18 @@ func TestInstantiateWithNonExistingCodeID(t *testing.T) {
 	require.Nil(t, addr)
 }
 
+func TestInstantiateWithInvalidLabel(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	initMsgBz := HackatomExampleInitMsg{Verifier: RandomAccountAddress(t), Beneficiary: RandomAccountAddress(t)}.GetBytes(t)
+
+	for _, label := range []string{"", " demo contract", "demo\ncontract", strings.Repeat("a", types.MaxLabelSize+1)} {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, initMsgBz, label, nil)
+		require.Error(t, err, "label %q", label)
+		require.Nil(t, addr)
+	}
+}
+
 func TestInstantiateWithContractDataResponse(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 
@@ -486,6 +583,45 @@ func TestInstantiateWithContractDataResponse(t *testing.T) {
 	assert.Equal(t, []byte("my-response-data"), data)
 }
 
+func TestInstantiateWithContractDataResponseExceedingLimit(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	params := keepers.WasmKeeper.GetParams(ctx)
+	params.MaxContractResponseDataSize = 5
+	keepers.WasmKeeper.SetParams(ctx, params)
+
+	specs := map[string]struct {
+		data   []byte
+		expErr *sdkerrors.Error
+	}{
+		"within limit": {
+			data: []byte("12345"),
+		},
+		"exceeds limit": {
+			data:   []byte("123456"),
+			expErr: types.ErrLimit,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			wasmerMock := &wasmtesting.MockWasmer{
+				InstantiateFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+					return &wasmvmtypes.Response{Data: spec.data}, 0, nil
+				},
+				AnalyzeCodeFn: wasmtesting.WithoutIBCAnalyzeFn,
+				CreateFn:      wasmtesting.NoOpCreateFn,
+			}
+			example := StoreRandomContract(t, ctx, keepers, wasmerMock)
+			_, data, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, nil, "test", nil)
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.data, data)
+		})
+	}
+}
+
 func TestExecute(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	accKeeper, keeper, bankKeeper := keepers.AccountKeeper, keepers.ContractKeeper, keepers.BankKeeper
@@ -530,7 +666,11 @@ func TestExecute(t *testing.T) {
 	res, err := keepers.ContractKeeper.Execute(trialCtx, addr, creator, []byte(`{"release":{}}`), nil)
 	require.Error(t, err)
 	require.True(t, errors.Is(err, types.ErrExecuteFailed))
-	require.Equal(t, "Unauthorized: execute wasm contract failed", err.Error())
+	require.Equal(t, fmt.Sprintf("contract %s failed with contract_error: Unauthorized: execute wasm contract failed", addr), err.Error())
+	var failure *types.ErrContractFailure
+	require.True(t, errors.As(err, &failure))
+	assert.Equal(t, addr.String(), failure.Contract)
+	assert.Equal(t, types.FailureClassContractError, failure.Class)
 
 	// verifier can execute, and get proper gas amount
 	start := time.Now()
@@ -545,7 +685,7 @@ func TestExecute(t *testing.T) {
 	// make sure gas is properly deducted from ctx
 	gasAfter := ctx.GasMeter().GasConsumed()
 	if types.EnableGasVerification {
-		require.Equal(t, uint64(0x17cd2), gasAfter-gasBefore)
+		require.Equal(t, uint64(0x1895f), gasAfter-gasBefore)
 	}
 	// ensure bob now exists and got both payments released
 	bobAcct = accKeeper.GetAccount(ctx, bob)
@@ -670,6 +810,32 @@ func TestExecuteWithNonExistingAddress(t *testing.T) {
 	require.True(t, types.ErrNotFound.Is(err), err)
 }
 
+func TestContractMsgSizeLimit(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	initMsgBz := HackatomExampleInitMsg{Verifier: example.VerifierAddr, Beneficiary: example.BeneficiaryAddr}.GetBytes(t)
+	releaseMsg := []byte(`{"release":{}}`)
+
+	// when the param is lowered below the message sizes
+	params := keepers.WasmKeeper.GetParams(ctx)
+	params.MaxContractMsgSize = uint64(len(releaseMsg) - 1)
+	keepers.WasmKeeper.SetParams(ctx, params)
+
+	// then
+	_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, initMsgBz, "label", nil)
+	assert.True(t, types.ErrLimit.Is(err), err)
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, releaseMsg, nil)
+	assert.True(t, types.ErrLimit.Is(err), err)
+	_, err = keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, initMsgBz)
+	assert.True(t, types.ErrLimit.Is(err), err)
+
+	// and when the message fits exactly
+	params.MaxContractMsgSize = uint64(len(releaseMsg))
+	keepers.WasmKeeper.SetParams(ctx, params)
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, releaseMsg, nil)
+	assert.NoError(t, err)
+}
+
 func TestExecuteWithPanic(t *testing.T) {
 	SkipIfM1(t)
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
@@ -787,6 +953,75 @@ func TestExecuteWithStorageLoop(t *testing.T) {
 	require.True(t, false, "We must panic before this line")
 }
 
+func TestExecuteWithGasLimit(t *testing.T) {
+	SkipIfM1(t)
+	parentCtx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+
+	specs := map[string]struct {
+		gasLimit  uint64
+		msg       []byte
+		expErr    *sdkerrors.Error
+		expMinGas uint64
+	}{
+		"within limit": {
+			gasLimit: 1_000_000,
+			msg:      []byte(`{"release":{}}`),
+		},
+		"limit exceeded": {
+			gasLimit:  100_000,
+			msg:       []byte(`{"cpu_loop":{}}`),
+			expErr:    sdkerrors.ErrOutOfGas,
+			expMinGas: 100_000,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			err := keepers.ContractKeeper.UpdateExecuteGasLimit(ctx, example.Contract, example.CreatorAddr, spec.gasLimit)
+			require.NoError(t, err)
+			assert.Equal(t, spec.gasLimit, keepers.WasmKeeper.GetContractInfo(ctx, example.Contract).ExecuteGasLimit)
+
+			deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 1))
+			verifierBalance := keepers.BankKeeper.GetAllBalances(ctx, example.VerifierAddr)
+			em := sdk.NewEventManager()
+			ctx = ctx.WithGasMeter(sdk.NewGasMeter(10_000_000)).WithEventManager(em)
+			// when
+			_, gotErr := keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, spec.msg, deposit)
+			// then
+			assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+			assert.GreaterOrEqual(t, ctx.GasMeter().GasConsumed(), spec.expMinGas)
+			assert.Less(t, ctx.GasMeter().GasConsumed(), spec.gasLimit+10_000)
+			if spec.expErr != nil {
+				// and state changes and events are discarded
+				assert.Equal(t, verifierBalance, keepers.BankKeeper.GetAllBalances(ctx, example.VerifierAddr))
+				assert.Empty(t, em.Events())
+				return
+			}
+			assert.Equal(t, verifierBalance.Sub(deposit), keepers.BankKeeper.GetAllBalances(ctx, example.VerifierAddr))
+			assert.NotEmpty(t, em.Events())
+		})
+	}
+}
+
+func TestUpdateExecuteGasLimit(t *testing.T) {
+	SkipIfM1(t)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	// only the admin can set the limit
+	err := keepers.ContractKeeper.UpdateExecuteGasLimit(ctx, example.Contract, example.VerifierAddr, 1)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+	err = keepers.ContractKeeper.UpdateExecuteGasLimit(ctx, RandomAccountAddress(t), example.CreatorAddr, 1)
+	assert.True(t, sdkerrors.ErrInvalidRequest.Is(err), "got %+v", err)
+
+	// zero removes the limit
+	require.NoError(t, keepers.ContractKeeper.UpdateExecuteGasLimit(ctx, example.Contract, example.CreatorAddr, 1))
+	require.NoError(t, keepers.ContractKeeper.UpdateExecuteGasLimit(ctx, example.Contract, example.CreatorAddr, 0))
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, []byte(`{"release":{}}`), nil)
+	require.NoError(t, err)
+}
+
 func TestMigrate(t *testing.T) {
 	SkipIfM1(t)
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
@@ -1059,6 +1294,8 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 			"Attr": []dict{
 				{"spender": contractAddr},
 				{"amount": "100000denom"},
+				{"_msg_index": "0"},
+				{"_reply_id": "0"},
 			},
 		},
 		{
@@ -1066,6 +1303,8 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 			"Attr": []dict{
 				{"receiver": myPayoutAddr},
 				{"amount": "100000denom"},
+				{"_msg_index": "0"},
+				{"_reply_id": "0"},
 			},
 		},
 		{
@@ -1074,6 +1313,8 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 				{"recipient": myPayoutAddr},
 				{"sender": contractAddr},
 				{"amount": "100000denom"},
+				{"_msg_index": "0"},
+				{"_reply_id": "0"},
 			},
 		},
 	}
@@ -1315,13 +1556,18 @@ func TestUpdateContractAdmin(t *testing.T) {
 			if spec.overrideContractAddr != nil {
 				addr = spec.overrideContractAddr
 			}
-			err = keeper.UpdateContractAdmin(ctx, addr, spec.caller, spec.newAdmin)
+			em := sdk.NewEventManager()
+			err = keeper.UpdateContractAdmin(ctx.WithEventManager(em), addr, spec.caller, spec.newAdmin)
 			require.True(t, spec.expErr.Is(err), "expected %v but got %+v", spec.expErr, err)
 			if spec.expErr != nil {
 				return
 			}
 			cInfo := keepers.WasmKeeper.GetContractInfo(ctx, addr)
 			assert.Equal(t, spec.newAdmin.String(), cInfo.Admin)
+			expEvt := sdk.NewEvent("update_admin",
+				sdk.NewAttribute("_contract_address", addr.String()),
+				sdk.NewAttribute("admin", spec.newAdmin.String()))
+			assert.Equal(t, sdk.Events{expEvt}, em.Events())
 		})
 	}
 }
@@ -1378,13 +1624,74 @@ func TestClearContractAdmin(t *testing.T) {
 			if spec.overrideContractAddr != nil {
 				addr = spec.overrideContractAddr
 			}
-			err = keeper.ClearContractAdmin(ctx, addr, spec.caller)
+			em := sdk.NewEventManager()
+			err = keeper.ClearContractAdmin(ctx.WithEventManager(em), addr, spec.caller)
 			require.True(t, spec.expErr.Is(err), "expected %v but got %+v", spec.expErr, err)
 			if spec.expErr != nil {
 				return
 			}
 			cInfo := keepers.WasmKeeper.GetContractInfo(ctx, addr)
 			assert.Empty(t, cInfo.Admin)
+			expEvt := sdk.NewEvent("clear_admin",
+				sdk.NewAttribute("_contract_address", addr.String()))
+			assert.Equal(t, sdk.Events{expEvt}, em.Events())
+		})
+	}
+}
+
+func TestContractInfoExtension(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	keepers.EncodingConfig.InterfaceRegistry.RegisterImplementations((*types.ContractInfoExtension)(nil), &govtypes.TextProposal{})
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	specs := map[string]struct {
+		contractAddr sdk.AccAddress
+		ext          types.ContractInfoExtension
+		expErr       *sdkerrors.Error
+		expEvt       sdk.Event
+	}{
+		"set extension": {
+			contractAddr: example.Contract,
+			ext:          &govtypes.TextProposal{Title: "foo", Description: "bar"},
+			expEvt: sdk.NewEvent("update_contract_info_extension",
+				sdk.NewAttribute("_contract_address", example.Contract.String()),
+				sdk.NewAttribute("extension_type", "/cosmos.gov.v1beta1.TextProposal")),
+		},
+		"remove extension": {
+			contractAddr: example.Contract,
+			expEvt: sdk.NewEvent("update_contract_info_extension",
+				sdk.NewAttribute("_contract_address", example.Contract.String()),
+				sdk.NewAttribute("extension_type", "")),
+		},
+		"invalid extension": {
+			contractAddr: example.Contract,
+			ext:          &govtypes.TextProposal{Title: "foo"},
+			expErr:       govtypes.ErrInvalidProposalContent,
+		},
+		"unknown contract": {
+			contractAddr: RandomAccountAddress(t),
+			ext:          &govtypes.TextProposal{Title: "foo", Description: "bar"},
+			expErr:       types.ErrNotFound,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			em := sdk.NewEventManager()
+			err := keepers.ContractKeeper.SetContractInfoExtension(ctx.WithEventManager(em), spec.contractAddr, spec.ext)
+			require.True(t, spec.expErr.Is(err), "expected %v but got %+v", spec.expErr, err)
+			if spec.expErr != nil {
+				assert.Empty(t, em.Events())
+				return
+			}
+			assert.Equal(t, sdk.Events{spec.expEvt}, em.Events())
+
+			var got govtypes.TextProposal
+			require.NoError(t, keepers.WasmKeeper.ReadContractInfoExtension(ctx, spec.contractAddr, &got))
+			if spec.ext == nil {
+				assert.Equal(t, govtypes.TextProposal{}, got)
+				return
+			}
+			assert.Equal(t, spec.ext, &got)
 		})
 	}
 }
@@ -1449,6 +1756,85 @@ func TestUnpinCode(t *testing.T) {
 	assert.Equal(t, exp, em.Events())
 }
 
+func TestPruneCode(t *testing.T) {
+	specs := map[string]struct {
+		setup  func(t *testing.T, ctx sdk.Context, keepers TestKeepers, mock *wasmtesting.MockWasmer) uint64
+		expErr *sdkerrors.Error
+	}{
+		"unused code": {
+			setup: func(t *testing.T, ctx sdk.Context, keepers TestKeepers, mock *wasmtesting.MockWasmer) uint64 {
+				return StoreRandomContract(t, ctx, keepers, mock).CodeID
+			},
+		},
+		"code with contract": {
+			setup: func(t *testing.T, ctx sdk.Context, keepers TestKeepers, mock *wasmtesting.MockWasmer) uint64 {
+				return SeedNewContractInstance(t, ctx, keepers, mock).CodeID
+			},
+			expErr: types.ErrInvalid,
+		},
+		"pinned code": {
+			setup: func(t *testing.T, ctx sdk.Context, keepers TestKeepers, mock *wasmtesting.MockWasmer) uint64 {
+				codeID := StoreRandomContract(t, ctx, keepers, mock).CodeID
+				require.NoError(t, keepers.WasmKeeper.pinCode(ctx, codeID))
+				return codeID
+			},
+			expErr: types.ErrInvalid,
+		},
+		"pruned code": {
+			setup: func(t *testing.T, ctx sdk.Context, keepers TestKeepers, mock *wasmtesting.MockWasmer) uint64 {
+				codeID := StoreRandomContract(t, ctx, keepers, mock).CodeID
+				require.NoError(t, keepers.WasmKeeper.pruneCode(ctx, codeID))
+				return codeID
+			},
+			expErr: types.ErrPrunedCode,
+		},
+		"unknown code": {
+			setup: func(*testing.T, sdk.Context, TestKeepers, *wasmtesting.MockWasmer) uint64 {
+				return 999
+			},
+			expErr: types.ErrNotFound,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			k := keepers.WasmKeeper
+			mock := wasmtesting.MockWasmer{PinFn: func(wasmvm.Checksum) error { return nil }}
+			wasmtesting.MakeInstantiable(&mock)
+			codeID := spec.setup(t, ctx, keepers, &mock)
+			em := sdk.NewEventManager()
+
+			// when
+			gotErr := k.pruneCode(ctx.WithEventManager(em), codeID)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.True(t, k.IsPrunedCode(ctx, codeID))
+			assert.NotNil(t, k.GetCodeInfo(ctx, codeID))
+			exp := sdk.Events{sdk.NewEvent("prune_code", sdk.NewAttribute("code_id", strconv.FormatUint(codeID, 10)))}
+			assert.Equal(t, exp, em.Events())
+
+			// and the byte code is gone
+			gotCode, err := k.GetByteCode(ctx, codeID)
+			require.NoError(t, err)
+			assert.Nil(t, gotCode)
+
+			// and the code can not be used anymore
+			_, _, err = keepers.ContractKeeper.Instantiate(ctx, codeID, RandomAccountAddress(t), nil, []byte(`{}`), "testing", nil)
+			assert.True(t, types.ErrPrunedCode.Is(err), "got %+v", err)
+			err = k.pinCode(ctx, codeID)
+			assert.True(t, types.ErrPrunedCode.Is(err), "got %+v", err)
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			_, err = keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, codeID, []byte(`{}`))
+			assert.True(t, types.ErrPrunedCode.Is(err), "got %+v", err)
+		})
+	}
+}
+
 func TestInitializePinnedCodes(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	k := keepers.WasmKeeper
@@ -1526,6 +1912,42 @@ func TestPinnedContractLoops(t *testing.T) {
 
 }
 
+func TestContractLoopsHitCallDepthLimit(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+
+	// a contract that calls itself via messages should terminate with an
+	// error when the max call depth is reached
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(&mock), WithMaxCallDepth(3))
+	k := keepers.WasmKeeper
+
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	var loops int
+	anyMsg := []byte(`{}`)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		loops++
+		return &wasmvmtypes.Response{
+			Messages: []wasmvmtypes.SubMsg{
+				{
+					ID:      1,
+					ReplyOn: wasmvmtypes.ReplyNever,
+					Msg: wasmvmtypes.CosmosMsg{
+						Wasm: &wasmvmtypes.WasmMsg{
+							Execute: &wasmvmtypes.ExecuteMsg{
+								ContractAddr: example.Contract.String(),
+								Msg:          anyMsg,
+							},
+						},
+					},
+				},
+			},
+		}, 0, nil
+	}
+	_, err := k.execute(ctx, example.Contract, RandomAccountAddress(t), anyMsg, nil)
+	assert.True(t, types.ErrLimit.Is(err), "got %+v", err)
+	assert.Equal(t, 4, loops)
+}
+
 func TestNewDefaultWasmVMContractResponseHandler(t *testing.T) {
 	specs := map[string]struct {
 		srcData []byte
@@ -1754,3 +2176,88 @@ func TestBuildContractAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildGenesisContractAddress(t *testing.T) {
+	myChecksum := bytes.Repeat([]byte{1}, 32)
+	otherChecksum := bytes.Repeat([]byte{2}, 32)
+
+	gotAddr := BuildGenesisContractAddress(myChecksum, "my label")
+	require.NotNil(t, gotAddr)
+	assert.Nil(t, sdk.VerifyAddressFormat(gotAddr))
+	assert.Equal(t, gotAddr, BuildGenesisContractAddress(myChecksum, "my label"), "deterministic")
+	assert.NotEqual(t, gotAddr, BuildGenesisContractAddress(otherChecksum, "my label"))
+	assert.NotEqual(t, gotAddr, BuildGenesisContractAddress(myChecksum, "other label"))
+	assert.NotEqual(t, gotAddr, BuildContractAddress(1, 1))
+}
+
+func TestGetChecksumsInUse(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	// code with a contract
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	// same code without a contract
+	StoreHackatomExampleContract(t, ctx, keepers)
+	// code without a contract
+	StoreReflectContract(t, ctx, keepers)
+	// pinned code without a contract
+	burner := StoreBurnerExampleContract(t, ctx, keepers)
+	require.NoError(t, keepers.ContractKeeper.PinCode(ctx, burner.CodeID))
+
+	// when
+	got := k.GetChecksumsInUse(ctx)
+
+	// then
+	exp := [][]byte{
+		k.GetCodeInfo(ctx, example.CodeID).CodeHash,
+		k.GetCodeInfo(ctx, burner.CodeID).CodeHash,
+	}
+	assert.Equal(t, exp, got)
+}
+
+func TestWasmHooks(t *testing.T) {
+	var calls []string
+	hooks := &wasmtesting.MockWasmHooks{
+		AfterContractInstantiatedFn: func(ctx sdk.Context, contractAddr, creator sdk.AccAddress, codeID uint64) error {
+			calls = append(calls, fmt.Sprintf("instantiated %s by %s with code %d", contractAddr, creator, codeID))
+			return nil
+		},
+		AfterContractExecutedFn: func(ctx sdk.Context, contractAddr, caller sdk.AccAddress, msg []byte, funds sdk.Coins) error {
+			calls = append(calls, fmt.Sprintf("executed %s by %s with %s and %s", contractAddr, caller, string(msg), funds))
+			return nil
+		},
+		AfterContractMigratedFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, oldCodeID, newCodeID uint64) error {
+			calls = append(calls, fmt.Sprintf("migrated %s from code %d to %d", contractAddr, oldCodeID, newCodeID))
+			return nil
+		},
+	}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmHooks(hooks))
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	verifier, beneficiary := RandomAccountAddress(t), RandomAccountAddress(t)
+	initMsg := HackatomExampleInitMsg{Verifier: verifier, Beneficiary: beneficiary}.GetBytes(t)
+
+	// when instantiated
+	contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, example.CreatorAddr, initMsg, "label", nil)
+	require.NoError(t, err)
+	// and executed
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, verifier, []byte(`{"release":{}}`), nil)
+	require.NoError(t, err)
+	// and migrated
+	newCode := StoreHackatomExampleContract(t, ctx, keepers)
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, example.CreatorAddr, newCode.CodeID, []byte(fmt.Sprintf(`{"verifier":%q}`, verifier.String())))
+	require.NoError(t, err)
+
+	// then
+	exp := []string{
+		fmt.Sprintf("instantiated %s by %s with code %d", contractAddr, example.CreatorAddr, example.CodeID),
+		fmt.Sprintf("executed %s by %s with %s and %s", contractAddr, verifier, `{"release":{}}`, sdk.Coins(nil)),
+		fmt.Sprintf("migrated %s from code %d to %d", contractAddr, example.CodeID, newCode.CodeID),
+	}
+	assert.Equal(t, exp, calls)
+
+	// and a hook error fails the contract call
+	hooks.AfterContractExecutedFn = func(ctx sdk.Context, contractAddr, caller sdk.AccAddress, msg []byte, funds sdk.Coins) error {
+		return types.ErrInvalid
+	}
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, verifier, []byte(`{"release":{}}`), nil)
+	assert.True(t, types.ErrInvalid.Is(err), "got %+v", err)
+}
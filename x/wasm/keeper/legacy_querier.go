@@ -27,6 +27,20 @@ const (
 	QueryMethodContractStateRaw   = "raw"
 )
 
+// LegacyQuerier creates a new legacy querier instance. Smart queries are rejected when disabled in the node config.
+func LegacyQuerier(k *Keeper) sdk.Querier {
+	q := NewLegacyQuerier(k, k.queryGasLimit)
+	if !k.smartQueryDisabled {
+		return q
+	}
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		if len(path) > 2 && path[0] == QueryGetContractState && path[2] == QueryMethodContractStateSmart {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "smart queries are disabled on this node")
+		}
+		return q(ctx, path, req)
+	}
+}
+
 // NewLegacyQuerier creates a new querier
 func NewLegacyQuerier(keeper types.ViewKeeper, gasLimit sdk.Gas) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
@@ -109,7 +123,7 @@ func queryContractState(ctx sdk.Context, bech, queryMethod string, data []byte,
 		// we enforce a subjective gas limit on all queries to avoid infinite loops
 		ctx = ctx.WithGasMeter(sdk.NewGasMeter(gasLimit))
 		msg := types.RawContractMessage(data)
-		if err := msg.ValidateBasic(); err != nil {
+		if err := msg.ValidateQuery(); err != nil {
 			return nil, sdkerrors.Wrap(err, "json msg")
 		}
 		// this returns raw bytes (must be base64-encoded)
@@ -127,6 +141,8 @@ func queryCodeList(ctx sdk.Context, keeper types.ViewKeeper) ([]types.CodeInfoRe
 			CodeID:   i,
 			Creator:  res.Creator,
 			DataHash: res.CodeHash,
+			Source:   res.Source,
+			Builder:  res.Builder,
 		})
 		return false
 	})
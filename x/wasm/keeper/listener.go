@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	"bytes"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// ContractStateListener is notified about every change to the state of a contract instance.
+type ContractStateListener interface {
+	// OnContractStateChange is called with the contract address and the key as used by the contract.
+	// The value is nil when the key was deleted.
+	// The store does not handle errors of listeners so that an implementation has to deal with failures itself.
+	OnContractStateChange(contractAddr sdk.AccAddress, key, value []byte, delete bool)
+}
+
+var _ storetypes.WriteListener = ContractStateWriteListener{}
+
+// ContractStateWriteListener is an ADR-38 store listener that decodes the writes to the wasm store
+// and forwards the changes to contract state. Other writes are ignored.
+// It is registered with the wasm store key on the commit multistore of the app:
+//
+//	app.CommitMultiStore().AddListeners(keys[wasm.StoreKey], []storetypes.WriteListener{keeper.NewContractStateWriteListener(l)})
+//
+// Writes are streamed when they are flushed to the commit multistore so that changes of failed
+// transactions or reverted submessages are not reported.
+// The contract address is expected to be of length types.ContractAddrLen as built by BuildContractAddress.
+type ContractStateWriteListener struct {
+	listener ContractStateListener
+}
+
+// NewContractStateWriteListener constructor
+func NewContractStateWriteListener(listener ContractStateListener) ContractStateWriteListener {
+	return ContractStateWriteListener{listener: listener}
+}
+
+// OnWrite satisfies the storetypes.WriteListener interface
+func (w ContractStateWriteListener) OnWrite(storeKey storetypes.StoreKey, key []byte, value []byte, delete bool) error {
+	if storeKey.Name() != types.StoreKey || !bytes.HasPrefix(key, types.ContractStorePrefix) {
+		return nil
+	}
+	addrKey := key[len(types.ContractStorePrefix):]
+	if len(addrKey) < types.ContractAddrLen {
+		return nil
+	}
+	w.listener.OnContractStateChange(sdk.AccAddress(addrKey[:types.ContractAddrLen]), addrKey[types.ContractAddrLen:], value, delete)
+	return nil
+}
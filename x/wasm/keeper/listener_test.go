@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/listenkv"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractStateWriteListener(t *testing.T) {
+	contractAddr := BuildContractAddress(1, 1)
+	specs := map[string]struct {
+		storeKey  storetypes.StoreKey
+		write     func(store sdk.KVStore)
+		expRecord []contractStateRecord
+	}{
+		"contract state set": {
+			storeKey: sdk.NewKVStoreKey(types.StoreKey),
+			write: func(store sdk.KVStore) {
+				prefix.NewStore(store, types.GetContractStorePrefix(contractAddr)).Set([]byte("foo"), []byte("bar"))
+			},
+			expRecord: []contractStateRecord{{contractAddr: contractAddr, key: []byte("foo"), value: []byte("bar")}},
+		},
+		"contract state delete": {
+			storeKey: sdk.NewKVStoreKey(types.StoreKey),
+			write: func(store sdk.KVStore) {
+				prefix.NewStore(store, types.GetContractStorePrefix(contractAddr)).Delete([]byte("foo"))
+			},
+			expRecord: []contractStateRecord{{contractAddr: contractAddr, key: []byte("foo"), delete: true}},
+		},
+		"other wasm data ignored": {
+			storeKey: sdk.NewKVStoreKey(types.StoreKey),
+			write: func(store sdk.KVStore) {
+				store.Set(types.GetContractAddressKey(contractAddr), []byte("bar"))
+			},
+		},
+		"other store ignored": {
+			storeKey: sdk.NewKVStoreKey("other"),
+			write: func(store sdk.KVStore) {
+				prefix.NewStore(store, types.GetContractStorePrefix(contractAddr)).Set([]byte("foo"), []byte("bar"))
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var listener recordingContractStateListener
+			store := listenkv.NewStore(dbadapter.Store{DB: dbm.NewMemDB()}, spec.storeKey,
+				[]storetypes.WriteListener{NewContractStateWriteListener(&listener)})
+			// when
+			spec.write(store)
+			// then
+			assert.Equal(t, spec.expRecord, listener.records)
+		})
+	}
+}
+
+type contractStateRecord struct {
+	contractAddr sdk.AccAddress
+	key, value   []byte
+	delete       bool
+}
+
+type recordingContractStateListener struct {
+	records []contractStateRecord
+}
+
+func (l *recordingContractStateListener) OnContractStateChange(contractAddr sdk.AccAddress, key, value []byte, delete bool) {
+	l.records = append(l.records, contractStateRecord{contractAddr: contractAddr, key: key, value: value, delete: delete})
+}
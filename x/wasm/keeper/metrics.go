@@ -1,14 +1,31 @@
 package keeper
 
 import (
+	"strconv"
+	"time"
+
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/armon/go-metrics"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 const (
 	labelPinned = "pinned"
 	labelMemory = "memory"
 	labelFs     = "fs"
+
+	labelOperation = "operation"
+	labelCodeID    = "code_id"
+	labelContract  = "contract"
+	// labelFailureClass is the class of a failed call as defined in types.FailureClass
+	labelFailureClass = "failure"
+
+	// labelOtherContracts is used for all contracts that are not configured for metrics
+	labelOtherContracts = "other"
 )
 
 // metricSource source of wasmvm metrics
@@ -34,7 +51,7 @@ func NewWasmVMMetricsCollector(s metricSource) *WasmVMMetricsCollector {
 		CacheHitsDescr:     prometheus.NewDesc("wasmvm_cache_hits_total", "Total number of cache hits", []string{"type"}, nil),
 		CacheMissesDescr:   prometheus.NewDesc("wasmvm_cache_misses_total", "Total number of cache misses", nil, nil),
 		CacheElementsDescr: prometheus.NewDesc("wasmvm_cache_elements_total", "Total number of elements in the cache", []string{"type"}, nil),
-		CacheSizeDescr:     prometheus.NewDesc("wasmvm_cache_size_bytes", "Total number of elements in the cache", []string{"type"}, nil),
+		CacheSizeDescr:     prometheus.NewDesc("wasmvm_cache_size_bytes", "Total size of the elements in the cache in bytes", []string{"type"}, nil),
 	}
 }
 
@@ -70,3 +87,31 @@ func (p *WasmVMMetricsCollector) Collect(c chan<- prometheus.Metric) {
 	// We had to either scan the whole directory of potentially thousands of files or track the values when files are added or removed.
 	// Such a tracking would need to be on disk such that the values are not cleared when the node is restarted.
 }
+
+// observeVMCall records telemetry for a single call into the wasm VM. The number of calls, failures,
+// duration and gas used in sdk gas units are labeled by operation and code id so that operators
+// can spot pathological contracts. Failures are also labeled with the failure class. The contract label is the address for the contracts in the
+// metrics_contracts node config and "other" for all others to keep the cardinality bounded.
+// In contract debug mode the call is also logged so that the debug output of the contract can be attributed.
+func (k Keeper) observeVMCall(ctx sdk.Context, operation string, codeID uint64, contractAddr sdk.AccAddress, start time.Time, vmGasUsed uint64, err error) {
+	if k.contractDebugMode {
+		k.Logger(ctx).Info("contract vm call", "operation", operation, "contract", contractAddr.String(), "code_id", codeID, "error", err)
+	}
+	contractLabel := labelOtherContracts
+	if _, ok := k.metricsContracts[contractAddr.String()]; ok {
+		contractLabel = contractAddr.String()
+	}
+	labels := []metrics.Label{
+		telemetry.NewLabel(telemetry.MetricLabelNameModule, types.ModuleName),
+		telemetry.NewLabel(labelOperation, operation),
+		telemetry.NewLabel(labelCodeID, strconv.FormatUint(codeID, 10)),
+		telemetry.NewLabel(labelContract, contractLabel),
+	}
+	metrics.MeasureSinceWithLabels([]string{"wasm", "vm", "duration"}, start, labels)
+	metrics.AddSampleWithLabels([]string{"wasm", "vm", "gas_used"}, float32(k.gasRegister.FromWasmVMGas(vmGasUsed)), labels)
+	metrics.IncrCounterWithLabels([]string{"wasm", "vm", "calls"}, 1, labels)
+	if err != nil {
+		labels = append(labels, telemetry.NewLabel(labelFailureClass, types.FailureClass(err)))
+		metrics.IncrCounterWithLabels([]string{"wasm", "vm", "failures"}, 1, labels)
+	}
+}
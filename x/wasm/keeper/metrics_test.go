@@ -0,0 +1,98 @@
+package keeper
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/armon/go-metrics"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestObserveVMCall(t *testing.T) {
+	sink := metrics.NewInmemSink(time.Hour, time.Hour)
+	cfg := metrics.DefaultConfig("")
+	cfg.EnableHostname = false
+	cfg.EnableRuntimeMetrics = false
+	_, err := metrics.NewGlobal(cfg, sink)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		metrics.NewGlobal(metrics.DefaultConfig(""), &metrics.BlackholeSink{})
+	})
+	ctx := sdk.Context{}.WithLogger(log.NewNopLogger())
+	configuredContract, otherContract := RandomAccountAddress(t), RandomAccountAddress(t)
+	k := Keeper{
+		gasRegister:      NewDefaultWasmGasRegister(),
+		metricsContracts: map[string]struct{}{configuredContract.String(): {}},
+	}
+
+	// when
+	k.observeVMCall(ctx, "execute", 1, otherContract, time.Now(), 1_000_000, nil)
+	k.observeVMCall(ctx, "execute", 1, otherContract, time.Now(), 3_000_000, errors.New("testing"))
+	k.observeVMCall(ctx, "execute", 1, otherContract, time.Now(), 1_000_000, wasmvmtypes.OutOfGasError{})
+	k.observeVMCall(ctx, "execute", 1, configuredContract, time.Now(), 1_000_000, nil)
+
+	// then
+	intervals := sink.Data()
+	require.Len(t, intervals, 1)
+	const labels = ";module=wasm;operation=execute;code_id=1;contract=other"
+	gotCounters := intervals[0].Counters
+	require.Contains(t, gotCounters, "wasm.vm.calls"+labels)
+	assert.Equal(t, 3, gotCounters["wasm.vm.calls"+labels].Count)
+	require.Contains(t, gotCounters, "wasm.vm.failures"+labels+";failure=contract_error")
+	assert.Equal(t, 1, gotCounters["wasm.vm.failures"+labels+";failure=contract_error"].Count)
+	require.Contains(t, gotCounters, "wasm.vm.failures"+labels+";failure=out_of_gas")
+	assert.Equal(t, 1, gotCounters["wasm.vm.failures"+labels+";failure=out_of_gas"].Count)
+
+	gotSamples := intervals[0].Samples
+	require.Contains(t, gotSamples, "wasm.vm.duration"+labels)
+	assert.Equal(t, 3, gotSamples["wasm.vm.duration"+labels].Count)
+	require.Contains(t, gotSamples, "wasm.vm.gas_used"+labels)
+	assert.Equal(t, float64(5_000_000/DefaultGasMultiplier), gotSamples["wasm.vm.gas_used"+labels].Sum)
+
+	// and the configured contract has its own label
+	configuredLabels := ";module=wasm;operation=execute;code_id=1;contract=" + configuredContract.String()
+	require.Contains(t, gotCounters, "wasm.vm.calls"+configuredLabels)
+	assert.Equal(t, 1, gotCounters["wasm.vm.calls"+configuredLabels].Count)
+	assert.NotContains(t, gotCounters, "wasm.vm.failures"+configuredLabels+";failure=contract_error")
+}
+
+func TestObserveVMCallContractDebugMode(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := sdk.Context{}.WithLogger(log.NewTMLogger(&buf))
+	contractAddr := RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		debugMode bool
+		expLog    bool
+	}{
+		"debug mode": {
+			debugMode: true,
+			expLog:    true,
+		},
+		"no debug mode": {},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			buf.Reset()
+			k := Keeper{gasRegister: NewDefaultWasmGasRegister(), contractDebugMode: spec.debugMode}
+
+			// when
+			k.observeVMCall(ctx, "execute", 1, contractAddr, time.Now(), 1_000_000, nil)
+
+			// then
+			if !spec.expLog {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.Contains(t, buf.String(), "contract vm call")
+			assert.Contains(t, buf.String(), "contract="+contractAddr.String())
+			assert.Contains(t, buf.String(), "operation=execute")
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 migrates from version 1 to 2.
+// It builds the contracts-by-creator and contracts-by-admin secondary indexes for all existing contracts.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	type indexEntry struct {
+		creator  sdk.AccAddress
+		admin    sdk.AccAddress
+		created  *types.AbsoluteTxPosition
+		contract sdk.AccAddress
+	}
+	var (
+		entries []indexEntry
+		err     error
+	)
+	// collect first to not write into the store while iterating
+	m.keeper.IterateContractInfo(ctx, func(contractAddr sdk.AccAddress, contractInfo types.ContractInfo) bool {
+		var creator sdk.AccAddress
+		creator, err = sdk.AccAddressFromBech32(contractInfo.Creator)
+		if err != nil {
+			err = sdkerrors.Wrapf(err, "creator of contract %s", contractAddr)
+			return true
+		}
+		var admin sdk.AccAddress
+		if contractInfo.Admin != "" {
+			admin, err = sdk.AccAddressFromBech32(contractInfo.Admin)
+			if err != nil {
+				err = sdkerrors.Wrapf(err, "admin of contract %s", contractAddr)
+				return true
+			}
+		}
+		entries = append(entries, indexEntry{creator: creator, admin: admin, created: contractInfo.Created, contract: contractAddr})
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		m.keeper.addToContractCreatorSecondaryIndex(ctx, e.creator, e.created, e.contract)
+		if e.admin != nil {
+			m.keeper.addToContractAdminSecondaryIndex(ctx, e.admin, e.created, e.contract)
+		}
+	}
+	return nil
+}
+
+// Migrate2to3 migrates from version 2 to 3.
+// It calculates the state size counters for all existing contracts.
+func (m Migrator) Migrate2to3(ctx sdk.Context) error {
+	var contracts []sdk.AccAddress
+	// collect first to not write into the store while iterating
+	m.keeper.IterateContractInfo(ctx, func(contractAddr sdk.AccAddress, _ types.ContractInfo) bool {
+		contracts = append(contracts, contractAddr)
+		return false
+	})
+	for _, c := range contracts {
+		m.keeper.setContractStateSize(ctx, c)
+	}
+	return nil
+}
+
+// Migrate3to4 migrates from version 3 to 4.
+// It sets the max contract message size param to the default value.
+func (m Migrator) Migrate3to4(ctx sdk.Context) error {
+	m.keeper.paramSpace.Set(ctx, types.ParamStoreKeyMaxContractMsgSize, types.DefaultMaxContractMsgSize)
+	return nil
+}
+
+// Migrate4to5 migrates from version 4 to 5.
+// It sets the max contract response data size param to the default value.
+func (m Migrator) Migrate4to5(ctx sdk.Context) error {
+	m.keeper.paramSpace.Set(ctx, types.ParamStoreKeyMaxContractResponseDataSize, types.DefaultMaxContractResponseDataSize)
+	return nil
+}
+
+// Migrate5to6 migrates from version 5 to 6.
+// It sets the denied funds denoms param to the default value.
+func (m Migrator) Migrate5to6(ctx sdk.Context) error {
+	m.keeper.paramSpace.Set(ctx, types.ParamStoreKeyDeniedFundsDenoms, types.DefaultParams().DeniedFundsDenoms)
+	return nil
+}
+
+// Migrate6to7 migrates from version 6 to 7.
+// It sets the instance and compile cost params to the values of the configured gas register
+// or to the default values for any other gas register implementation.
+func (m Migrator) Migrate6to7(ctx sdk.Context) error {
+	instanceCost, compileCost := types.DefaultInstanceCost, types.DefaultCompileCost
+	if r, ok := m.keeper.gasRegister.(WasmGasRegister); ok {
+		instanceCost, compileCost = r.c.InstanceCost, r.c.CompileCost
+	}
+	m.keeper.paramSpace.Set(ctx, types.ParamStoreKeyInstanceCost, instanceCost)
+	m.keeper.paramSpace.Set(ctx, types.ParamStoreKeyCompileCost, compileCost)
+	return nil
+}
+
+// Migrate7to8 migrates from version 7 to 8.
+// It sets the default creator admin param to false so that instantiation without admin works as before.
+func (m Migrator) Migrate7to8(ctx sdk.Context) error {
+	m.keeper.paramSpace.Set(ctx, types.ParamStoreKeyDefaultCreatorAdmin, false)
+	return nil
+}
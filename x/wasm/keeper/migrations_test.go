@@ -0,0 +1,214 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate1To2(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
+	creator := keepers.Faucet.NewFundedAccount(ctx, deposit...)
+	otherCreator := keepers.Faucet.NewFundedAccount(ctx, deposit...)
+
+	codeID := StoreHackatomExampleContract(t, ctx, keepers).CodeID
+	initMsgBz := HackatomExampleInitMsg{Verifier: creator, Beneficiary: creator}.GetBytes(t)
+
+	var expContracts []sdk.AccAddress
+	for i := 0; i < 3; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		contract, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, otherCreator, initMsgBz, "label", nil)
+		require.NoError(t, err)
+		expContracts = append(expContracts, contract)
+	}
+	otherContract, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, otherCreator, nil, initMsgBz, "label", nil)
+	require.NoError(t, err)
+
+	// remove the indexes to simulate a v1 store
+	for _, p := range [][]byte{types.ContractsByCreatorPrefix, types.ContractsByAdminPrefix} {
+		store := prefix.NewStore(ctx.KVStore(wasmKeeper.storeKey), p)
+		iter := store.Iterator(nil, nil)
+		var keys [][]byte
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, iter.Key())
+		}
+		require.NoError(t, iter.Close())
+		require.NotEmpty(t, keys)
+		for _, k := range keys {
+			store.Delete(k)
+		}
+	}
+
+	// when
+	err = NewMigrator(*wasmKeeper).Migrate1to2(ctx)
+
+	// then
+	require.NoError(t, err)
+	var gotContracts []sdk.AccAddress
+	wasmKeeper.IterateContractsByCreator(ctx, creator, func(address sdk.AccAddress) bool {
+		gotContracts = append(gotContracts, address)
+		return false
+	})
+	assert.Equal(t, expContracts, gotContracts)
+
+	gotContracts = nil
+	wasmKeeper.IterateContractsByCreator(ctx, otherCreator, func(address sdk.AccAddress) bool {
+		gotContracts = append(gotContracts, address)
+		return false
+	})
+	assert.Equal(t, []sdk.AccAddress{otherContract}, gotContracts)
+
+	gotContracts = nil
+	wasmKeeper.IterateContractsByAdmin(ctx, otherCreator, func(address sdk.AccAddress) bool {
+		gotContracts = append(gotContracts, address)
+		return false
+	})
+	assert.Equal(t, expContracts, gotContracts)
+
+	gotContracts = nil
+	wasmKeeper.IterateContractsByAdmin(ctx, creator, func(address sdk.AccAddress) bool {
+		gotContracts = append(gotContracts, address)
+		return false
+	})
+	assert.Empty(t, gotContracts)
+}
+
+func TestMigrate2To3(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+
+	var contracts []sdk.AccAddress
+	for i := 0; i < 2; i++ {
+		contracts = append(contracts, InstantiateHackatomExampleContract(t, ctx, keepers).Contract)
+	}
+	expSizes := make([]types.ContractStateSize, len(contracts))
+	for i, c := range contracts {
+		expSizes[i] = wasmKeeper.GetContractStateSize(ctx, c)
+		require.NotZero(t, expSizes[i].Keys)
+	}
+
+	// remove the counters to simulate a v2 store
+	store := prefix.NewStore(ctx.KVStore(wasmKeeper.storeKey), types.ContractStateSizePrefix)
+	for _, c := range contracts {
+		store.Delete(c)
+	}
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate2to3(ctx)
+
+	// then
+	require.NoError(t, err)
+	for i, c := range contracts {
+		assert.Equal(t, expSizes[i], wasmKeeper.GetContractStateSize(ctx, c))
+	}
+}
+
+func TestMigrate3To4(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+	// change the param to simulate a v3 store
+	wasmKeeper.paramSpace.Set(ctx, types.ParamStoreKeyMaxContractMsgSize, uint64(1))
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate3to4(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, types.DefaultMaxContractMsgSize, wasmKeeper.GetParams(ctx).MaxContractMsgSize)
+}
+
+func TestMigrate4To5(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+	// change the param to simulate a v4 store
+	wasmKeeper.paramSpace.Set(ctx, types.ParamStoreKeyMaxContractResponseDataSize, uint64(1))
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate4to5(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, types.DefaultMaxContractResponseDataSize, wasmKeeper.GetParams(ctx).MaxContractResponseDataSize)
+}
+
+func TestMigrate5To6(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+	// change the param to simulate a v5 store
+	wasmKeeper.paramSpace.Set(ctx, types.ParamStoreKeyDeniedFundsDenoms, []string{"foo"})
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate5to6(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, wasmKeeper.GetParams(ctx).DeniedFundsDenoms)
+}
+
+func TestMigrate6To7(t *testing.T) {
+	specs := map[string]struct {
+		srcRegister     GasRegister
+		expInstanceCost uint64
+		expCompileCost  uint64
+	}{
+		"default gas register": {
+			srcRegister:     NewDefaultWasmGasRegister(),
+			expInstanceCost: types.DefaultInstanceCost,
+			expCompileCost:  types.DefaultCompileCost,
+		},
+		"custom gas register config": {
+			srcRegister: NewWasmGasRegister(func() WasmGasRegisterConfig {
+				c := DefaultGasRegisterConfig()
+				c.InstanceCost, c.CompileCost = 1, 2
+				return c
+			}()),
+			expInstanceCost: 1,
+			expCompileCost:  2,
+		},
+		"other gas register": {
+			srcRegister:     &wasmtesting.MockGasRegister{},
+			expInstanceCost: types.DefaultInstanceCost,
+			expCompileCost:  types.DefaultCompileCost,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithGasRegister(spec.srcRegister))
+			wasmKeeper := keepers.WasmKeeper
+			// change the params to simulate a v6 store
+			wasmKeeper.paramSpace.Set(ctx, types.ParamStoreKeyInstanceCost, uint64(0))
+			wasmKeeper.paramSpace.Set(ctx, types.ParamStoreKeyCompileCost, uint64(0))
+
+			// when
+			err := NewMigrator(*wasmKeeper).Migrate6to7(ctx)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, spec.expInstanceCost, wasmKeeper.GetParams(ctx).InstanceCost)
+			assert.Equal(t, spec.expCompileCost, wasmKeeper.GetParams(ctx).CompileCost)
+		})
+	}
+}
+
+func TestMigrate7To8(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+	// change the param to simulate a store that was set before
+	wasmKeeper.paramSpace.Set(ctx, types.ParamStoreKeyDefaultCreatorAdmin, true)
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate7to8(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, wasmKeeper.GetParams(ctx).DefaultCreatorAdmin)
+}
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"fmt"
+	"strconv"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -77,7 +78,7 @@ func (d MessageDispatcher) dispatchMsgWithGasLimit(ctx sdk.Context, contractAddr
 // that dispatched them, both on success as well as failure
 func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk.AccAddress, ibcPort string, msgs []wasmvmtypes.SubMsg) ([]byte, error) {
 	var rsp []byte
-	for _, msg := range msgs {
+	for i, msg := range msgs {
 		switch msg.ReplyOn {
 		case wasmvmtypes.ReplySuccess, wasmvmtypes.ReplyError, wasmvmtypes.ReplyAlways, wasmvmtypes.ReplyNever:
 		default:
@@ -106,7 +107,7 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 		if err == nil {
 			commit()
 			filteredEvents = filterEvents(append(em.Events(), events...))
-			ctx.EventManager().EmitEvents(filteredEvents)
+			ctx.EventManager().EmitEvents(withSubmsgAttributes(filteredEvents, i, msg.ID))
 		} // on failure, revert state from sandbox, and ignore events (just skip doing the above)
 
 		// we only callback if requested. Short-circuit here the cases we don't want to
@@ -187,6 +188,26 @@ func filterEvents(events []sdk.Event) []sdk.Event {
 	return res
 }
 
+// withSubmsgAttributes returns a copy of the events with the index and reply id of the submessage that
+// emitted them appended. Events of nested submessages get one pair per level, innermost first, so that
+// indexers can reconstruct the dispatch hierarchy. The reserved prefix ensures that the attributes
+// can not be set by contracts.
+func withSubmsgAttributes(events []sdk.Event, msgIndex int, replyID uint64) []sdk.Event {
+	res := make([]sdk.Event, len(events))
+	for i, ev := range events {
+		attrs := make([]abci.EventAttribute, len(ev.Attributes), len(ev.Attributes)+2)
+		copy(attrs, ev.Attributes)
+		res[i] = sdk.Event{
+			Type: ev.Type,
+			Attributes: append(attrs,
+				abci.EventAttribute{Key: []byte(types.AttributeKeyMsgIndex), Value: []byte(strconv.Itoa(msgIndex))},
+				abci.EventAttribute{Key: []byte(types.AttributeKeyReplyID), Value: []byte(strconv.FormatUint(replyID, 10))},
+			),
+		}
+	}
+	return res
+}
+
 func sdkEventsToWasmVMEvents(events []sdk.Event) []wasmvmtypes.Event {
 	res := make([]wasmvmtypes.Event, len(events))
 	for i, ev := range events {
@@ -101,10 +101,8 @@ func TestDispatchSubmessages(t *testing.T) {
 			},
 			expData:    []byte("myReplyData"),
 			expCommits: []bool{true},
-			expEvents: []sdk.Event{{
-				Type:       "myEvent",
-				Attributes: []abci.EventAttribute{{Key: []byte("foo"), Value: []byte("bar")}},
-			},
+			expEvents: []sdk.Event{
+				sdk.NewEvent("myEvent", sdk.NewAttribute("foo", "bar"), sdk.NewAttribute("_msg_index", "0"), sdk.NewAttribute("_reply_id", "0")),
 				sdk.NewEvent("wasm-reply"),
 			},
 		},
@@ -121,10 +119,9 @@ func TestDispatchSubmessages(t *testing.T) {
 				},
 			},
 			expCommits: []bool{true},
-			expEvents: []sdk.Event{{
-				Type:       "myEvent",
-				Attributes: []abci.EventAttribute{{Key: []byte("foo"), Value: []byte("bar")}},
-			}},
+			expEvents: []sdk.Event{
+				sdk.NewEvent("myEvent", sdk.NewAttribute("foo", "bar"), sdk.NewAttribute("_msg_index", "0"), sdk.NewAttribute("_reply_id", "0")),
+			},
 		},
 		"with context events - discarded on failure": {
 			msgs: []wasmvmtypes.SubMsg{{
@@ -293,7 +290,21 @@ func TestDispatchSubmessages(t *testing.T) {
 			},
 			expData:    nil,
 			expCommits: []bool{true},
-			expEvents:  []sdk.Event{sdk.NewEvent("execute", sdk.NewAttribute("foo", "bar"))},
+			expEvents:  []sdk.Event{sdk.NewEvent("execute", sdk.NewAttribute("foo", "bar"), sdk.NewAttribute("_msg_index", "0"), sdk.NewAttribute("_reply_id", "0"))},
+		},
+		"events tagged with submessage index and reply id": {
+			msgs:    []wasmvmtypes.SubMsg{{ID: 7, ReplyOn: wasmvmtypes.ReplyNever}, {ID: 9, ReplyOn: wasmvmtypes.ReplyNever}},
+			replyer: &mockReplyer{},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+					return []sdk.Event{sdk.NewEvent("execute", sdk.NewAttribute("foo", "bar"))}, nil, nil
+				},
+			},
+			expCommits: []bool{true, true},
+			expEvents: []sdk.Event{
+				sdk.NewEvent("execute", sdk.NewAttribute("foo", "bar"), sdk.NewAttribute("_msg_index", "0"), sdk.NewAttribute("_reply_id", "7")),
+				sdk.NewEvent("execute", sdk.NewAttribute("foo", "bar"), sdk.NewAttribute("_msg_index", "1"), sdk.NewAttribute("_reply_id", "9")),
+			},
 		},
 		"reply gets proper events": {
 			msgs: []wasmvmtypes.SubMsg{{ID: 1, ReplyOn: wasmvmtypes.ReplyAlways}},
@@ -315,6 +326,10 @@ func TestDispatchSubmessages(t *testing.T) {
 					if res.Events[1].Type != "wasm" {
 						return nil, fmt.Errorf("event1: %#v", res.Events[1])
 					}
+					// the submessage attributes are not passed to the contract
+					if len(res.Events[1].Attributes) != 1 {
+						return nil, fmt.Errorf("event1 attributes: %#v", res.Events[1].Attributes)
+					}
 
 					// let's add a custom event here and see if it makes it out
 					ctx.EventManager().EmitEvent(sdk.NewEvent("wasm-reply"))
@@ -337,8 +352,8 @@ func TestDispatchSubmessages(t *testing.T) {
 			expData:    []byte("subData"),
 			expCommits: []bool{true},
 			expEvents: []sdk.Event{
-				sdk.NewEvent("execute", sdk.NewAttribute("_contract_address", "placeholder-random-addr")),
-				sdk.NewEvent("wasm", sdk.NewAttribute("random", "data")),
+				sdk.NewEvent("execute", sdk.NewAttribute("_contract_address", "placeholder-random-addr"), sdk.NewAttribute("_msg_index", "0"), sdk.NewAttribute("_reply_id", "1")),
+				sdk.NewEvent("wasm", sdk.NewAttribute("random", "data"), sdk.NewAttribute("_msg_index", "0"), sdk.NewAttribute("_reply_id", "1")),
 				sdk.NewEvent("wasm-reply"),
 			},
 		},
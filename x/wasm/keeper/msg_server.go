@@ -11,12 +11,25 @@ import (
 
 var _ types.MsgServer = msgServer{}
 
+// MsgServerSettings provides the read access to code and module settings that the msg server needs
+// besides the contract operations
+type MsgServerSettings interface {
+	GetCodeInfo(ctx sdk.Context, codeID uint64) *types.CodeInfo
+	// IsDefaultCreatorAdmin returns true when the creator becomes admin of a new contract without an explicit admin
+	IsDefaultCreatorAdmin(ctx sdk.Context) bool
+	// IsDeniedFundsDenom returns true when the denom can not be sent as funds to a contract
+	IsDeniedFundsDenom(ctx sdk.Context, denom string) bool
+	// HasAdminTimelock returns true when admin changes and migrations by msg are scheduled instead of applied
+	HasAdminTimelock() bool
+}
+
 type msgServer struct {
-	keeper types.ContractOpsKeeper
+	keeper   types.ContractOpsKeeper
+	settings MsgServerSettings
 }
 
-func NewMsgServerImpl(k types.ContractOpsKeeper) types.MsgServer {
-	return &msgServer{keeper: k}
+func NewMsgServerImpl(k types.ContractOpsKeeper, s MsgServerSettings) types.MsgServer {
+	return &msgServer{keeper: k, settings: s}
 }
 
 func (m msgServer) StoreCode(goCtx context.Context, msg *types.MsgStoreCode) (*types.MsgStoreCodeResponse, error) {
@@ -36,9 +49,15 @@ func (m msgServer) StoreCode(goCtx context.Context, msg *types.MsgStoreCode) (*t
 	if err != nil {
 		return nil, err
 	}
+	if msg.Source != "" || msg.Builder != "" {
+		if err := m.keeper.SetCodeSource(ctx, codeID, senderAddr, msg.Source, msg.Builder); err != nil {
+			return nil, err
+		}
+	}
 
 	return &types.MsgStoreCodeResponse{
-		CodeID: codeID,
+		CodeID:   codeID,
+		Checksum: m.settings.GetCodeInfo(ctx, codeID).CodeHash,
 	}, nil
 }
 
@@ -49,11 +68,9 @@ func (m msgServer) InstantiateContract(goCtx context.Context, msg *types.MsgInst
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "sender")
 	}
-	var adminAddr sdk.AccAddress
-	if msg.Admin != "" {
-		if adminAddr, err = sdk.AccAddressFromBech32(msg.Admin); err != nil {
-			return nil, sdkerrors.Wrap(err, "admin")
-		}
+	adminAddr, err := m.instantiateAdmin(ctx, senderAddr, msg.Admin, msg.AdminMode)
+	if err != nil {
+		return nil, err
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -62,6 +79,10 @@ func (m msgServer) InstantiateContract(goCtx context.Context, msg *types.MsgInst
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
+	if err := m.assertFundsAllowed(ctx, msg.Funds); err != nil {
+		return nil, err
+	}
+
 	contractAddr, data, err := m.keeper.Instantiate(ctx, msg.CodeID, senderAddr, adminAddr, msg.Msg, msg.Label, msg.Funds)
 	if err != nil {
 		return nil, err
@@ -90,6 +111,10 @@ func (m msgServer) ExecuteContract(goCtx context.Context, msg *types.MsgExecuteC
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
+	if err := m.assertFundsAllowed(ctx, msg.Funds); err != nil {
+		return nil, err
+	}
+
 	data, err := m.keeper.Execute(ctx, contractAddr, senderAddr, msg.Msg, msg.Funds)
 	if err != nil {
 		return nil, err
@@ -117,7 +142,7 @@ func (m msgServer) MigrateContract(goCtx context.Context, msg *types.MsgMigrateC
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
-	data, err := m.keeper.Migrate(ctx, contractAddr, senderAddr, msg.CodeID, msg.Msg)
+	data, err := m.migrate(ctx, contractAddr, senderAddr, msg.CodeID, msg.Msg)
 	if err != nil {
 		return nil, err
 	}
@@ -148,6 +173,12 @@ func (m msgServer) UpdateAdmin(goCtx context.Context, msg *types.MsgUpdateAdmin)
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
+	if m.settings.HasAdminTimelock() {
+		if _, err := m.keeper.ScheduleContractAdminUpdate(ctx, contractAddr, senderAddr, newAdminAddr); err != nil {
+			return nil, err
+		}
+		return &types.MsgUpdateAdminResponse{}, nil
+	}
 	if err := m.keeper.UpdateContractAdmin(ctx, contractAddr, senderAddr, newAdminAddr); err != nil {
 		return nil, err
 	}
@@ -178,3 +209,273 @@ func (m msgServer) ClearAdmin(goCtx context.Context, msg *types.MsgClearAdmin) (
 
 	return &types.MsgClearAdminResponse{}, nil
 }
+
+func (m msgServer) UpdateExecuteGasLimit(goCtx context.Context, msg *types.MsgUpdateExecuteGasLimit) (*types.MsgUpdateExecuteGasLimitResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.keeper.UpdateExecuteGasLimit(ctx, contractAddr, senderAddr, msg.GasLimit); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateExecuteGasLimitResponse{}, nil
+}
+
+func (m msgServer) StoreAndInstantiateContract(goCtx context.Context, msg *types.MsgStoreAndInstantiateContract) (*types.MsgStoreAndInstantiateContractResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	adminAddr, err := m.instantiateAdmin(ctx, senderAddr, msg.Admin, msg.AdminMode)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.assertFundsAllowed(ctx, msg.Funds); err != nil {
+		return nil, err
+	}
+
+	codeID, err := m.keeper.Create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission)
+	if err != nil {
+		return nil, err
+	}
+
+	contractAddr, data, err := m.keeper.Instantiate(ctx, codeID, senderAddr, adminAddr, msg.Msg, msg.Label, msg.Funds)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgStoreAndInstantiateContractResponse{
+		CodeID:   codeID,
+		Checksum: m.settings.GetCodeInfo(ctx, codeID).CodeHash,
+		Address:  contractAddr.String(),
+		Data:     data,
+	}, nil
+}
+
+func (m msgServer) StoreAndMigrateContract(goCtx context.Context, msg *types.MsgStoreAndMigrateContract) (*types.MsgStoreAndMigrateContractResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddrs := make([]sdk.AccAddress, len(msg.Contracts))
+	for i, c := range msg.Contracts {
+		if contractAddrs[i], err = sdk.AccAddressFromBech32(c); err != nil {
+			return nil, sdkerrors.Wrapf(err, "contract %s", c)
+		}
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	codeID, err := m.keeper.Create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([][]byte, len(contractAddrs))
+	for i, contractAddr := range contractAddrs {
+		if data[i], err = m.migrate(ctx, contractAddr, senderAddr, codeID, msg.Msg); err != nil {
+			return nil, sdkerrors.Wrapf(err, "contract %s", contractAddr)
+		}
+	}
+
+	return &types.MsgStoreAndMigrateContractResponse{
+		CodeID:   codeID,
+		Checksum: m.settings.GetCodeInfo(ctx, codeID).CodeHash,
+		Data:     data,
+	}, nil
+}
+
+// migrate migrates the contract or schedules the migration when the admin timelock is enabled.
+// No data is returned for a scheduled migration.
+func (m msgServer) migrate(ctx sdk.Context, contractAddr, senderAddr sdk.AccAddress, codeID uint64, msg []byte) ([]byte, error) {
+	if m.settings.HasAdminTimelock() {
+		_, err := m.keeper.ScheduleMigrate(ctx, contractAddr, senderAddr, codeID, msg)
+		return nil, err
+	}
+	return m.keeper.Migrate(ctx, contractAddr, senderAddr, codeID, msg)
+}
+
+// instantiateAdmin returns the admin of a new contract instance for the admin mode. Without admin address and mode,
+// the creator becomes admin when this is the default in the params.
+func (m msgServer) instantiateAdmin(ctx sdk.Context, creator sdk.AccAddress, admin string, mode types.InstantiateAdminMode) (sdk.AccAddress, error) {
+	switch {
+	case mode == types.InstantiateAdminModeCreator:
+		return creator, nil
+	case mode == types.InstantiateAdminModeNone:
+		return nil, nil
+	case admin != "":
+		adminAddr, err := sdk.AccAddressFromBech32(admin)
+		return adminAddr, sdkerrors.Wrap(err, "admin")
+	case m.settings.IsDefaultCreatorAdmin(ctx):
+		return creator, nil
+	}
+	return nil, nil
+}
+
+// assertFundsAllowed returns an error when one of the coins has a denom that can not be sent to contracts
+func (m msgServer) assertFundsAllowed(ctx sdk.Context, funds sdk.Coins) error {
+	for _, c := range funds {
+		if m.settings.IsDeniedFundsDenom(ctx, c.Denom) {
+			return sdkerrors.Wrapf(types.ErrDeniedFundsDenom, "%s", c.Denom)
+		}
+	}
+	return nil
+}
+
+func (m msgServer) ScheduleContractCall(goCtx context.Context, msg *types.MsgScheduleContractCall) (*types.MsgScheduleContractCallResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	id, err := m.keeper.ScheduleContractCall(ctx, contractAddr, msg.Msg, msg.Height, msg.Time)
+	if err != nil {
+		return nil, err
+	}
+	return &types.MsgScheduleContractCallResponse{ID: id}, nil
+}
+
+func (m msgServer) DepositRent(goCtx context.Context, msg *types.MsgDepositRent) (*types.MsgDepositRentResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.keeper.DepositRent(ctx, senderAddr, contractAddr, msg.Amount); err != nil {
+		return nil, err
+	}
+	return &types.MsgDepositRentResponse{}, nil
+}
+
+func (m msgServer) UpdateOperator(goCtx context.Context, msg *types.MsgUpdateOperator) (*types.MsgUpdateOperatorResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+	var operatorAddr sdk.AccAddress
+	if msg.Operator != "" {
+		if operatorAddr, err = sdk.AccAddressFromBech32(msg.Operator); err != nil {
+			return nil, sdkerrors.Wrap(err, "operator")
+		}
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	// removing the operator does not grant any permissions and is not timelocked
+	if m.settings.HasAdminTimelock() && operatorAddr != nil {
+		if _, err := m.keeper.ScheduleContractOperatorUpdate(ctx, contractAddr, senderAddr, operatorAddr, msg.SudoMsgs); err != nil {
+			return nil, err
+		}
+		return &types.MsgUpdateOperatorResponse{}, nil
+	}
+	if err := m.keeper.UpdateContractOperator(ctx, contractAddr, senderAddr, operatorAddr, msg.SudoMsgs); err != nil {
+		return nil, err
+	}
+	return &types.MsgUpdateOperatorResponse{}, nil
+}
+
+func (m msgServer) UpdateLabel(goCtx context.Context, msg *types.MsgUpdateLabel) (*types.MsgUpdateLabelResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.keeper.UpdateContractLabel(ctx, contractAddr, senderAddr, msg.Label); err != nil {
+		return nil, err
+	}
+	return &types.MsgUpdateLabelResponse{}, nil
+}
+
+func (m msgServer) OperatorSudo(goCtx context.Context, msg *types.MsgOperatorSudo) (*types.MsgOperatorSudoResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if m.settings.HasAdminTimelock() {
+		if _, err := m.keeper.ScheduleOperatorSudo(ctx, contractAddr, senderAddr, msg.Msg); err != nil {
+			return nil, err
+		}
+		return &types.MsgOperatorSudoResponse{}, nil
+	}
+	data, err := m.keeper.OperatorSudo(ctx, contractAddr, senderAddr, msg.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return &types.MsgOperatorSudoResponse{Data: data}, nil
+}
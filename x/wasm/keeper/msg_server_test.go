@@ -0,0 +1,85 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestInstantiateAdminMode(t *testing.T) {
+	myAdmin := RandomAccountAddress(t)
+	specs := map[string]struct {
+		srcAdmin               sdk.AccAddress
+		srcMode                types.InstantiateAdminMode
+		srcDefaultCreatorAdmin bool
+		expCreatorAdmin        bool
+		expAdmin               sdk.AccAddress
+	}{
+		"admin address": {
+			srcAdmin: myAdmin,
+			expAdmin: myAdmin,
+		},
+		"admin address with default creator admin": {
+			srcAdmin:               myAdmin,
+			srcDefaultCreatorAdmin: true,
+			expAdmin:               myAdmin,
+		},
+		"no admin": {},
+		"no admin with default creator admin": {
+			srcDefaultCreatorAdmin: true,
+			expCreatorAdmin:        true,
+		},
+		"creator mode": {
+			srcMode:         types.InstantiateAdminModeCreator,
+			expCreatorAdmin: true,
+		},
+		"none mode": {
+			srcMode: types.InstantiateAdminModeNone,
+		},
+		"none mode with default creator admin": {
+			srcMode:                types.InstantiateAdminModeNone,
+			srcDefaultCreatorAdmin: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			k := keepers.WasmKeeper
+			params := k.GetParams(ctx)
+			params.DefaultCreatorAdmin = spec.srcDefaultCreatorAdmin
+			k.SetParams(ctx, params)
+			example := StoreRandomContract(t, ctx, keepers, &mock)
+			msg := &types.MsgInstantiateContract{
+				Sender:    example.CreatorAddr.String(),
+				CodeID:    example.CodeID,
+				Label:     "testing",
+				Msg:       []byte(`{}`),
+				AdminMode: spec.srcMode,
+			}
+			if spec.srcAdmin != nil {
+				msg.Admin = spec.srcAdmin.String()
+			}
+			require.NoError(t, msg.ValidateBasic())
+
+			// when
+			rsp, err := NewMsgServerImpl(NewDefaultPermissionKeeper(k), k).InstantiateContract(sdk.WrapSDKContext(ctx), msg)
+
+			// then
+			require.NoError(t, err)
+			contractAddr, err := sdk.AccAddressFromBech32(rsp.Address)
+			require.NoError(t, err)
+			expAdmin := spec.expAdmin
+			if spec.expCreatorAdmin {
+				expAdmin = example.CreatorAddr
+			}
+			assert.Equal(t, expAdmin, k.GetContractInfo(ctx, contractAddr).AdminAddr())
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// setContractOperator sets the operator of the contract and the sudo messages it is allowed to send. Only the admin
+// can set the operator. A nil operator removes it.
+func (k Keeper) setContractOperator(ctx sdk.Context, contractAddress, caller, operator sdk.AccAddress, sudoMsgs []string, authZ AuthorizationPolicy) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	contractInfo.Operator, contractInfo.OperatorSudoMsgs = "", nil
+	if operator != nil {
+		contractInfo.Operator, contractInfo.OperatorSudoMsgs = operator.String(), sudoMsgs
+	}
+	if err := contractInfo.ValidateBasic(); err != nil {
+		return err
+	}
+	k.storeContractInfo(ctx, contractAddress, contractInfo)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateOperator,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyOperator, contractInfo.Operator),
+	))
+	return nil
+}
+
+// setContractLabel sets a new label for the contract. It can be set by the admin or the operator of the contract.
+func (k Keeper) setContractLabel(ctx sdk.Context, contractAddress, caller sdk.AccAddress, label string, authZ AuthorizationPolicy) error {
+	if err := types.ValidateLabel(label); err != nil {
+		return sdkerrors.Wrap(err, "label")
+	}
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanOperateContract(contractInfo.AdminAddr(), contractInfo.OperatorAddr(), caller) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	contractInfo.Label = label
+	k.storeContractInfo(ctx, contractAddress, contractInfo)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateLabel,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyLabel, label),
+	))
+	return nil
+}
+
+// operatorSudo calls the sudo entry point of the contract on behalf of the admin or operator. The top level key of
+// the message must be in the list of sudo messages that the admin allowed for the operator.
+func (k Keeper) operatorSudo(ctx sdk.Context, contractAddress, caller sdk.AccAddress, msg []byte, authZ AuthorizationPolicy) ([]byte, error) {
+	if err := k.checkContractMsgSize(ctx, msg); err != nil {
+		return nil, err
+	}
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanOperateContract(contractInfo.AdminAddr(), contractInfo.OperatorAddr(), caller) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not operate contract")
+	}
+	name, err := sudoMsgName(msg)
+	if err != nil {
+		return nil, err
+	}
+	if !contains(contractInfo.OperatorSudoMsgs, name) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "sudo msg not allowed: %s", name)
+	}
+	return k.Sudo(ctx, contractAddress, msg)
+}
+
+// sudoMsgName returns the top level key of a json encoded sudo message
+func sudoMsgName(msg []byte) (string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(msg, &raw); err != nil {
+		return "", sdkerrors.Wrap(types.ErrInvalid, "sudo msg must be a json object")
+	}
+	if len(raw) != 1 {
+		return "", sdkerrors.Wrap(types.ErrInvalid, "sudo msg must have exactly one top level key")
+	}
+	for name := range raw {
+		return name, nil
+	}
+	return "", nil // unreachable
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestUpdateContractOperator(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	operator := RandomAccountAddress(t)
+
+	// only the admin can set the operator
+	err := keepers.ContractKeeper.UpdateContractOperator(ctx, example.Contract, operator, operator, nil)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+	err = keepers.ContractKeeper.UpdateContractOperator(ctx, RandomAccountAddress(t), example.CreatorAddr, operator, nil)
+	assert.True(t, sdkerrors.ErrInvalidRequest.Is(err), "got %+v", err)
+	err = keepers.ContractKeeper.UpdateContractOperator(ctx, example.Contract, example.CreatorAddr, operator, []string{"foo", "foo"})
+	assert.True(t, types.ErrDuplicate.Is(err), "got %+v", err)
+
+	require.NoError(t, keepers.ContractKeeper.UpdateContractOperator(ctx, example.Contract, example.CreatorAddr, operator, []string{"foo"}))
+	info := k.GetContractInfo(ctx, example.Contract)
+	assert.Equal(t, operator.String(), info.Operator)
+	assert.Equal(t, []string{"foo"}, info.OperatorSudoMsgs)
+
+	// the operator can not replace itself
+	err = keepers.ContractKeeper.UpdateContractOperator(ctx, example.Contract, operator, RandomAccountAddress(t), nil)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+
+	// and when cleared
+	require.NoError(t, keepers.ContractKeeper.UpdateContractOperator(ctx, example.Contract, example.CreatorAddr, nil, []string{"foo"}))
+	info = k.GetContractInfo(ctx, example.Contract)
+	assert.Empty(t, info.Operator)
+	assert.Empty(t, info.OperatorSudoMsgs)
+}
+
+func TestAdminChangeRemovesOperator(t *testing.T) {
+	specs := map[string]struct {
+		src func(ctx sdk.Context, keepers TestKeepers, example ExampleContractInstance) error
+	}{
+		"update admin": {
+			src: func(ctx sdk.Context, keepers TestKeepers, example ExampleContractInstance) error {
+				return keepers.ContractKeeper.UpdateContractAdmin(ctx, example.Contract, example.CreatorAddr, RandomAccountAddress(t))
+			},
+		},
+		"clear admin": {
+			src: func(ctx sdk.Context, keepers TestKeepers, example ExampleContractInstance) error {
+				return keepers.ContractKeeper.ClearContractAdmin(ctx, example.Contract, example.CreatorAddr)
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			operator := RandomAccountAddress(t)
+			require.NoError(t, keepers.ContractKeeper.UpdateContractOperator(ctx, example.Contract, example.CreatorAddr, operator, []string{"foo"}))
+			em := sdk.NewEventManager()
+
+			// when
+			require.NoError(t, spec.src(ctx.WithEventManager(em), keepers, example))
+
+			// then
+			info := keepers.WasmKeeper.GetContractInfo(ctx, example.Contract)
+			assert.Empty(t, info.Operator)
+			assert.Empty(t, info.OperatorSudoMsgs)
+			expEvt := sdk.NewEvent("update_contract_operator",
+				sdk.NewAttribute("_contract_address", example.Contract.String()),
+				sdk.NewAttribute("operator", ""),
+			)
+			assert.Contains(t, em.Events(), expEvt)
+			_, err := keepers.ContractKeeper.OperatorSudo(ctx, example.Contract, operator, []byte(`{"foo":{}}`))
+			assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+		})
+	}
+}
+
+func TestOperatorPermissions(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	newCodeID := StoreRandomContract(t, ctx, keepers, &mock).CodeID
+	operator, other := RandomAccountAddress(t), RandomAccountAddress(t)
+	require.NoError(t, keepers.ContractKeeper.UpdateContractOperator(ctx, example.Contract, example.CreatorAddr, operator, nil))
+
+	specs := map[string]struct {
+		src   func(caller sdk.AccAddress) error
+		expOp bool
+	}{
+		"update label": {
+			src: func(caller sdk.AccAddress) error {
+				return keepers.ContractKeeper.UpdateContractLabel(ctx, example.Contract, caller, "new label")
+			},
+			expOp: true,
+		},
+		"update execute gas limit": {
+			src: func(caller sdk.AccAddress) error {
+				return keepers.ContractKeeper.UpdateExecuteGasLimit(ctx, example.Contract, caller, 1)
+			},
+			expOp: true,
+		},
+		"migrate": {
+			src: func(caller sdk.AccAddress) error {
+				_, err := keepers.ContractKeeper.Migrate(ctx, example.Contract, caller, newCodeID, []byte(`{}`))
+				return err
+			},
+		},
+		"update admin": {
+			src: func(caller sdk.AccAddress) error {
+				return keepers.ContractKeeper.UpdateContractAdmin(ctx, example.Contract, caller, caller)
+			},
+		},
+		"clear admin": {
+			src: func(caller sdk.AccAddress) error {
+				return keepers.ContractKeeper.ClearContractAdmin(ctx, example.Contract, caller)
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			err := spec.src(other)
+			assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+			err = spec.src(operator)
+			if spec.expOp {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+			}
+		})
+	}
+	assert.Equal(t, "new label", k.GetContractInfo(ctx, example.Contract).Label)
+}
+
+func TestOperatorSudo(t *testing.T) {
+	specs := map[string]struct {
+		srcCaller func(example ExampleContractInstance, operator sdk.AccAddress) sdk.AccAddress
+		srcMsg    []byte
+		expErr    *sdkerrors.Error
+	}{
+		"operator with allowed msg": {
+			srcCaller: func(_ ExampleContractInstance, operator sdk.AccAddress) sdk.AccAddress { return operator },
+			srcMsg:    []byte(`{"set_params":{"foo":1}}`),
+		},
+		"admin with allowed msg": {
+			srcCaller: func(example ExampleContractInstance, _ sdk.AccAddress) sdk.AccAddress { return example.CreatorAddr },
+			srcMsg:    []byte(`{"set_params":{}}`),
+		},
+		"operator with not allowed msg": {
+			srcCaller: func(_ ExampleContractInstance, operator sdk.AccAddress) sdk.AccAddress { return operator },
+			srcMsg:    []byte(`{"withdraw":{}}`),
+			expErr:    sdkerrors.ErrUnauthorized,
+		},
+		"operator with multiple top level keys": {
+			srcCaller: func(_ ExampleContractInstance, operator sdk.AccAddress) sdk.AccAddress { return operator },
+			srcMsg:    []byte(`{"set_params":{},"withdraw":{}}`),
+			expErr:    types.ErrInvalid,
+		},
+		"operator with non object msg": {
+			srcCaller: func(_ ExampleContractInstance, operator sdk.AccAddress) sdk.AccAddress { return operator },
+			srcMsg:    []byte(`"set_params"`),
+			expErr:    types.ErrInvalid,
+		},
+		"other account": {
+			srcCaller: func(ExampleContractInstance, sdk.AccAddress) sdk.AccAddress { return RandomAccountAddress(t) },
+			srcMsg:    []byte(`{"set_params":{}}`),
+			expErr:    sdkerrors.ErrUnauthorized,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			var gotSudoMsg []byte
+			mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+				gotSudoMsg = sudoMsg
+				return &wasmvmtypes.Response{Data: []byte("ok")}, 0, nil
+			}
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			operator := RandomAccountAddress(t)
+			require.NoError(t, keepers.ContractKeeper.UpdateContractOperator(ctx, example.Contract, example.CreatorAddr, operator, []string{"set_params"}))
+
+			// when
+			data, gotErr := keepers.ContractKeeper.OperatorSudo(ctx, example.Contract, spec.srcCaller(example, operator), spec.srcMsg)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				assert.Nil(t, gotSudoMsg)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, []byte("ok"), data)
+			assert.Equal(t, spec.srcMsg, gotSudoMsg)
+		})
+	}
+}
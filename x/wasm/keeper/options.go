@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -100,7 +101,16 @@ func WithVMCacheMetrics(r prometheus.Registerer) Option {
 	})
 }
 
+// WithTracerProvider sets the OpenTelemetry provider for the contract call spans.
+// The global provider is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return optsFn(func(k *Keeper) {
+		k.tracer = tp.Tracer(tracerName)
+	})
+}
+
 // WithGasRegister set a new gas register to implement custom gas costs.
+// The JSON deserialization costs in wasmvm are converted with the gas multiplier of the register.
 // When the "gas multiplier" for wasmvm gas conversion is modified inside the new register,
 // make sure to also use `WithApiCosts` option for non default values
 func WithGasRegister(x GasRegister) Option {
@@ -109,10 +119,95 @@ func WithGasRegister(x GasRegister) Option {
 	})
 }
 
+// WithMaxCallDepth sets the max depth of nested message dispatches from contracts.
+// This value is consensus relevant and must be the same on all nodes.
+func WithMaxCallDepth(depth uint32) Option {
+	return optsFn(func(k *Keeper) {
+		k.maxCallDepth = depth
+	})
+}
+
+// WithCronGasLimit sets the max gas for a single begin or end block call to a contract. This applies
+// to cron contracts and deferred calls.
+// This value is consensus relevant and must be the same on all nodes.
+func WithCronGasLimit(gasLimit uint64) Option {
+	return optsFn(func(k *Keeper) {
+		k.cronGasLimit = gasLimit
+	})
+}
+
+// WithMaxDeferredCallsPerBlock sets the max number of deferred calls that are delivered in a single block.
+// The limit is split between the calls that are due by height and the calls that are due by time. Calls that
+// exceed the limit are delivered in the next blocks. With 0 no deferred calls are delivered at all.
+// This value is consensus relevant and must be the same on all nodes.
+func WithMaxDeferredCallsPerBlock(n uint32) Option {
+	return optsFn(func(k *Keeper) {
+		k.maxDeferredCallsPerBlock = n
+	})
+}
+
+// WithMaxDeferredCallsPerContract sets the max number of deferred calls that a single contract can have pending.
+// Scheduling more calls fails until some of the pending calls were delivered.
+// This value is consensus relevant and must be the same on all nodes.
+func WithMaxDeferredCallsPerContract(n uint32) Option {
+	return optsFn(func(k *Keeper) {
+		k.maxDeferredCallsPerContract = n
+	})
+}
+
+// WithPrivilegedMessages rejects messages from contracts that were not granted the capability the requirement
+// returns for the message. Capabilities are granted by governance. The check decorates the final message handler,
+// independent of the option order.
+func WithPrivilegedMessages(requirement CapabilityRequirement) Option {
+	return optsFn(func(k *Keeper) {
+		k.capabilityRequirement = requirement
+	})
+}
+
+// WithStateRent enables the state rent that contracts pay for the bytes in their store.
+// This value is consensus relevant and must be the same on all nodes.
+func WithStateRent(c StateRentConfig) Option {
+	if err := c.ValidateBasic(); err != nil {
+		panic(fmt.Sprintf("invalid state rent config: %s", err))
+	}
+	return optsFn(func(k *Keeper) {
+		k.stateRent = &c
+	})
+}
+
+// WithAdminTimelock delays admin changes and migrations that are sent as msg by the given number of blocks so
+// that users of a contract have time to react. Changes by governance are not delayed.
+// Timelocked migrations are executed in end block with the cron gas limit, see WithCronGasLimit. A migration that
+// needs more gas fails and the contract is not modified. The cron gas limit is charged when the migration is scheduled.
+// This value is consensus relevant and must be the same on all nodes.
+func WithAdminTimelock(blocks uint64) Option {
+	return optsFn(func(k *Keeper) {
+		k.adminTimelock = blocks
+	})
+}
+
+// WithMaxTimelockedCallsPerBlock sets the max number of timelocked migrations and operator sudo calls that are executed
+// in a single block. Calls that exceed the limit are executed in the next blocks. With 0 no timelocked calls are
+// executed at all.
+// This value is consensus relevant and must be the same on all nodes.
+func WithMaxTimelockedCallsPerBlock(n uint32) Option {
+	return optsFn(func(k *Keeper) {
+		k.maxTimelockedCallsPerBlock = n
+	})
+}
+
+// WithWasmHooks sets the hooks that are called on contract lifecycle events.
+// Use types.NewMultiWasmHooks to register hooks of multiple modules.
+func WithWasmHooks(h types.WasmHooks) Option {
+	return optsFn(func(k *Keeper) {
+		k.hooks = h
+	})
+}
+
 // WithAPICosts sets custom api costs. Amounts are in cosmwasm gas Not SDK gas.
+// Without this option, the default costs are converted with the gas multiplier of the gas register.
 func WithAPICosts(human, canonical uint64) Option {
-	return optsFn(func(_ *Keeper) {
-		costHumanize = human
-		costCanonical = canonical
+	return optsFn(func(k *Keeper) {
+		k.customAPICosts = &apiCosts{humanize: human, canonical: canonical}
 	})
 }
@@ -3,6 +3,7 @@ package keeper
 import (
 	"testing"
 
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	distributionkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
@@ -67,12 +68,39 @@ func TestConstructorOptions(t *testing.T) {
 				assert.IsType(t, &wasmtesting.MockGasRegister{}, k.gasRegister)
 			},
 		},
+		"gas multiplier for json deserialization": {
+			srcOpt: WithGasRegister(NewWasmGasRegister(WasmGasRegisterConfig{GasMultiplier: 3})),
+			verify: func(t *testing.T, k Keeper) {
+				exp := wasmvmtypes.UFraction{Numerator: 3 * DefaultDeserializationCostPerByte, Denominator: 1}
+				assert.Equal(t, exp, k.jsonDeserializationCosts())
+			},
+		},
+		"privileged messages": {
+			srcOpt: WithPrivilegedMessages(func(wasmvmtypes.CosmosMsg) string { return "" }),
+			verify: func(t *testing.T, k Keeper) {
+				assert.IsType(t, CapabilityMessageHandler{}, k.messenger)
+			},
+		},
+		"max call depth": {
+			srcOpt: WithMaxCallDepth(1),
+			verify: func(t *testing.T, k Keeper) {
+				assert.Equal(t, uint32(1), k.maxCallDepth)
+			},
+		},
 		"api costs": {
 			srcOpt: WithAPICosts(1, 2),
 			verify: func(t *testing.T, k Keeper) {
-				t.Cleanup(setApiDefaults)
-				assert.Equal(t, uint64(1), costHumanize)
-				assert.Equal(t, uint64(2), costCanonical)
+				assert.Equal(t, apiCosts{humanize: 1, canonical: 2}, k.addressAPICosts())
+			},
+		},
+		"gas multiplier for api costs": {
+			srcOpt: WithGasRegister(NewWasmGasRegister(WasmGasRegisterConfig{GasMultiplier: 3})),
+			verify: func(t *testing.T, k Keeper) {
+				exp := apiCosts{humanize: 3 * DefaultGasCostHumanAddress, canonical: 3 * DefaultGasCostCanonicalAddress}
+				assert.Equal(t, exp, k.addressAPICosts())
+				_, gotCost, err := k.cosmwasmAPI().HumanAddress(make([]byte, types.SDKAddrLen))
+				require.NoError(t, err)
+				assert.Equal(t, exp.humanize, gotCost)
 			},
 		},
 	}
@@ -85,7 +113,21 @@ func TestConstructorOptions(t *testing.T) {
 
 }
 
-func setApiDefaults() {
-	costHumanize = DefaultGasCostHumanAddress * DefaultGasMultiplier
-	costCanonical = DefaultGasCostCanonicalAddress * DefaultGasMultiplier
+func TestPrivilegedMessagesOptionOrder(t *testing.T) {
+	requirement := func(wasmvmtypes.CosmosMsg) string { return "" }
+	specs := map[string][]Option{
+		"before message handler": {WithPrivilegedMessages(requirement), WithMessageHandler(&wasmtesting.MockMessageHandler{})},
+		"after message handler":  {WithMessageHandler(&wasmtesting.MockMessageHandler{}), WithPrivilegedMessages(requirement)},
+		"before message handler decorator": {
+			WithPrivilegedMessages(requirement),
+			WithMessageHandlerDecorator(func(old Messenger) Messenger { return &wasmtesting.MockMessageHandler{} }),
+		},
+	}
+	for name, opts := range specs {
+		t.Run(name, func(t *testing.T) {
+			k := NewKeeper(nil, nil, paramtypes.NewSubspace(nil, nil, nil, nil, ""), authkeeper.AccountKeeper{}, nil, stakingkeeper.Keeper{}, distributionkeeper.Keeper{}, nil, nil, nil, nil, nil, nil, "tempDir", types.DefaultWasmConfig(), SupportedFeatures, opts...)
+			require.IsType(t, CapabilityMessageHandler{}, k.messenger)
+			assert.IsType(t, &wasmtesting.MockMessageHandler{}, k.messenger.(CapabilityMessageHandler).nested)
+		})
+	}
 }
@@ -47,6 +47,22 @@ func NewWasmProposalHandlerX(k types.ContractOpsKeeper, enabledProposalTypes []t
 			return handlePinCodesProposal(ctx, k, *c)
 		case *types.UnpinCodesProposal:
 			return handleUnpinCodesProposal(ctx, k, *c)
+		case *types.DeactivateContractsProposal:
+			return handleDeactivateContractsProposal(ctx, k, *c)
+		case *types.ActivateContractsProposal:
+			return handleActivateContractsProposal(ctx, k, *c)
+		case *types.UpdateExecuteGasLimitProposal:
+			return handleUpdateExecuteGasLimitProposal(ctx, k, *c)
+		case *types.RegisterCronContractsProposal:
+			return handleRegisterCronContractsProposal(ctx, k, *c)
+		case *types.UnregisterCronContractsProposal:
+			return handleUnregisterCronContractsProposal(ctx, k, *c)
+		case *types.PruneCodesProposal:
+			return handlePruneCodesProposal(ctx, k, *c)
+		case *types.GrantContractCapabilitiesProposal:
+			return handleGrantContractCapabilitiesProposal(ctx, k, *c)
+		case *types.RevokeContractCapabilitiesProposal:
+			return handleRevokeContractCapabilitiesProposal(ctx, k, *c)
 		default:
 			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized wasm proposal content type: %T", c)
 		}
@@ -66,6 +82,11 @@ func handleStoreCodeProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types
 	if err != nil {
 		return err
 	}
+	if p.Source != "" || p.Builder != "" {
+		if err := k.SetCodeSource(ctx, codeID, runAsAddr, p.Source, p.Builder); err != nil {
+			return err
+		}
+	}
 	return k.PinCode(ctx, codeID)
 }
 
@@ -221,3 +242,122 @@ func handleUnpinCodesProposal(ctx sdk.Context, k types.ContractOpsKeeper, p type
 	}
 	return nil
 }
+
+func handlePruneCodesProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.PruneCodesProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	for _, v := range p.CodeIDs {
+		if err := k.PruneCode(ctx, v); err != nil {
+			return sdkerrors.Wrapf(err, "code id: %d", v)
+		}
+	}
+	return nil
+}
+
+func handleGrantContractCapabilitiesProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.GrantContractCapabilitiesProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(p.Contract)
+	if err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	for _, c := range p.Capabilities {
+		if err := k.GrantContractCapability(ctx, contractAddr, c); err != nil {
+			return sdkerrors.Wrapf(err, "capability: %s", c)
+		}
+	}
+	return nil
+}
+
+func handleRevokeContractCapabilitiesProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.RevokeContractCapabilitiesProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(p.Contract)
+	if err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	for _, c := range p.Capabilities {
+		if err := k.RevokeContractCapability(ctx, contractAddr, c); err != nil {
+			return sdkerrors.Wrapf(err, "capability: %s", c)
+		}
+	}
+	return nil
+}
+
+func handleDeactivateContractsProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.DeactivateContractsProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	for _, v := range p.Contracts {
+		contractAddr, err := sdk.AccAddressFromBech32(v)
+		if err != nil {
+			return sdkerrors.Wrap(err, "contract")
+		}
+		if err := k.DeactivateContract(ctx, contractAddr); err != nil {
+			return sdkerrors.Wrapf(err, "contract: %s", v)
+		}
+	}
+	return nil
+}
+
+func handleActivateContractsProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.ActivateContractsProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	for _, v := range p.Contracts {
+		contractAddr, err := sdk.AccAddressFromBech32(v)
+		if err != nil {
+			return sdkerrors.Wrap(err, "contract")
+		}
+		if err := k.ActivateContract(ctx, contractAddr); err != nil {
+			return sdkerrors.Wrapf(err, "contract: %s", v)
+		}
+	}
+	return nil
+}
+
+func handleUpdateExecuteGasLimitProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.UpdateExecuteGasLimitProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(p.Contract)
+	if err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	return k.UpdateExecuteGasLimit(ctx, contractAddr, nil, p.GasLimit)
+}
+
+func handleRegisterCronContractsProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.RegisterCronContractsProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	for _, v := range p.Contracts {
+		contractAddr, err := sdk.AccAddressFromBech32(v)
+		if err != nil {
+			return sdkerrors.Wrap(err, "contract")
+		}
+		if err := k.RegisterCronContract(ctx, contractAddr); err != nil {
+			return sdkerrors.Wrapf(err, "contract: %s", v)
+		}
+	}
+	return nil
+}
+
+func handleUnregisterCronContractsProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.UnregisterCronContractsProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	for _, v := range p.Contracts {
+		contractAddr, err := sdk.AccAddressFromBech32(v)
+		if err != nil {
+			return sdkerrors.Wrap(err, "contract")
+		}
+		if err := k.UnregisterCronContract(ctx, contractAddr); err != nil {
+			return sdkerrors.Wrapf(err, "contract: %s", v)
+		}
+	}
+	return nil
+}
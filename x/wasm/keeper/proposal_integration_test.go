@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"testing"
 
@@ -29,6 +30,8 @@ func TestStoreCodeProposal(t *testing.T) {
 	wasmKeeper.SetParams(ctx, types.Params{
 		CodeUploadAccess:             types.AllowNobody,
 		InstantiateDefaultPermission: types.AccessTypeNobody,
+		MaxContractMsgSize:           types.DefaultMaxContractMsgSize,
+		MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
 	})
 	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
 	require.NoError(t, err)
@@ -38,6 +41,8 @@ func TestStoreCodeProposal(t *testing.T) {
 	src := types.StoreCodeProposalFixture(func(p *types.StoreCodeProposal) {
 		p.RunAs = myActorAddress
 		p.WASMByteCode = wasmCode
+		p.Source = "https://github.com/CosmWasm/cosmwasm/tree/v1.0.0/contracts/hackatom"
+		p.Builder = "cosmwasm/rust-optimizer:0.12.6"
 	})
 
 	// when stored
@@ -53,6 +58,8 @@ func TestStoreCodeProposal(t *testing.T) {
 	cInfo := wasmKeeper.GetCodeInfo(ctx, 1)
 	require.NotNil(t, cInfo)
 	assert.Equal(t, myActorAddress, cInfo.Creator)
+	assert.Equal(t, src.Source, cInfo.Source)
+	assert.Equal(t, src.Builder, cInfo.Builder)
 	assert.True(t, wasmKeeper.IsPinnedCode(ctx, 1))
 
 	storedCode, err := wasmKeeper.GetByteCode(ctx, 1)
@@ -66,6 +73,8 @@ func TestInstantiateProposal(t *testing.T) {
 	wasmKeeper.SetParams(ctx, types.Params{
 		CodeUploadAccess:             types.AllowNobody,
 		InstantiateDefaultPermission: types.AccessTypeNobody,
+		MaxContractMsgSize:           types.DefaultMaxContractMsgSize,
+		MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
 	})
 
 	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
@@ -129,6 +138,8 @@ func TestInstantiateProposal_NoAdmin(t *testing.T) {
 	wasmKeeper.SetParams(ctx, types.Params{
 		CodeUploadAccess:             types.AllowNobody,
 		InstantiateDefaultPermission: types.AccessTypeNobody,
+		MaxContractMsgSize:           types.DefaultMaxContractMsgSize,
+		MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
 	})
 
 	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
@@ -203,6 +214,8 @@ func TestMigrateProposal(t *testing.T) {
 	wasmKeeper.SetParams(ctx, types.Params{
 		CodeUploadAccess:             types.AllowNobody,
 		InstantiateDefaultPermission: types.AccessTypeNobody,
+		MaxContractMsgSize:           types.DefaultMaxContractMsgSize,
+		MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
 	})
 
 	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
@@ -225,7 +238,7 @@ func TestMigrateProposal(t *testing.T) {
 	key, err := hex.DecodeString("636F6E666967")
 	require.NoError(t, err)
 	m := types.Model{Key: key, Value: []byte(`{"verifier":"AAAAAAAAAAAAAAAAAAAAAAAAAAA=","beneficiary":"AAAAAAAAAAAAAAAAAAAAAAAAAAA=","funder":"AQEBAQEBAQEBAQEBAQEBAQEBAQE="}`)}
-	require.NoError(t, wasmKeeper.importContract(ctx, contractAddr, &contractInfoFixture, []types.Model{m}))
+	require.NoError(t, wasmKeeper.importContract(ctx, contractAddr, &contractInfoFixture, []types.Model{m}, nil))
 
 	migMsg := struct {
 		Verifier sdk.AccAddress `json:"verifier"`
@@ -454,12 +467,14 @@ func TestAdminProposals(t *testing.T) {
 			wasmKeeper.SetParams(ctx, types.Params{
 				CodeUploadAccess:             types.AllowNobody,
 				InstantiateDefaultPermission: types.AccessTypeNobody,
+				MaxContractMsgSize:           types.DefaultMaxContractMsgSize,
+				MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
 			})
 
 			codeInfoFixture := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
 			require.NoError(t, wasmKeeper.importCode(ctx, 1, codeInfoFixture, wasmCode))
 
-			require.NoError(t, wasmKeeper.importContract(ctx, contractAddr, &spec.state, []types.Model{}))
+			require.NoError(t, wasmKeeper.importContract(ctx, contractAddr, &spec.state, []types.Model{}, nil))
 			// when stored
 			storedProposal, err := govKeeper.SubmitProposal(ctx, spec.srcProposal)
 			require.NoError(t, err)
@@ -749,3 +764,215 @@ func TestUnpinCodesProposal(t *testing.T) {
 		})
 	}
 }
+
+func TestDeactivateContractsProposal(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking")
+	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+
+	exampleContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+	contractAddr := exampleContract.Contract
+	releaseMsgBz := []byte(`{"release":{}}`)
+	migMsgBz := []byte(fmt.Sprintf(`{"verifier":%q}`, exampleContract.BeneficiaryAddr))
+
+	submitAndExecute := func(t *testing.T, ctx sdk.Context, content govtypes.Content) error {
+		storedProposal, err := govKeeper.SubmitProposal(ctx, content)
+		if err != nil {
+			return err
+		}
+		handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
+		return handler(ctx, storedProposal.GetContent())
+	}
+
+	// when deactivated
+	err := submitAndExecute(t, ctx, &types.DeactivateContractsProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contracts:   []string{contractAddr.String()},
+	})
+	require.NoError(t, err)
+	assert.True(t, wasmKeeper.IsInactiveContract(ctx, contractAddr))
+
+	// then execute and migrate are rejected
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, exampleContract.VerifierAddr, releaseMsgBz, nil)
+	assert.True(t, types.ErrInactiveContract.Is(err), "got %+v", err)
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, exampleContract.CreatorAddr, exampleContract.CodeID, migMsgBz)
+	assert.True(t, types.ErrInactiveContract.Is(err), "got %+v", err)
+
+	// and queries still work
+	_, err = wasmKeeper.QuerySmart(ctx, contractAddr, []byte(`{"verifier":{}}`))
+	require.NoError(t, err)
+
+	// when activated again
+	err = submitAndExecute(t, ctx, &types.ActivateContractsProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contracts:   []string{contractAddr.String()},
+	})
+	require.NoError(t, err)
+	assert.False(t, wasmKeeper.IsInactiveContract(ctx, contractAddr))
+
+	// then the contract can be executed
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, exampleContract.VerifierAddr, releaseMsgBz, nil)
+	require.NoError(t, err)
+
+	// and an unknown contract can not be deactivated
+	err = submitAndExecute(t, ctx, &types.DeactivateContractsProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contracts:   []string{RandomBech32AccountAddress(t)},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestRegisterCronContractsProposal(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking")
+	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+
+	contractAddr := InstantiateHackatomExampleContract(t, ctx, keepers).Contract
+
+	submitAndExecute := func(t *testing.T, ctx sdk.Context, content govtypes.Content) error {
+		storedProposal, err := govKeeper.SubmitProposal(ctx, content)
+		if err != nil {
+			return err
+		}
+		handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
+		return handler(ctx, storedProposal.GetContent())
+	}
+
+	// when registered
+	err := submitAndExecute(t, ctx, &types.RegisterCronContractsProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contracts:   []string{contractAddr.String()},
+	})
+	require.NoError(t, err)
+	assert.True(t, wasmKeeper.IsCronContract(ctx, contractAddr))
+
+	// then the failing begin block call does not halt the chain
+	assert.NotPanics(t, func() { wasmKeeper.BeginBlocker(ctx) })
+
+	// when unregistered
+	err = submitAndExecute(t, ctx, &types.UnregisterCronContractsProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contracts:   []string{contractAddr.String()},
+	})
+	require.NoError(t, err)
+	assert.False(t, wasmKeeper.IsCronContract(ctx, contractAddr))
+
+	// and an unknown contract can not be registered
+	err = submitAndExecute(t, ctx, &types.RegisterCronContractsProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contracts:   []string{RandomBech32AccountAddress(t)},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestPruneCodesProposal(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking")
+	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+
+	unused := StoreHackatomExampleContract(t, ctx, keepers)
+	used := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	submitAndExecute := func(ctx sdk.Context, codeIDs ...uint64) error {
+		storedProposal, err := govKeeper.SubmitProposal(ctx, &types.PruneCodesProposal{
+			Title:       "Foo",
+			Description: "Bar",
+			CodeIDs:     codeIDs,
+		})
+		if err != nil {
+			return err
+		}
+		handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
+		return handler(ctx, storedProposal.GetContent())
+	}
+
+	// when a code with contracts is included
+	cacheCtx, _ := ctx.CacheContext()
+	err := submitAndExecute(cacheCtx, unused.CodeID, used.CodeID)
+	// then
+	require.Error(t, err)
+
+	// when only unused codes
+	err = submitAndExecute(ctx, unused.CodeID)
+	// then
+	require.NoError(t, err)
+	assert.True(t, wasmKeeper.IsPrunedCode(ctx, unused.CodeID))
+	assert.False(t, wasmKeeper.IsPrunedCode(ctx, used.CodeID))
+	assert.NotNil(t, wasmKeeper.GetCodeInfo(ctx, unused.CodeID))
+}
+
+func TestContractCapabilitiesProposal(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking")
+	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+
+	contractAddr := InstantiateHackatomExampleContract(t, ctx, keepers).Contract
+
+	submitAndExecute := func(ctx sdk.Context, content govtypes.Content) error {
+		storedProposal, err := govKeeper.SubmitProposal(ctx, content)
+		if err != nil {
+			return err
+		}
+		handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
+		return handler(ctx, storedProposal.GetContent())
+	}
+
+	// when granted
+	err := submitAndExecute(ctx, &types.GrantContractCapabilitiesProposal{
+		Title:        "Foo",
+		Description:  "Bar",
+		Contract:     contractAddr.String(),
+		Capabilities: []string{"mint", "gov_hooks"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gov_hooks", "mint"}, wasmKeeper.GetContractCapabilities(ctx, contractAddr))
+
+	// when revoked
+	err = submitAndExecute(ctx, &types.RevokeContractCapabilitiesProposal{
+		Title:        "Foo",
+		Description:  "Bar",
+		Contract:     contractAddr.String(),
+		Capabilities: []string{"mint"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gov_hooks"}, wasmKeeper.GetContractCapabilities(ctx, contractAddr))
+
+	// and an unknown contract can not be granted capabilities
+	err = submitAndExecute(ctx, &types.GrantContractCapabilitiesProposal{
+		Title:        "Foo",
+		Description:  "Bar",
+		Contract:     RandomBech32AccountAddress(t),
+		Capabilities: []string{"mint"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestUpdateExecuteGasLimitProposal(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking")
+	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+
+	exampleContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+	proposal := types.UpdateExecuteGasLimitProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contract:    exampleContract.Contract.String(),
+		GasLimit:    100_000,
+	}
+
+	// when stored
+	storedProposal, err := govKeeper.SubmitProposal(ctx, &proposal)
+	require.NoError(t, err)
+
+	// and proposal execute
+	handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
+	err = handler(ctx, storedProposal.GetContent())
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, uint64(100_000), wasmKeeper.GetContractInfo(ctx, exampleContract.Contract).ExecuteGasLimit)
+}
@@ -24,6 +24,10 @@ type grpcQuerier struct {
 	storeKey      sdk.StoreKey
 	keeper        types.ViewKeeper
 	queryGasLimit sdk.Gas
+	// smartQueryDisabled rejects smart contract state queries
+	smartQueryDisabled bool
+	// rateLimiter limits the smart queries per client, optional
+	rateLimiter *queryRateLimiter
 }
 
 // NewGrpcQuerier constructor
@@ -165,7 +169,14 @@ func (q grpcQuerier) SmartContractState(c context.Context, req *types.QuerySmart
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
-	if err := req.QueryData.ValidateBasic(); err != nil {
+	if q.smartQueryDisabled {
+		return nil, status.Error(codes.Unavailable, "smart queries are disabled on this node")
+	}
+	// only direct gRPC clients can be identified, ABCI queries without a client ID are not limited
+	if clientID := queryClientID(c); q.rateLimiter != nil && clientID != "" && !q.rateLimiter.Allow(clientID) {
+		return nil, status.Error(codes.ResourceExhausted, "smart query rate limit exceeded")
+	}
+	if err := req.QueryData.ValidateQuery(); err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid query data")
 	}
 	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
@@ -242,6 +253,8 @@ func (q grpcQuerier) Codes(c context.Context, req *types.QueryCodesRequest) (*ty
 				CodeID:   binary.BigEndian.Uint64(key),
 				Creator:  c.Creator,
 				DataHash: c.CodeHash,
+				Source:   c.Source,
+				Builder:  c.Builder,
 			})
 		}
 		return true, nil
@@ -278,6 +291,8 @@ func queryCode(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) (*types.
 		CodeID:   codeID,
 		Creator:  res.Creator,
 		DataHash: res.CodeHash,
+		Source:   res.Source,
+		Builder:  res.Builder,
 	}
 
 	code, err := keeper.GetByteCode(ctx, codeID)
@@ -298,7 +313,6 @@ func (q grpcQuerier) PinnedCodes(c context.Context, req *types.QueryPinnedCodesR
 	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.PinnedCodeIndexPrefix)
 	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, _ []byte, accumulate bool) (bool, error) {
 		if accumulate {
-
 			r = append(r, sdk.BigEndianToUint64(key))
 		}
 		return true, nil
@@ -310,5 +324,131 @@ func (q grpcQuerier) PinnedCodes(c context.Context, req *types.QueryPinnedCodesR
 		CodeIDs:    r,
 		Pagination: pageRes,
 	}, nil
+}
+
+// Params returns params of the module.
+func (q grpcQuerier) Params(c context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	params := q.keeper.GetParams(ctx)
+	return &types.QueryParamsResponse{Params: params}, nil
+}
+
+// ContractsByCreator lists all smart contracts for a creator
+func (q grpcQuerier) ContractsByCreator(c context.Context, req *types.QueryContractsByCreatorRequest) (*types.QueryContractsByCreatorResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	contracts := make([]string, 0)
 
+	creatorAddress, err := sdk.AccAddressFromBech32(req.CreatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetContractsByCreatorPrefix(creatorAddress))
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			var contractAddr sdk.AccAddress = key[types.AbsoluteTxPositionLen:]
+			contracts = append(contracts, contractAddr.String())
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.QueryContractsByCreatorResponse{
+		ContractAddresses: contracts,
+		Pagination:        pageRes,
+	}, nil
+}
+
+// ContractsByAdmin lists all smart contracts for an admin
+func (q grpcQuerier) ContractsByAdmin(c context.Context, req *types.QueryContractsByAdminRequest) (*types.QueryContractsByAdminResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	contracts := make([]string, 0)
+
+	adminAddress, err := sdk.AccAddressFromBech32(req.AdminAddress)
+	if err != nil {
+		return nil, err
+	}
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetContractsByAdminPrefix(adminAddress))
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			var contractAddr sdk.AccAddress = key[types.AbsoluteTxPositionLen:]
+			contracts = append(contracts, contractAddr.String())
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.QueryContractsByAdminResponse{
+		ContractAddresses: contracts,
+		Pagination:        pageRes,
+	}, nil
+}
+
+// ContractStateSize returns the number of keys and bytes stored by a contract
+func (q grpcQuerier) ContractStateSize(c context.Context, req *types.QueryContractStateSizeRequest) (*types.QueryContractStateSizeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if !q.keeper.HasContractInfo(ctx, contractAddr) {
+		return nil, types.ErrNotFound
+	}
+	return &types.QueryContractStateSizeResponse{
+		StateSize: q.keeper.GetContractStateSize(ctx, contractAddr),
+	}, nil
+}
+
+// ContractRent returns the state rent account of a contract
+func (q grpcQuerier) ContractRent(c context.Context, req *types.QueryContractRentRequest) (*types.QueryContractRentResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if !q.keeper.HasContractInfo(ctx, contractAddr) {
+		return nil, types.ErrNotFound
+	}
+	return &types.QueryContractRentResponse{
+		Rent: q.keeper.GetContractRent(ctx, contractAddr),
+	}, nil
+}
+
+// VMCacheMetrics returns the cache statistics of the wasm VM of this node
+func (q grpcQuerier) VMCacheMetrics(c context.Context, req *types.QueryVMCacheMetricsRequest) (*types.QueryVMCacheMetricsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	m, err := q.keeper.GetVMCacheMetrics()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &types.QueryVMCacheMetricsResponse{
+		HitsPinnedMemoryCache:     m.HitsPinnedMemoryCache,
+		HitsMemoryCache:           m.HitsMemoryCache,
+		HitsFsCache:               m.HitsFsCache,
+		Misses:                    m.Misses,
+		ElementsPinnedMemoryCache: m.ElementsPinnedMemoryCache,
+		ElementsMemoryCache:       m.ElementsMemoryCache,
+		SizePinnedMemoryCache:     m.SizePinnedMemoryCache,
+		SizeMemoryCache:           m.SizeMemoryCache,
+	}, nil
 }
@@ -1,15 +1,19 @@
 package keeper
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"sync"
 	"testing"
 	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	cosmwasm "github.com/CosmWasm/wasmvm"
@@ -20,6 +24,7 @@ import (
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
@@ -156,6 +161,94 @@ func TestQuerySmartContractState(t *testing.T) {
 	}
 }
 
+func TestQuerySmartContractStateDisabled(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	keeper := keepers.WasmKeeper
+	exampleContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+	contractAddr := exampleContract.Contract.String()
+	keeper.smartQueryDisabled = true
+
+	// when
+	_, gotErr := Querier(keeper).SmartContractState(sdk.WrapSDKContext(ctx),
+		&types.QuerySmartContractStateRequest{Address: contractAddr, QueryData: []byte(`{"verifier":{}}`)})
+	// then
+	assert.Equal(t, codes.Unavailable, status.Code(gotErr))
+
+	// and legacy querier
+	q := LegacyQuerier(keeper)
+	_, gotErr = q(ctx, []string{QueryGetContractState, contractAddr, QueryMethodContractStateSmart}, abci.RequestQuery{Data: []byte(`{"verifier":{}}`)})
+	assert.ErrorIs(t, gotErr, sdkErrors.ErrInvalidRequest)
+
+	// raw queries still served
+	gotRaw, err := Querier(keeper).RawContractState(sdk.WrapSDKContext(ctx),
+		&types.QueryRawContractStateRequest{Address: contractAddr, QueryData: []byte("config")})
+	require.NoError(t, err)
+	assert.NotEmpty(t, gotRaw.Data)
+	_, err = q(ctx, []string{QueryGetContractState, contractAddr, QueryMethodContractStateRaw}, abci.RequestQuery{Data: []byte("config")})
+	require.NoError(t, err)
+}
+
+func TestQuerySmartContractStateRateLimited(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	keeper := keepers.WasmKeeper
+	exampleContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+	keeper.smartQueryRateLimit = 1
+	q := Querier(keeper)
+
+	req := &types.QuerySmartContractStateRequest{Address: exampleContract.Contract.String(), QueryData: []byte(`{"verifier":{}}`)}
+	grpcClientCtx := func(ip string) context.Context {
+		return peer.NewContext(sdk.WrapSDKContext(ctx), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234}})
+	}
+	_, err := q.SmartContractState(grpcClientCtx("192.0.2.1"), req)
+	require.NoError(t, err)
+	// when
+	_, gotErr := q.SmartContractState(grpcClientCtx("192.0.2.1"), req)
+	// then
+	assert.Equal(t, codes.ResourceExhausted, status.Code(gotErr))
+
+	// other clients are not affected
+	_, err = q.SmartContractState(grpcClientCtx("192.0.2.2"), req)
+	require.NoError(t, err)
+
+	// and ABCI queries without client ID are not limited
+	for i := 0; i < 2; i++ {
+		_, err = q.SmartContractState(sdk.WrapSDKContext(ctx), req)
+		require.NoError(t, err)
+	}
+}
+
+func TestQuerySmartContractStateConcurrently(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	exampleContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+	expResp := fmt.Sprintf(`{"verifier":"%s"}`, exampleContract.VerifierAddr.String())
+
+	q := Querier(keepers.WasmKeeper)
+	const parallelQueries = 20
+	var wg sync.WaitGroup
+	results := make([][]byte, parallelQueries)
+	errs := make([]error, parallelQueries)
+	for i := 0; i < parallelQueries; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// each query runs with its own gas meter set by the querier
+			rsp, err := q.SmartContractState(sdk.WrapSDKContext(ctx), &types.QuerySmartContractStateRequest{
+				Address:   exampleContract.Contract.String(),
+				QueryData: []byte(`{"verifier":{}}`),
+			})
+			errs[i] = err
+			if err == nil {
+				results[i] = rsp.Data
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i := 0; i < parallelQueries; i++ {
+		require.NoError(t, errs[i])
+		assert.JSONEq(t, expResp, string(results[i]))
+	}
+}
+
 func TestQuerySmartContractPanics(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	contractAddr := BuildContractAddress(1, 1)
@@ -310,6 +403,23 @@ func TestQueryContractListByCodeOrdering(t *testing.T) {
 	for _, contractAddr := range res.Contracts {
 		assert.NotEmpty(t, contractAddr)
 	}
+
+	// and walk through all pages with the next key cursor
+	var (
+		pageReq = &query.PageRequest{Limit: 3}
+		paged   []string
+	)
+	for {
+		page, err := q.ContractsByCode(sdk.WrapSDKContext(ctx), &types.QueryContractsByCodeRequest{CodeId: codeID, Pagination: pageReq})
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page.Contracts), 3)
+		paged = append(paged, page.Contracts...)
+		if len(page.Pagination.NextKey) == 0 {
+			break
+		}
+		pageReq = &query.PageRequest{Key: page.Pagination.NextKey, Limit: 3}
+	}
+	assert.Equal(t, res.Contracts, paged)
 }
 
 func TestQueryContractHistory(t *testing.T) {
@@ -420,6 +530,30 @@ func TestQueryContractHistory(t *testing.T) {
 				Msg:       []byte(`"init message"`),
 			}},
 		},
+		"with pagination next key": {
+			srcHistory: []types.ContractCodeHistoryEntry{{
+				Operation: types.ContractCodeHistoryOperationTypeInit,
+				CodeID:    firstCodeID,
+				Updated:   types.NewAbsoluteTxPosition(ctx),
+				Msg:       []byte(`"init message"`),
+			}, {
+				Operation: types.ContractCodeHistoryOperationTypeMigrate,
+				CodeID:    2,
+				Updated:   types.NewAbsoluteTxPosition(ctx),
+				Msg:       []byte(`"migrate message 1"`),
+			}},
+			req: types.QueryContractHistoryRequest{
+				Address: myContractBech32Addr,
+				Pagination: &query.PageRequest{
+					Key: fromBase64("AAAAAAAAAAI="),
+				},
+			},
+			expContent: []types.ContractCodeHistoryEntry{{
+				Operation: types.ContractCodeHistoryOperationTypeMigrate,
+				CodeID:    2,
+				Msg:       []byte(`"migrate message 1"`),
+			}},
+		},
 		"unknown contract address": {
 			req: types.QueryContractHistoryRequest{Address: otherBech32Addr},
 			srcHistory: []types.ContractCodeHistoryEntry{{
@@ -528,6 +662,32 @@ func TestQueryCodeList(t *testing.T) {
 	}
 }
 
+func TestQueryCodeSource(t *testing.T) {
+	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	codeInfo := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode), func(info *types.CodeInfo) {
+		info.Source = "https://github.com/CosmWasm/cosmwasm/tree/v1.0.0/contracts/hackatom"
+		info.Builder = "cosmwasm/rust-optimizer:0.12.6"
+	})
+	require.NoError(t, keepers.WasmKeeper.importCode(ctx, 1, codeInfo, wasmCode))
+	q := Querier(keepers.WasmKeeper)
+
+	// when
+	gotCode, err := q.Code(sdk.WrapSDKContext(ctx), &types.QueryCodeRequest{CodeId: 1})
+	require.NoError(t, err)
+	gotCodes, err := q.Codes(sdk.WrapSDKContext(ctx), &types.QueryCodesRequest{})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, codeInfo.Source, gotCode.Source)
+	assert.Equal(t, codeInfo.Builder, gotCode.Builder)
+	require.Len(t, gotCodes.CodeInfos, 1)
+	assert.Equal(t, codeInfo.Source, gotCodes.CodeInfos[0].Source)
+	assert.Equal(t, codeInfo.Builder, gotCodes.CodeInfos[0].Builder)
+}
+
 func TestQueryContractInfo(t *testing.T) {
 	var (
 		contractAddr = RandomAccountAddress(t)
@@ -662,3 +822,334 @@ func fromBase64(s string) []byte {
 	}
 	return r
 }
+
+func TestQueryParams(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	keeper := keepers.WasmKeeper
+
+	q := Querier(keeper)
+
+	paramsResponse, err := q.Params(sdk.WrapSDKContext(ctx), &types.QueryParamsRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, paramsResponse)
+
+	defaultParams := types.DefaultParams()
+
+	require.Equal(t, defaultParams.CodeUploadAccess, paramsResponse.Params.CodeUploadAccess)
+	require.Equal(t, defaultParams.InstantiateDefaultPermission, paramsResponse.Params.InstantiateDefaultPermission)
+
+	keeper.SetParams(ctx, types.Params{
+		CodeUploadAccess:             types.AllowNobody,
+		InstantiateDefaultPermission: types.AccessTypeNobody,
+		MaxContractMsgSize:           types.DefaultMaxContractMsgSize,
+		MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
+	})
+
+	paramsResponse, err = q.Params(sdk.WrapSDKContext(ctx), &types.QueryParamsRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, paramsResponse)
+
+	require.Equal(t, types.AllowNobody, paramsResponse.Params.CodeUploadAccess)
+	require.Equal(t, types.AccessTypeNobody, paramsResponse.Params.InstantiateDefaultPermission)
+
+	_, err = q.Params(sdk.WrapSDKContext(ctx), nil)
+	require.Error(t, err)
+}
+
+func TestQueryContractsByCreatorList(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 1000000))
+	topUp := sdk.NewCoins(sdk.NewInt64Coin("denom", 500))
+	creator := keepers.Faucet.NewFundedAccount(ctx, deposit...)
+	anyAddr := keepers.Faucet.NewFundedAccount(ctx, topUp...)
+
+	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	codeID, err := keepers.ContractKeeper.Create(ctx, creator, wasmCode, nil)
+	require.NoError(t, err)
+
+	_, _, bob := keyPubAddr()
+	initMsg := HackatomExampleInitMsg{
+		Verifier:    anyAddr,
+		Beneficiary: bob,
+	}
+	initMsgBz, err := json.Marshal(initMsg)
+	require.NoError(t, err)
+
+	// manage some realistic block settings
+	var h int64 = 10
+	setBlock := func(ctx sdk.Context, height int64) sdk.Context {
+		ctx = ctx.WithBlockHeight(height)
+		meter := sdk.NewGasMeter(1000000)
+		ctx = ctx.WithGasMeter(meter)
+		ctx = ctx.WithBlockGasMeter(meter)
+		return ctx
+	}
+
+	var allExpectedContracts []string
+	// create 10 contracts with real block/gas setup
+	for i := 0; i < 10; i++ {
+		ctx = setBlock(ctx, h)
+		h++
+		contract, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, initMsgBz, fmt.Sprintf("contract %d", i), topUp)
+		allExpectedContracts = append(allExpectedContracts, contract.String())
+		require.NoError(t, err)
+	}
+	// and one by an other creator
+	_, _, err = keepers.ContractKeeper.Instantiate(ctx, codeID, anyAddr, nil, initMsgBz, "other", nil)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		srcQuery        *types.QueryContractsByCreatorRequest
+		expContractAddr []string
+		expErr          error
+	}{
+		"query all": {
+			srcQuery: &types.QueryContractsByCreatorRequest{
+				CreatorAddress: creator.String(),
+			},
+			expContractAddr: allExpectedContracts,
+		},
+		"with pagination offset": {
+			srcQuery: &types.QueryContractsByCreatorRequest{
+				CreatorAddress: creator.String(),
+				Pagination: &query.PageRequest{
+					Offset: 5,
+				},
+			},
+			expContractAddr: allExpectedContracts[5:],
+		},
+		"with pagination limit": {
+			srcQuery: &types.QueryContractsByCreatorRequest{
+				CreatorAddress: creator.String(),
+				Pagination: &query.PageRequest{
+					Limit: 4,
+				},
+			},
+			expContractAddr: allExpectedContracts[0:4],
+		},
+		"nil creator": {
+			srcQuery: &types.QueryContractsByCreatorRequest{
+				Pagination: &query.PageRequest{},
+			},
+			expErr: errors.New("empty address string is not allowed"),
+		},
+		"unknown creator": {
+			srcQuery: &types.QueryContractsByCreatorRequest{
+				CreatorAddress: RandomBech32AccountAddress(t),
+			},
+			expContractAddr: []string{},
+		},
+	}
+
+	q := Querier(keepers.WasmKeeper)
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, err := q.ContractsByCreator(sdk.WrapSDKContext(ctx), spec.srcQuery)
+			if spec.expErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, spec.expErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, got)
+			assert.Equal(t, spec.expContractAddr, got.ContractAddresses)
+		})
+	}
+}
+
+func TestQueryContractsByAdminList(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 1000000))
+	creator := keepers.Faucet.NewFundedAccount(ctx, deposit...)
+	admin := keepers.Faucet.NewFundedAccount(ctx, deposit...)
+	newAdmin := RandomAccountAddress(t)
+
+	codeID := StoreHackatomExampleContract(t, ctx, keepers).CodeID
+	initMsgBz := HackatomExampleInitMsg{Verifier: creator, Beneficiary: creator}.GetBytes(t)
+
+	var (
+		contractAddrs        []sdk.AccAddress
+		allExpectedContracts []string
+	)
+	for i := 0; i < 4; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		contract, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, admin, initMsgBz, fmt.Sprintf("contract %d", i), nil)
+		require.NoError(t, err)
+		contractAddrs = append(contractAddrs, contract)
+		allExpectedContracts = append(allExpectedContracts, contract.String())
+	}
+	// and one without admin
+	_, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, initMsgBz, "no admin", nil)
+	require.NoError(t, err)
+
+	// move the first contract to a new admin and clear the admin of the second
+	require.NoError(t, keepers.ContractKeeper.UpdateContractAdmin(ctx, contractAddrs[0], admin, newAdmin))
+	require.NoError(t, keepers.ContractKeeper.ClearContractAdmin(ctx, contractAddrs[1], admin))
+
+	specs := map[string]struct {
+		srcQuery        *types.QueryContractsByAdminRequest
+		expContractAddr []string
+		expErr          error
+	}{
+		"query all": {
+			srcQuery: &types.QueryContractsByAdminRequest{
+				AdminAddress: admin.String(),
+			},
+			expContractAddr: allExpectedContracts[2:],
+		},
+		"with pagination offset": {
+			srcQuery: &types.QueryContractsByAdminRequest{
+				AdminAddress: admin.String(),
+				Pagination: &query.PageRequest{
+					Offset: 1,
+				},
+			},
+			expContractAddr: allExpectedContracts[3:],
+		},
+		"with pagination limit": {
+			srcQuery: &types.QueryContractsByAdminRequest{
+				AdminAddress: admin.String(),
+				Pagination: &query.PageRequest{
+					Limit: 1,
+				},
+			},
+			expContractAddr: allExpectedContracts[2:3],
+		},
+		"updated admin": {
+			srcQuery: &types.QueryContractsByAdminRequest{
+				AdminAddress: newAdmin.String(),
+			},
+			expContractAddr: allExpectedContracts[0:1],
+		},
+		"empty admin": {
+			srcQuery: &types.QueryContractsByAdminRequest{},
+			expErr:   errors.New("empty address string is not allowed"),
+		},
+		"unknown admin": {
+			srcQuery: &types.QueryContractsByAdminRequest{
+				AdminAddress: RandomBech32AccountAddress(t),
+			},
+			expContractAddr: []string{},
+		},
+	}
+
+	q := Querier(keepers.WasmKeeper)
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, err := q.ContractsByAdmin(sdk.WrapSDKContext(ctx), spec.srcQuery)
+			if spec.expErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, spec.expErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, got)
+			assert.Equal(t, spec.expContractAddr, got.ContractAddresses)
+		})
+	}
+}
+
+func TestQueryContractStateSize(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	keeper := keepers.WasmKeeper
+
+	exampleContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+	contractAddr := exampleContract.Contract
+	expSize := keeper.GetContractStateSize(ctx, contractAddr)
+	contractModel := []types.Model{
+		{Key: []byte("foo"), Value: []byte(`"bar"`)},
+		{Key: []byte{0x0, 0x1}, Value: []byte(`{"count":8}`)},
+	}
+	require.NoError(t, keeper.importContractState(ctx, contractAddr, contractModel))
+	expSize.Keys += 2
+	expSize.Bytes += 3 + 5 + 2 + 11
+
+	q := Querier(keeper)
+	specs := map[string]struct {
+		srcQuery *types.QueryContractStateSizeRequest
+		expSize  types.ContractStateSize
+		expErr   error
+	}{
+		"query existing contract": {
+			srcQuery: &types.QueryContractStateSizeRequest{Address: contractAddr.String()},
+			expSize:  expSize,
+		},
+		"query unknown contract": {
+			srcQuery: &types.QueryContractStateSizeRequest{Address: RandomBech32AccountAddress(t)},
+			expErr:   types.ErrNotFound,
+		},
+		"query with invalid address": {
+			srcQuery: &types.QueryContractStateSizeRequest{Address: "invalid"},
+			expErr:   errors.New("decoding bech32 failed: invalid bech32 string length 7"),
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, err := q.ContractStateSize(sdk.WrapSDKContext(ctx), spec.srcQuery)
+			if spec.expErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, spec.expErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expSize, got.StateSize)
+		})
+	}
+}
+
+func TestQueryVMCacheMetrics(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	specs := map[string]struct {
+		src    func() (*wasmvmtypes.Metrics, error)
+		exp    *types.QueryVMCacheMetricsResponse
+		expErr bool
+	}{
+		"all values": {
+			src: func() (*wasmvmtypes.Metrics, error) {
+				return &wasmvmtypes.Metrics{
+					HitsPinnedMemoryCache:     1,
+					HitsMemoryCache:           2,
+					HitsFsCache:               3,
+					Misses:                    4,
+					ElementsPinnedMemoryCache: 5,
+					ElementsMemoryCache:       6,
+					SizePinnedMemoryCache:     7,
+					SizeMemoryCache:           8,
+				}, nil
+			},
+			exp: &types.QueryVMCacheMetricsResponse{
+				HitsPinnedMemoryCache:     1,
+				HitsMemoryCache:           2,
+				HitsFsCache:               3,
+				Misses:                    4,
+				ElementsPinnedMemoryCache: 5,
+				ElementsMemoryCache:       6,
+				SizePinnedMemoryCache:     7,
+				SizeMemoryCache:           8,
+			},
+		},
+		"vm error": {
+			src: func() (*wasmvmtypes.Metrics, error) {
+				return nil, errors.New("testing")
+			},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			keepers.WasmKeeper.wasmVM = &wasmtesting.MockWasmer{GetMetricsFn: spec.src}
+			q := Querier(keepers.WasmKeeper)
+			got, err := q.VMCacheMetrics(sdk.WrapSDKContext(ctx), &types.QueryVMCacheMetricsRequest{})
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
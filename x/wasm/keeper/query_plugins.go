@@ -467,7 +467,7 @@ func WasmQuerier(k wasmQueryKeeper) func(ctx sdk.Context, request *wasmvmtypes.W
 				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, request.Smart.ContractAddr)
 			}
 			msg := types.RawContractMessage(request.Smart.Msg)
-			if err := msg.ValidateBasic(); err != nil {
+			if err := msg.ValidateQuery(); err != nil {
 				return nil, sdkerrors.Wrap(err, "json msg")
 			}
 			return k.QuerySmart(ctx, addr, msg)
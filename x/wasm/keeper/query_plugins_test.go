@@ -344,6 +344,51 @@ func TestBankQuerierBalance(t *testing.T) {
 	assert.Equal(t, exp, got)
 }
 
+func TestSmartWasmQuerier(t *testing.T) {
+	myValidContractAddr := RandomBech32AccountAddress(t)
+	specs := map[string]struct {
+		srcAddr string
+		srcMsg  string
+		expErr  bool
+	}{
+		"object": {
+			srcAddr: myValidContractAddr,
+			srcMsg:  `{"config":{}}`,
+		},
+		"unit variant": {
+			srcAddr: myValidContractAddr,
+			srcMsg:  `"config"`,
+		},
+		"invalid json": {
+			srcAddr: myValidContractAddr,
+			srcMsg:  `not json`,
+			expErr:  true,
+		},
+		"invalid addr": {
+			srcAddr: "not a valid addr",
+			srcMsg:  `{"config":{}}`,
+			expErr:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := mockWasmQueryKeeper{QuerySmartFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, req types.RawContractMessage) ([]byte, error) {
+				return []byte(`{"ok":true}`), nil
+			}}
+			q := WasmQuerier(mock)
+			gotBz, gotErr := q(sdk.Context{}, &wasmvmtypes.WasmQuery{
+				Smart: &wasmvmtypes.SmartQuery{ContractAddr: spec.srcAddr, Msg: []byte(spec.srcMsg)},
+			})
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, `{"ok":true}`, string(gotBz))
+		})
+	}
+}
+
 func TestContractInfoWasmQuerier(t *testing.T) {
 	var myValidContractAddr = RandomBech32AccountAddress(t)
 	var myCreatorAddr = RandomBech32AccountAddress(t)
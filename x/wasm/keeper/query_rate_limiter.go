@@ -0,0 +1,90 @@
+package keeper
+
+import (
+	"context"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/peer"
+)
+
+// maxRateLimitedClients is the number of client buckets that are kept before idle clients are pruned
+const maxRateLimitedClients = 10_000
+
+// queryRateLimiter is a token bucket rate limiter with a bucket per client. The buckets hold up to
+// burst tokens and are refilled with limit tokens per second.
+type queryRateLimiter struct {
+	mu      sync.Mutex
+	limit   float64
+	burst   float64
+	clients map[string]*tokenBucket
+	now     func() time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newQueryRateLimiter constructor. The limit is the number of queries per second and client.
+func newQueryRateLimiter(limit uint32) *queryRateLimiter {
+	return &queryRateLimiter{
+		limit:   float64(limit),
+		burst:   float64(limit),
+		clients: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Allow consumes a token from the bucket of the client and returns false when the bucket is empty.
+func (l *queryRateLimiter) Allow(client string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.clients[client]
+	if !ok {
+		if len(l.clients) >= maxRateLimitedClients {
+			l.prune(now)
+		}
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.clients[client] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (l *queryRateLimiter) refill(b *tokenBucket, now time.Time) float64 {
+	return math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.limit)
+}
+
+// prune removes the buckets that are full again so that they can not grow unbounded
+func (l *queryRateLimiter) prune(now time.Time) {
+	for k, b := range l.clients {
+		if l.refill(b, now) >= l.burst {
+			delete(l.clients, k)
+		}
+	}
+}
+
+// queryClientID returns the IP of the gRPC peer. Queries that do not come from a gRPC client of this node have
+// no peer and an empty client ID. This includes the queries of the REST gateway, the legacy REST routes and the
+// Tendermint RPC, as these are all executed as ABCI queries in the SDK.
+func queryClientID(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
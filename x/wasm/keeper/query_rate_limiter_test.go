@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
+)
+
+func TestQueryRateLimiter(t *testing.T) {
+	now := time.Now()
+	l := newQueryRateLimiter(2)
+	l.now = func() time.Time { return now }
+
+	// burst up to the limit
+	assert.True(t, l.Allow("a"))
+	assert.True(t, l.Allow("a"))
+	assert.False(t, l.Allow("a"))
+	// other clients have their own bucket
+	assert.True(t, l.Allow("b"))
+
+	// refilled over time
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, l.Allow("a"))
+	assert.False(t, l.Allow("a"))
+
+	// not more than burst
+	now = now.Add(time.Hour)
+	assert.True(t, l.Allow("a"))
+	assert.True(t, l.Allow("a"))
+	assert.False(t, l.Allow("a"))
+}
+
+func TestQueryRateLimiterPrunesIdleClients(t *testing.T) {
+	now := time.Now()
+	l := newQueryRateLimiter(1)
+	l.now = func() time.Time { return now }
+	for i := 0; i < maxRateLimitedClients; i++ {
+		l.clients[string(rune(i))] = &tokenBucket{tokens: 0, last: now}
+	}
+	now = now.Add(time.Second)
+
+	// when
+	assert.True(t, l.Allow("new"))
+
+	// then
+	assert.Len(t, l.clients, 1)
+}
+
+func TestQueryClientID(t *testing.T) {
+	remote := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}
+	local := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1234}
+	specs := map[string]struct {
+		src context.Context
+		exp string
+	}{
+		"no peer": {
+			src: context.Background(),
+			exp: "",
+		},
+		"remote peer": {
+			src: peer.NewContext(context.Background(), &peer.Peer{Addr: remote}),
+			exp: "192.0.2.1",
+		},
+		"local peer": {
+			src: peer.NewContext(context.Background(), &peer.Peer{Addr: local}),
+			exp: "127.0.0.1",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, queryClientID(spec.src))
+		})
+	}
+}
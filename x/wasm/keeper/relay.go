@@ -25,19 +25,25 @@ func (k Keeper) OnOpenChannel(
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-open-channel")
 
-	_, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	contractInfo, codeInfo, prefixStore, err := k.activeContractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
 	}
 
 	env := types.NewEnv(ctx, contractAddr)
+
+	ctx, span := k.startContractSpan(ctx, "ibc-open-channel", contractInfo.CodeID, contractAddr, nil)
+	defer span.End()
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
-	gasUsed, execErr := k.wasmVM.IBCChannelOpen(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
+	start := time.Now()
+	gasUsed, execErr := k.wasmVM.IBCChannelOpen(codeInfo.CodeHash, env, msg, prefixStore, k.cosmwasmAPI(), querier, ctx.GasMeter(), gas, k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "ibc-open-channel", contractInfo.CodeID, contractAddr, start, gasUsed, execErr)
+	k.traceVMCall(span, gasUsed, execErr)
 	if execErr != nil {
-		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return types.NewErrContractFailure(contractAddr, execErr, types.ErrExecuteFailed)
 	}
 
 	return nil
@@ -56,19 +62,25 @@ func (k Keeper) OnConnectChannel(
 	msg wasmvmtypes.IBCChannelConnectMsg,
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-connect-channel")
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	contractInfo, codeInfo, prefixStore, err := k.activeContractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
 	}
 
 	env := types.NewEnv(ctx, contractAddr)
+
+	ctx, span := k.startContractSpan(ctx, "ibc-connect-channel", contractInfo.CodeID, contractAddr, nil)
+	defer span.End()
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCChannelConnect(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
+	start := time.Now()
+	res, gasUsed, execErr := k.wasmVM.IBCChannelConnect(codeInfo.CodeHash, env, msg, prefixStore, k.cosmwasmAPI(), querier, ctx.GasMeter(), gas, k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "ibc-connect-channel", contractInfo.CodeID, contractAddr, start, gasUsed, execErr)
+	k.traceVMCall(span, gasUsed, execErr)
 	if execErr != nil {
-		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return types.NewErrContractFailure(contractAddr, execErr, types.ErrExecuteFailed)
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
@@ -87,19 +99,25 @@ func (k Keeper) OnCloseChannel(
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-close-channel")
 
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	contractInfo, codeInfo, prefixStore, err := k.activeContractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
 	}
 
 	params := types.NewEnv(ctx, contractAddr)
+
+	ctx, span := k.startContractSpan(ctx, "ibc-close-channel", contractInfo.CodeID, contractAddr, nil)
+	defer span.End()
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCChannelClose(codeInfo.CodeHash, params, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
+	start := time.Now()
+	res, gasUsed, execErr := k.wasmVM.IBCChannelClose(codeInfo.CodeHash, params, msg, prefixStore, k.cosmwasmAPI(), querier, ctx.GasMeter(), gas, k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "ibc-close-channel", contractInfo.CodeID, contractAddr, start, gasUsed, execErr)
+	k.traceVMCall(span, gasUsed, execErr)
 	if execErr != nil {
-		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return types.NewErrContractFailure(contractAddr, execErr, types.ErrExecuteFailed)
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
@@ -117,19 +135,25 @@ func (k Keeper) OnRecvPacket(
 	msg wasmvmtypes.IBCPacketReceiveMsg,
 ) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-recv-packet")
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	contractInfo, codeInfo, prefixStore, err := k.activeContractInstance(ctx, contractAddr)
 	if err != nil {
 		return nil, err
 	}
 
 	env := types.NewEnv(ctx, contractAddr)
+
+	ctx, span := k.startContractSpan(ctx, "ibc-recv-packet", contractInfo.CodeID, contractAddr, nil)
+	defer span.End()
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCPacketReceive(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
+	start := time.Now()
+	res, gasUsed, execErr := k.wasmVM.IBCPacketReceive(codeInfo.CodeHash, env, msg, prefixStore, k.cosmwasmAPI(), querier, ctx.GasMeter(), gas, k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "ibc-recv-packet", contractInfo.CodeID, contractAddr, start, gasUsed, execErr)
+	k.traceVMCall(span, gasUsed, execErr)
 	if execErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, types.NewErrContractFailure(contractAddr, execErr, types.ErrExecuteFailed)
 	}
 	if res.Err != "" { // handle error case as before https://github.com/CosmWasm/wasmvm/commit/c300106fe5c9426a495f8e10821e00a9330c56c6
 		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, res.Err)
@@ -151,19 +175,25 @@ func (k Keeper) OnAckPacket(
 	msg wasmvmtypes.IBCPacketAckMsg,
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-ack-packet")
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	contractInfo, codeInfo, prefixStore, err := k.activeContractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
 	}
 
 	env := types.NewEnv(ctx, contractAddr)
+
+	ctx, span := k.startContractSpan(ctx, "ibc-ack-packet", contractInfo.CodeID, contractAddr, nil)
+	defer span.End()
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCPacketAck(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
+	start := time.Now()
+	res, gasUsed, execErr := k.wasmVM.IBCPacketAck(codeInfo.CodeHash, env, msg, prefixStore, k.cosmwasmAPI(), querier, ctx.GasMeter(), gas, k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "ibc-ack-packet", contractInfo.CodeID, contractAddr, start, gasUsed, execErr)
+	k.traceVMCall(span, gasUsed, execErr)
 	if execErr != nil {
-		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return types.NewErrContractFailure(contractAddr, execErr, types.ErrExecuteFailed)
 	}
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
 }
@@ -178,19 +208,25 @@ func (k Keeper) OnTimeoutPacket(
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-timeout-packet")
 
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	contractInfo, codeInfo, prefixStore, err := k.activeContractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
 	}
 
 	env := types.NewEnv(ctx, contractAddr)
+
+	ctx, span := k.startContractSpan(ctx, "ibc-timeout-packet", contractInfo.CodeID, contractAddr, nil)
+	defer span.End()
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCPacketTimeout(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
+	start := time.Now()
+	res, gasUsed, execErr := k.wasmVM.IBCPacketTimeout(codeInfo.CodeHash, env, msg, prefixStore, k.cosmwasmAPI(), querier, ctx.GasMeter(), gas, k.jsonDeserializationCosts())
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.observeVMCall(ctx, "ibc-timeout-packet", contractInfo.CodeID, contractAddr, start, gasUsed, execErr)
+	k.traceVMCall(span, gasUsed, execErr)
 	if execErr != nil {
-		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return types.NewErrContractFailure(contractAddr, execErr, types.ErrExecuteFailed)
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
@@ -81,7 +81,7 @@ func TestOnOpenChannel(t *testing.T) {
 			}
 			require.NoError(t, err)
 			// verify gas consumed
-			const storageCosts = sdk.Gas(2903)
+			const storageCosts = sdk.Gas(3930)
 			assert.Equal(t, spec.expGas, ctx.GasMeter().GasConsumed()-before-storageCosts)
 		})
 	}
@@ -187,7 +187,7 @@ func TestOnConnectChannel(t *testing.T) {
 			}
 			require.NoError(t, err)
 			// verify gas consumed
-			const storageCosts = sdk.Gas(2903)
+			const storageCosts = sdk.Gas(3930)
 			assert.Equal(t, spec.expContractGas, ctx.GasMeter().GasConsumed()-before-storageCosts)
 			// verify msgs dispatched
 			require.Len(t, *capturedMsgs, len(spec.contractResp.Messages))
@@ -298,7 +298,7 @@ func TestOnCloseChannel(t *testing.T) {
 			}
 			require.NoError(t, err)
 			// verify gas consumed
-			const storageCosts = sdk.Gas(2903)
+			const storageCosts = sdk.Gas(3930)
 			assert.Equal(t, spec.expContractGas, ctx.GasMeter().GasConsumed()-before-storageCosts)
 			// verify msgs dispatched
 			require.Len(t, *capturedMsgs, len(spec.contractResp.Messages))
@@ -318,7 +318,11 @@ func TestOnRecvPacket(t *testing.T) {
 	parentCtx, keepers := CreateTestInput(t, false, SupportedFeatures, WithMessageHandler(messenger))
 	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
 	const myContractGas = 40
-	const storageCosts = sdk.Gas(2903)
+	const storageCosts = sdk.Gas(3930)
+	// gas for reading the max response data size param when the contract returns data
+	const responseDataCheckCosts = sdk.Gas(1117)
+	// gas for checking the inactive flag on the contract entry point, not charged for the reply
+	const inactiveContractCheckCosts = sdk.Gas(1000)
 
 	specs := map[string]struct {
 		contractAddr       sdk.AccAddress
@@ -333,7 +337,7 @@ func TestOnRecvPacket(t *testing.T) {
 	}{
 		"consume contract gas": {
 			contractAddr:   example.Contract,
-			expContractGas: myContractGas,
+			expContractGas: myContractGas + responseDataCheckCosts,
 			contractResp: &wasmvmtypes.IBCReceiveResponse{
 				Acknowledgement: []byte("myAck"),
 			},
@@ -357,7 +361,7 @@ func TestOnRecvPacket(t *testing.T) {
 		},
 		"dispatch contract messages on success": {
 			contractAddr:   example.Contract,
-			expContractGas: myContractGas,
+			expContractGas: myContractGas + responseDataCheckCosts,
 			contractResp: &wasmvmtypes.IBCReceiveResponse{
 				Acknowledgement: []byte("myAck"),
 				Messages:        []wasmvmtypes.SubMsg{{ReplyOn: wasmvmtypes.ReplyNever, Msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{}}}, {ReplyOn: wasmvmtypes.ReplyNever, Msg: wasmvmtypes.CosmosMsg{Custom: json.RawMessage(`{"foo":"bar"}`)}}},
@@ -366,7 +370,7 @@ func TestOnRecvPacket(t *testing.T) {
 		},
 		"emit contract attributes on success": {
 			contractAddr:   example.Contract,
-			expContractGas: myContractGas + 10,
+			expContractGas: myContractGas + responseDataCheckCosts + 10,
 			contractResp: &wasmvmtypes.IBCReceiveResponse{
 				Acknowledgement: []byte("myAck"),
 				Attributes:      []wasmvmtypes.EventAttribute{{Key: "Foo", Value: "Bar"}},
@@ -376,7 +380,7 @@ func TestOnRecvPacket(t *testing.T) {
 		},
 		"emit contract events on success": {
 			contractAddr:   example.Contract,
-			expContractGas: myContractGas + 46, // charge or custom event as well
+			expContractGas: myContractGas + responseDataCheckCosts + 46, // charge or custom event as well
 			contractResp: &wasmvmtypes.IBCReceiveResponse{
 				Acknowledgement: []byte("myAck"),
 				Attributes:      []wasmvmtypes.EventAttribute{{Key: "Foo", Value: "Bar"}},
@@ -405,7 +409,7 @@ func TestOnRecvPacket(t *testing.T) {
 		},
 		"submessage reply can overwrite ack data": {
 			contractAddr:   example.Contract,
-			expContractGas: myContractGas + storageCosts,
+			expContractGas: myContractGas + storageCosts - inactiveContractCheckCosts + 2*responseDataCheckCosts,
 			contractResp: &wasmvmtypes.IBCReceiveResponse{
 				Acknowledgement: []byte("myAck"),
 				Messages:        []wasmvmtypes.SubMsg{{ReplyOn: wasmvmtypes.ReplyAlways, Msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{}}}},
@@ -461,7 +465,7 @@ func TestOnRecvPacket(t *testing.T) {
 			require.Equal(t, spec.expAck, gotAck)
 
 			// verify gas consumed
-			const storageCosts = sdk.Gas(2903)
+			const storageCosts = sdk.Gas(3930)
 			assert.Equal(t, spec.expContractGas, ctx.GasMeter().GasConsumed()-before-storageCosts)
 			// verify msgs dispatched
 			require.Len(t, *capturedMsgs, len(spec.contractResp.Messages))
@@ -568,7 +572,7 @@ func TestOnAckPacket(t *testing.T) {
 			}
 			require.NoError(t, err)
 			// verify gas consumed
-			const storageCosts = sdk.Gas(2903)
+			const storageCosts = sdk.Gas(3930)
 			assert.Equal(t, spec.expContractGas, ctx.GasMeter().GasConsumed()-before-storageCosts)
 			// verify msgs dispatched
 			require.Len(t, *capturedMsgs, len(spec.contractResp.Messages))
@@ -689,7 +693,7 @@ func TestOnTimeoutPacket(t *testing.T) {
 			}
 			require.NoError(t, err)
 			// verify gas consumed
-			const storageCosts = sdk.Gas(2903)
+			const storageCosts = sdk.Gas(3930)
 			assert.Equal(t, spec.expContractGas, ctx.GasMeter().GasConsumed()-before-storageCosts)
 			// verify msgs dispatched
 			require.Len(t, *capturedMsgs, len(spec.contractResp.Messages))
@@ -701,6 +705,31 @@ func TestOnTimeoutPacket(t *testing.T) {
 	}
 }
 
+func TestIBCCallsToInactiveContract(t *testing.T) {
+	SkipIfM1(t)
+	var m wasmtesting.MockWasmer
+	wasmtesting.MakeIBCInstantiable(&m)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := SeedNewContractInstance(t, ctx, keepers, &m)
+	require.NoError(t, keepers.ContractKeeper.DeactivateContract(ctx, example.Contract))
+
+	// the mock wasmer panics when the contract is called
+	myChannel := wasmvmtypes.IBCChannel{Version: "my test channel"}
+	err := keepers.WasmKeeper.OnOpenChannel(ctx, example.Contract, wasmvmtypes.IBCChannelOpenMsg{OpenInit: &wasmvmtypes.IBCOpenInit{Channel: myChannel}})
+	assert.True(t, types.ErrInactiveContract.Is(err), "got %+v", err)
+	err = keepers.WasmKeeper.OnConnectChannel(ctx, example.Contract, wasmvmtypes.IBCChannelConnectMsg{OpenAck: &wasmvmtypes.IBCOpenAck{Channel: myChannel}})
+	assert.True(t, types.ErrInactiveContract.Is(err), "got %+v", err)
+	err = keepers.WasmKeeper.OnCloseChannel(ctx, example.Contract, wasmvmtypes.IBCChannelCloseMsg{CloseInit: &wasmvmtypes.IBCCloseInit{Channel: myChannel}})
+	assert.True(t, types.ErrInactiveContract.Is(err), "got %+v", err)
+	myPacket := wasmvmtypes.IBCPacket{Data: []byte("my data")}
+	_, err = keepers.WasmKeeper.OnRecvPacket(ctx, example.Contract, wasmvmtypes.IBCPacketReceiveMsg{Packet: myPacket})
+	assert.True(t, types.ErrInactiveContract.Is(err), "got %+v", err)
+	err = keepers.WasmKeeper.OnAckPacket(ctx, example.Contract, wasmvmtypes.IBCPacketAckMsg{OriginalPacket: myPacket})
+	assert.True(t, types.ErrInactiveContract.Is(err), "got %+v", err)
+	err = keepers.WasmKeeper.OnTimeoutPacket(ctx, example.Contract, wasmvmtypes.IBCPacketTimeoutMsg{Packet: myPacket})
+	assert.True(t, types.ErrInactiveContract.Is(err), "got %+v", err)
+}
+
 func stripTypes(events sdk.Events) []string {
 	var r []string
 	for _, e := range events {
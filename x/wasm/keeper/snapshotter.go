@@ -0,0 +1,173 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"io"
+
+	snapshot "github.com/cosmos/cosmos-sdk/snapshots/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	protoio "github.com/gogo/protobuf/io"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+var _ snapshot.ExtensionSnapshotter = &WasmSnapshotter{}
+
+// SnapshotFormat format 1 is just gzipped wasm byte code for each item payload. No protobuf envelope, no metadata.
+const SnapshotFormat = 1
+
+// WasmSnapshotter adds the wasm byte code to state-sync snapshots. The byte code is stored by the VM
+// outside of the multistore and would be missing on a node that was started from a snapshot otherwise.
+type WasmSnapshotter struct {
+	wasm *Keeper
+	cms  sdk.MultiStore
+}
+
+// NewWasmSnapshotter constructor
+func NewWasmSnapshotter(cms sdk.MultiStore, wasm *Keeper) *WasmSnapshotter {
+	return &WasmSnapshotter{
+		wasm: wasm,
+		cms:  cms,
+	}
+}
+
+// SnapshotName returns the name of the snapshot extension
+func (ws *WasmSnapshotter) SnapshotName() string {
+	return types.ModuleName
+}
+
+// SnapshotFormat returns the format used to write new snapshots
+func (ws *WasmSnapshotter) SnapshotFormat() uint32 {
+	return SnapshotFormat
+}
+
+// SupportedFormats returns all formats that can be restored
+func (ws *WasmSnapshotter) SupportedFormats() []uint32 {
+	// If we support older formats, add them here and handle them in Restore
+	return []uint32{SnapshotFormat}
+}
+
+// Snapshot writes the gzipped byte code of all codes stored at the given height. Codes with the same
+// checksum are written only once. Pruned codes have no byte code and are skipped.
+func (ws *WasmSnapshotter) Snapshot(height uint64, protoWriter protoio.Writer) error {
+	cacheMS, err := ws.cms.CacheMultiStoreWithVersion(int64(height))
+	if err != nil {
+		return err
+	}
+
+	ctx := sdk.NewContext(cacheMS, tmproto.Header{}, false, log.NewNopLogger())
+	seenBefore := make(map[string]bool)
+	var rerr error
+
+	ws.wasm.IterateCodeInfos(ctx, func(id uint64, info types.CodeInfo) bool {
+		if ws.wasm.IsPrunedCode(ctx, id) {
+			return false
+		}
+		hexHash := hex.EncodeToString(info.CodeHash)
+		// if seen before, just skip this one and move to the next
+		if seenBefore[hexHash] {
+			return false
+		}
+		seenBefore[hexHash] = true
+
+		// load code and abort on error
+		wasmBytes, err := ws.wasm.GetByteCode(ctx, id)
+		if err != nil {
+			rerr = err
+			return true
+		}
+
+		compressedWasm, err := gzipIt(wasmBytes)
+		if err != nil {
+			rerr = err
+			return true
+		}
+
+		if err := snapshot.WriteExtensionItem(protoWriter, compressedWasm); err != nil {
+			rerr = err
+			return true
+		}
+		return false
+	})
+
+	return rerr
+}
+
+// Restore stores and compiles the byte code from the snapshot items. It returns the first item
+// that is not a wasm extension payload so that the snapshot manager can continue with it.
+func (ws *WasmSnapshotter) Restore(height uint64, format uint32, protoReader protoio.Reader) (snapshot.SnapshotItem, error) {
+	if format == SnapshotFormat {
+		return ws.processAllItems(height, protoReader, restoreV1, finalizeV1)
+	}
+	return snapshot.SnapshotItem{}, snapshot.ErrUnknownFormat
+}
+
+func restoreV1(_ sdk.Context, k *Keeper, compressedCode []byte) error {
+	wasmCode, err := uncompress(compressedCode, uint64(types.MaxWasmSize))
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
+	}
+
+	if _, err := k.wasmVM.Create(wasmCode); err != nil {
+		return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
+	}
+	return nil
+}
+
+// finalizeV1 ensures that the byte code for all codes that were not pruned was restored and pins the codes
+// in the VM cache
+func finalizeV1(ctx sdk.Context, k *Keeper) error {
+	var rerr error
+	k.IterateCodeInfos(ctx, func(id uint64, info types.CodeInfo) bool {
+		if k.IsPrunedCode(ctx, id) {
+			return false
+		}
+		if _, err := k.wasmVM.GetCode(info.CodeHash); err != nil {
+			rerr = sdkerrors.Wrapf(types.ErrNotFound, "byte code for code id %d: %s", id, err)
+			return true
+		}
+		return false
+	})
+	if rerr != nil {
+		return rerr
+	}
+	return k.InitializePinnedCodes(ctx)
+}
+
+func (ws *WasmSnapshotter) processAllItems(
+	height uint64,
+	protoReader protoio.Reader,
+	cb func(sdk.Context, *Keeper, []byte) error,
+	finalize func(sdk.Context, *Keeper) error,
+) (snapshot.SnapshotItem, error) {
+	ctx := sdk.NewContext(ws.cms, tmproto.Header{Height: int64(height)}, false, log.NewNopLogger())
+
+	// keep the last item here... if we break, it will either be empty (if we hit io.EOF)
+	// or contain the last item (if we hit payload == nil)
+	var item snapshot.SnapshotItem
+	for {
+		item = snapshot.SnapshotItem{}
+		err := protoReader.ReadMsg(&item)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return snapshot.SnapshotItem{}, sdkerrors.Wrap(err, "invalid protobuf message")
+		}
+
+		// if it is not another ExtensionPayload message, then it is not for us.
+		// we should return it an let the manager handle this one
+		payload := item.GetExtensionPayload()
+		if payload == nil {
+			break
+		}
+
+		if err := cb(ctx, ws.wasm, payload.Payload); err != nil {
+			return snapshot.SnapshotItem{}, sdkerrors.Wrap(err, "processing snapshot item")
+		}
+	}
+
+	return item, finalize(ctx, ws.wasm)
+}
@@ -0,0 +1,214 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// StateRentConfig configures the optional state rent that contracts pay for the bytes in their store.
+// The rent is paid from a deposit that anybody can fund for a contract. Contracts that have not enough
+// deposit left for longer than the grace period are deactivated.
+type StateRentConfig struct {
+	// Denom of the deposits
+	Denom string
+	// PricePerByte is the rent per stored byte and block
+	PricePerByte sdk.Dec
+	// ChargeInterval is the number of blocks between two rent charges
+	ChargeInterval uint64
+	// GracePeriod is the number of blocks a contract can stay without sufficient deposit before it is deactivated
+	GracePeriod uint64
+	// MaxChargesPerBlock is the max number of contracts that are charged in a single block. A charge round that
+	// is not complete continues in the next blocks.
+	MaxChargesPerBlock uint32
+}
+
+// ValidateBasic performs basic validation
+func (c StateRentConfig) ValidateBasic() error {
+	if err := sdk.ValidateDenom(c.Denom); err != nil {
+		return sdkerrors.Wrap(err, "denom")
+	}
+	if c.PricePerByte.IsNil() || !c.PricePerByte.IsPositive() {
+		return sdkerrors.Wrap(types.ErrInvalid, "price per byte must be positive")
+	}
+	if c.ChargeInterval == 0 {
+		return sdkerrors.Wrap(types.ErrInvalid, "charge interval must not be 0")
+	}
+	if c.MaxChargesPerBlock == 0 {
+		return sdkerrors.Wrap(types.ErrInvalid, "max charges per block must not be 0")
+	}
+	return nil
+}
+
+// rentFor returns the rent for the given number of bytes over the charge interval
+func (c StateRentConfig) rentFor(bytes uint64) sdk.Int {
+	return c.PricePerByte.MulInt64(int64(bytes)).MulInt64(int64(c.ChargeInterval)).Ceil().TruncateInt()
+}
+
+// depositRent adds the amount to the rent deposit of the contract. The funds are held by the module account.
+// Inactive contracts do not pay rent and can not receive deposits.
+func (k Keeper) depositRent(ctx sdk.Context, sender, contractAddr sdk.AccAddress, amount sdk.Coin) error {
+	if k.stateRent == nil {
+		return sdkerrors.Wrap(types.ErrInvalid, "state rent not enabled")
+	}
+	if amount.Denom != k.stateRent.Denom {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "expected denom %s", k.stateRent.Denom)
+	}
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	if k.IsInactiveContract(ctx, contractAddr) {
+		return sdkerrors.Wrap(types.ErrInactiveContract, contractAddr.String())
+	}
+	if err := k.burner.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, sdk.NewCoins(amount)); err != nil {
+		return err
+	}
+	rent := k.burnFormerDenomDeposit(ctx, contractAddr, k.GetContractRent(ctx, contractAddr))
+	rent.Deposit = rent.Deposit.Add(amount)
+	// the grace period ends when the deposit is sufficient for the next charge again
+	if rent.GraceStartHeight != 0 && rent.Deposit.Amount.GTE(k.stateRent.rentFor(k.GetContractStateSize(ctx, contractAddr).Bytes)) {
+		rent.GraceStartHeight = 0
+	}
+	k.setContractRent(ctx, contractAddr, rent)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeDepositRent,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+		sdk.NewAttribute(types.AttributeKeyDeposit, rent.Deposit.String()),
+	))
+	return nil
+}
+
+// GetContractRent returns the state rent account of the contract. The deposit is empty when none was made.
+func (k Keeper) GetContractRent(ctx sdk.Context, contractAddr sdk.AccAddress) types.ContractRent {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetContractRentKey(contractAddr))
+	if bz == nil {
+		var denom string
+		if k.stateRent != nil {
+			denom = k.stateRent.Denom
+		}
+		return types.ContractRent{Deposit: sdk.Coin{Denom: denom, Amount: sdk.ZeroInt()}}
+	}
+	var rent types.ContractRent
+	k.cdc.MustUnmarshal(bz, &rent)
+	return rent
+}
+
+// hasContractRent returns true when a state rent account is stored for the contract
+func (k Keeper) hasContractRent(ctx sdk.Context, contractAddr sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetContractRentKey(contractAddr))
+}
+
+// setContractRent stores the state rent account. Empty accounts are removed.
+func (k Keeper) setContractRent(ctx sdk.Context, contractAddr sdk.AccAddress, rent types.ContractRent) {
+	store := ctx.KVStore(k.storeKey)
+	if rent.Deposit.IsZero() && rent.GraceStartHeight == 0 {
+		store.Delete(types.GetContractRentKey(contractAddr))
+		return
+	}
+	store.Set(types.GetContractRentKey(contractAddr), k.cdc.MustMarshal(&rent))
+}
+
+// burnFormerDenomDeposit burns the deposit when it is in a former rent denom and returns the rent account with an
+// empty deposit in the current denom. The deposit can not be refunded as the depositors are not stored.
+func (k Keeper) burnFormerDenomDeposit(ctx sdk.Context, contractAddr sdk.AccAddress, rent types.ContractRent) types.ContractRent {
+	if rent.Deposit.Denom == k.stateRent.Denom {
+		return rent
+	}
+	if rent.Deposit.IsPositive() {
+		if err := k.burner.BurnCoins(ctx, types.ModuleName, sdk.NewCoins(rent.Deposit)); err != nil {
+			// a failure here means the deposits are not backed by the module account balance
+			panic(sdkerrors.Wrap(err, "burn former state rent deposit"))
+		}
+		k.Logger(ctx).Info("burned state rent deposit in former denom", "contract", contractAddr.String(), "deposit", rent.Deposit.String())
+	}
+	rent.Deposit = sdk.NewCoin(k.stateRent.Denom, sdk.ZeroInt())
+	return rent
+}
+
+// chargeStateRent charges the rent for the state size of all active contracts when the charge interval is reached.
+// At most MaxChargesPerBlock contracts are charged in a block, the round continues in the next blocks until all
+// contracts were charged. A new round only starts at the charge interval when the previous one is complete.
+// The rent is burned. Contracts that can not pay their rent enter the grace period and are deactivated when
+// the deposit is still not sufficient at the end of it.
+func (k Keeper) chargeStateRent(ctx sdk.Context) {
+	if k.stateRent == nil {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	cursor := store.Get(types.StateRentCursorKey)
+	if cursor == nil && uint64(ctx.BlockHeight())%k.stateRent.ChargeInterval != 0 {
+		return
+	}
+	// collect first to not write into the store while iterating
+	contracts, next := k.nextStateRentContracts(ctx, cursor, k.stateRent.MaxChargesPerBlock)
+	if next == nil {
+		store.Delete(types.StateRentCursorKey)
+	} else {
+		store.Set(types.StateRentCursorKey, next)
+	}
+	height := uint64(ctx.BlockHeight())
+	burn := sdk.ZeroInt()
+	for _, contractAddr := range contracts {
+		if k.IsInactiveContract(ctx, contractAddr) {
+			continue
+		}
+		due := k.stateRent.rentFor(k.GetContractStateSize(ctx, contractAddr).Bytes)
+		if due.IsZero() {
+			continue
+		}
+		rent := k.burnFormerDenomDeposit(ctx, contractAddr, k.GetContractRent(ctx, contractAddr))
+		if rent.Deposit.Amount.GTE(due) {
+			burn = burn.Add(due)
+			rent.Deposit.Amount = rent.Deposit.Amount.Sub(due)
+			rent.GraceStartHeight = 0
+			k.setContractRent(ctx, contractAddr, rent)
+			continue
+		}
+		burn = burn.Add(rent.Deposit.Amount)
+		rent.Deposit.Amount = sdk.ZeroInt()
+		switch {
+		case rent.GraceStartHeight == 0:
+			rent.GraceStartHeight = height
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeRentExhausted,
+				sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+			))
+		case height-rent.GraceStartHeight >= k.stateRent.GracePeriod:
+			rent.GraceStartHeight = 0
+			if err := k.deactivateContract(ctx, contractAddr); err != nil { // should never happen
+				k.Logger(ctx).Error("deactivate contract without state rent", "contract", contractAddr.String(), "error", err)
+			}
+		}
+		k.setContractRent(ctx, contractAddr, rent)
+	}
+	if burn.IsZero() {
+		return
+	}
+	if err := k.burner.BurnCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewCoin(k.stateRent.Denom, burn))); err != nil {
+		// a failure here means the deposits are not backed by the module account balance
+		panic(sdkerrors.Wrap(err, "burn state rent"))
+	}
+}
+
+// nextStateRentContracts returns up to n contract addresses starting with the given one or the first contract
+// when nil. The address of the next contract is returned as well, it is nil when there are no more contracts.
+func (k Keeper) nextStateRentContracts(ctx sdk.Context, start []byte, n uint32) ([]sdk.AccAddress, []byte) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.ContractKeyPrefix)
+	iter := store.Iterator(start, nil)
+	defer iter.Close()
+
+	var r []sdk.AccAddress
+	for ; iter.Valid(); iter.Next() {
+		if len(r) == int(n) {
+			return r, append([]byte{}, iter.Key()...)
+		}
+		r = append(r, append([]byte{}, iter.Key()...))
+	}
+	return r, nil
+}
@@ -0,0 +1,292 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+var myStateRentConfig = StateRentConfig{
+	Denom:              "denom",
+	PricePerByte:       sdk.OneDec(),
+	ChargeInterval:     10,
+	GracePeriod:        20,
+	MaxChargesPerBlock: 10,
+}
+
+func TestDepositRent(t *testing.T) {
+	specs := map[string]struct {
+		srcRentConfig *StateRentConfig
+		srcContract   bool
+		srcInactive   bool
+		srcAmount     sdk.Coin
+		srcRent       *types.ContractRent
+		expErr        *sdkerrors.Error
+		expRent       types.ContractRent
+	}{
+		"first deposit": {
+			srcRentConfig: &myStateRentConfig,
+			srcContract:   true,
+			srcAmount:     sdk.NewInt64Coin("denom", 100),
+			expRent:       types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 100)},
+		},
+		"deposit added": {
+			srcRentConfig: &myStateRentConfig,
+			srcContract:   true,
+			srcAmount:     sdk.NewInt64Coin("denom", 100),
+			srcRent:       &types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 1)},
+			expRent:       types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 101)},
+		},
+		"sufficient deposit ends grace period": {
+			srcRentConfig: &myStateRentConfig,
+			srcContract:   true,
+			srcAmount:     sdk.NewInt64Coin("denom", 60),
+			srcRent:       &types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 0), GraceStartHeight: 1},
+			expRent:       types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 60)},
+		},
+		"insufficient deposit keeps grace period": {
+			srcRentConfig: &myStateRentConfig,
+			srcContract:   true,
+			srcAmount:     sdk.NewInt64Coin("denom", 59),
+			srcRent:       &types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 0), GraceStartHeight: 1},
+			expRent:       types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 59), GraceStartHeight: 1},
+		},
+		"deposit in former denom burned": {
+			srcRentConfig: &myStateRentConfig,
+			srcContract:   true,
+			srcAmount:     sdk.NewInt64Coin("denom", 100),
+			srcRent:       &types.ContractRent{Deposit: sdk.NewInt64Coin("other", 5)},
+			expRent:       types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 100)},
+		},
+		"inactive contract": {
+			srcRentConfig: &myStateRentConfig,
+			srcContract:   true,
+			srcInactive:   true,
+			srcAmount:     sdk.NewInt64Coin("denom", 100),
+			expErr:        types.ErrInactiveContract,
+		},
+		"state rent not enabled": {
+			srcContract: true,
+			srcAmount:   sdk.NewInt64Coin("denom", 100),
+			expErr:      types.ErrInvalid,
+		},
+		"wrong denom": {
+			srcRentConfig: &myStateRentConfig,
+			srcContract:   true,
+			srcAmount:     sdk.NewInt64Coin("other", 100),
+			expErr:        sdkerrors.ErrInvalidCoins,
+		},
+		"unknown contract": {
+			srcRentConfig: &myStateRentConfig,
+			srcAmount:     sdk.NewInt64Coin("denom", 100),
+			expErr:        types.ErrNotFound,
+		},
+		"insufficient funds": {
+			srcRentConfig: &myStateRentConfig,
+			srcContract:   true,
+			srcAmount:     sdk.NewInt64Coin("denom", 1001),
+			expErr:        sdkerrors.ErrInsufficientFunds,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var opts []Option
+			if spec.srcRentConfig != nil {
+				opts = append(opts, WithStateRent(*spec.srcRentConfig))
+			}
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, opts...)
+			k := keepers.WasmKeeper
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			k.contractStateStore(ctx, example.Contract).Set([]byte("foo"), []byte("bar"))
+			contractAddr := RandomAccountAddress(t)
+			if spec.srcContract {
+				contractAddr = example.Contract
+			}
+			if spec.srcInactive {
+				require.NoError(t, k.deactivateContract(ctx, contractAddr))
+			}
+			moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+			if spec.srcRent != nil {
+				if spec.srcRent.Deposit.IsPositive() {
+					fundModuleAccount(t, ctx, keepers, spec.srcRent.Deposit)
+				}
+				k.setContractRent(ctx, contractAddr, *spec.srcRent)
+			}
+			moduleBalance := keepers.BankKeeper.GetBalance(ctx, moduleAddr, "denom")
+
+			// when
+			gotErr := k.depositRent(ctx, example.CreatorAddr, contractAddr, spec.srcAmount)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expRent, k.GetContractRent(ctx, contractAddr))
+			assert.Equal(t, moduleBalance.Add(spec.srcAmount), keepers.BankKeeper.GetBalance(ctx, moduleAddr, "denom"))
+			assert.True(t, keepers.BankKeeper.GetBalance(ctx, moduleAddr, "other").IsZero())
+		})
+	}
+}
+
+func TestChargeStateRent(t *testing.T) {
+	specs := map[string]struct {
+		srcHeight       int64
+		srcRent         *types.ContractRent
+		srcEmptyState   bool
+		expRent         types.ContractRent
+		expBurned       int64
+		expInactive     bool
+		expGraceStarted bool
+	}{
+		"sufficient deposit": {
+			srcHeight: 100,
+			srcRent:   &types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 100)},
+			expRent:   types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 40)},
+			expBurned: 60,
+		},
+		"sufficient deposit ends grace period": {
+			srcHeight: 100,
+			srcRent:   &types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 60), GraceStartHeight: 90},
+			expRent:   types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 0)},
+			expBurned: 60,
+		},
+		"insufficient deposit starts grace period": {
+			srcHeight:       100,
+			srcRent:         &types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 59)},
+			expRent:         types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 0), GraceStartHeight: 100},
+			expBurned:       59,
+			expGraceStarted: true,
+		},
+		"no deposit starts grace period": {
+			srcHeight:       100,
+			expRent:         types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 0), GraceStartHeight: 100},
+			expGraceStarted: true,
+		},
+		"in grace period": {
+			srcHeight: 100,
+			srcRent:   &types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 0), GraceStartHeight: 90},
+			expRent:   types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 0), GraceStartHeight: 90},
+		},
+		"grace period ended": {
+			srcHeight:   110,
+			srcRent:     &types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 0), GraceStartHeight: 90},
+			expRent:     types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 0)},
+			expInactive: true,
+		},
+		"no state": {
+			srcHeight:     100,
+			srcEmptyState: true,
+			srcRent:       &types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 100)},
+			expRent:       types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 100)},
+		},
+		"deposit in former denom burned": {
+			srcHeight:       100,
+			srcRent:         &types.ContractRent{Deposit: sdk.NewInt64Coin("other", 100)},
+			expRent:         types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 0), GraceStartHeight: 100},
+			expGraceStarted: true,
+		},
+		"not a charge height": {
+			srcHeight: 101,
+			srcRent:   &types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 100)},
+			expRent:   types.ContractRent{Deposit: sdk.NewInt64Coin("denom", 100)},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithStateRent(myStateRentConfig))
+			k := keepers.WasmKeeper
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			if !spec.srcEmptyState {
+				k.contractStateStore(ctx, example.Contract).Set([]byte("foo"), []byte("bar"))
+			}
+			moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+			if spec.srcRent != nil {
+				if spec.srcRent.Deposit.IsPositive() {
+					fundModuleAccount(t, ctx, keepers, spec.srcRent.Deposit)
+				}
+				k.setContractRent(ctx, example.Contract, *spec.srcRent)
+			}
+			supply := keepers.BankKeeper.GetSupply(ctx, "denom")
+			em := sdk.NewEventManager()
+
+			// when
+			k.chargeStateRent(ctx.WithBlockHeight(spec.srcHeight).WithEventManager(em))
+
+			// then
+			assert.Equal(t, spec.expRent, k.GetContractRent(ctx, example.Contract))
+			assert.Equal(t, supply.SubAmount(sdk.NewInt(spec.expBurned)), keepers.BankKeeper.GetSupply(ctx, "denom"))
+			assert.Equal(t, spec.expInactive, k.IsInactiveContract(ctx, example.Contract))
+			assert.True(t, keepers.BankKeeper.GetBalance(ctx, moduleAddr, "other").IsZero())
+			var gotGraceEvent bool
+			for _, e := range em.Events() {
+				gotGraceEvent = gotGraceEvent || e.Type == types.EventTypeRentExhausted
+			}
+			assert.Equal(t, spec.expGraceStarted, gotGraceEvent)
+		})
+	}
+}
+
+func TestChargeStateRentAcrossBlocks(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	rentConfig := myStateRentConfig
+	rentConfig.MaxChargesPerBlock = 2
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithStateRent(rentConfig))
+	k := keepers.WasmKeeper
+	var contracts []sdk.AccAddress
+	for i := 0; i < 5; i++ {
+		example := SeedNewContractInstance(t, ctx, keepers, &mock)
+		k.contractStateStore(ctx, example.Contract).Set([]byte("foo"), []byte("bar"))
+		require.NoError(t, k.depositRent(ctx, example.CreatorAddr, example.Contract, sdk.NewInt64Coin("denom", 100)))
+		contracts = append(contracts, example.Contract)
+	}
+	countCharged := func() int {
+		var n int
+		for _, c := range contracts {
+			if k.GetContractRent(ctx, c).Deposit.Amount.Int64() == 40 {
+				n++
+			}
+		}
+		return n
+	}
+
+	// when not a charge height
+	k.chargeStateRent(ctx.WithBlockHeight(99))
+	// then
+	assert.Equal(t, 0, countCharged())
+
+	// when a round starts
+	k.chargeStateRent(ctx.WithBlockHeight(100))
+	// then
+	assert.Equal(t, 2, countCharged())
+
+	// and when continued in the next blocks
+	k.chargeStateRent(ctx.WithBlockHeight(101))
+	assert.Equal(t, 4, countCharged())
+	k.chargeStateRent(ctx.WithBlockHeight(102))
+	assert.Equal(t, 5, countCharged())
+	assert.False(t, ctx.KVStore(k.storeKey).Has(types.StateRentCursorKey))
+
+	// and when the round is complete
+	k.chargeStateRent(ctx.WithBlockHeight(103))
+	// then no contract is charged twice
+	assert.Equal(t, 5, countCharged())
+}
+
+func fundModuleAccount(t *testing.T, ctx sdk.Context, keepers TestKeepers, amount sdk.Coin) {
+	sender := keepers.Faucet.NewFundedAccount(ctx, amount)
+	require.NoError(t, keepers.BankKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, sdk.NewCoins(amount)))
+}
@@ -0,0 +1,93 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// contractStateStore is the prefixed data store of a contract instance. It keeps the state size counters
+// of the contract up to date on writes and deletes. Reading the previous value and updating the counters
+// is charged to the gas meter of the context like any other store access.
+type contractStateStore struct {
+	prefix.Store
+	parent  sdk.KVStore
+	sizeKey []byte
+	cdc     codec.Codec
+}
+
+func (k Keeper) contractStateStore(ctx sdk.Context, contractAddress sdk.AccAddress) contractStateStore {
+	// 0x03 | BuildContractAddress (sdk.AccAddress)
+	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
+	parent := ctx.KVStore(k.storeKey)
+	return contractStateStore{
+		Store:   prefix.NewStore(parent, prefixStoreKey),
+		parent:  parent,
+		sizeKey: types.GetContractStateSizeKey(contractAddress),
+		cdc:     k.cdc,
+	}
+}
+
+// Set stores the value and updates the state size counters
+func (s contractStateStore) Set(key, value []byte) {
+	old := s.Store.Get(key)
+	s.Store.Set(key, value)
+	if old == nil {
+		s.updateSize(1, int64(len(key)+len(value)))
+		return
+	}
+	if len(value) != len(old) {
+		s.updateSize(0, int64(len(value)-len(old)))
+	}
+}
+
+// Delete removes the key and updates the state size counters
+func (s contractStateStore) Delete(key []byte) {
+	old := s.Store.Get(key)
+	s.Store.Delete(key)
+	if old != nil {
+		s.updateSize(-1, -int64(len(key)+len(old)))
+	}
+}
+
+func (s contractStateStore) updateSize(keysDelta, bytesDelta int64) {
+	size := readContractStateSize(s.cdc, s.parent, s.sizeKey)
+	size.Keys = uint64(int64(size.Keys) + keysDelta)
+	size.Bytes = uint64(int64(size.Bytes) + bytesDelta)
+	writeContractStateSize(s.cdc, s.parent, s.sizeKey, size)
+}
+
+// GetContractStateSize returns the number of keys and bytes stored by the contract
+func (k Keeper) GetContractStateSize(ctx sdk.Context, contractAddress sdk.AccAddress) types.ContractStateSize {
+	return readContractStateSize(k.cdc, ctx.KVStore(k.storeKey), types.GetContractStateSizeKey(contractAddress))
+}
+
+// setContractStateSize counts the keys and bytes in the contract store and persists the result.
+// This is used when the contract store was written without the state size tracking.
+func (k Keeper) setContractStateSize(ctx sdk.Context, contractAddress sdk.AccAddress) {
+	var size types.ContractStateSize
+	k.IterateContractState(ctx, contractAddress, func(key, value []byte) bool {
+		size.Keys++
+		size.Bytes += uint64(len(key) + len(value))
+		return false
+	})
+	writeContractStateSize(k.cdc, ctx.KVStore(k.storeKey), types.GetContractStateSizeKey(contractAddress), size)
+}
+
+func readContractStateSize(cdc codec.Codec, store sdk.KVStore, sizeKey []byte) types.ContractStateSize {
+	var size types.ContractStateSize
+	if bz := store.Get(sizeKey); bz != nil {
+		cdc.MustUnmarshal(bz, &size)
+	}
+	return size
+}
+
+func writeContractStateSize(cdc codec.Codec, store sdk.KVStore, sizeKey []byte, size types.ContractStateSize) {
+	if size.Keys == 0 {
+		store.Delete(sizeKey)
+		return
+	}
+	store.Set(sizeKey, cdc.MustMarshal(&size))
+}
@@ -0,0 +1,108 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractStateStoreTracksSize(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	contractAddr := RandomAccountAddress(t)
+
+	specs := []struct {
+		name    string
+		do      func(s sdk.KVStore)
+		expSize types.ContractStateSize
+	}{
+		{
+			name:    "set new key",
+			do:      func(s sdk.KVStore) { s.Set([]byte("foo"), []byte("bar")) },
+			expSize: types.ContractStateSize{Keys: 1, Bytes: 6},
+		},
+		{
+			name:    "set other key",
+			do:      func(s sdk.KVStore) { s.Set([]byte("a"), []byte("bc")) },
+			expSize: types.ContractStateSize{Keys: 2, Bytes: 9},
+		},
+		{
+			name:    "overwrite with longer value",
+			do:      func(s sdk.KVStore) { s.Set([]byte("foo"), []byte("barbaz")) },
+			expSize: types.ContractStateSize{Keys: 2, Bytes: 12},
+		},
+		{
+			name:    "overwrite with shorter value",
+			do:      func(s sdk.KVStore) { s.Set([]byte("foo"), []byte{}) },
+			expSize: types.ContractStateSize{Keys: 2, Bytes: 6},
+		},
+		{
+			name:    "delete non existing key",
+			do:      func(s sdk.KVStore) { s.Delete([]byte("unknown")) },
+			expSize: types.ContractStateSize{Keys: 2, Bytes: 6},
+		},
+		{
+			name:    "delete key",
+			do:      func(s sdk.KVStore) { s.Delete([]byte("foo")) },
+			expSize: types.ContractStateSize{Keys: 1, Bytes: 3},
+		},
+		{
+			name:    "delete last key",
+			do:      func(s sdk.KVStore) { s.Delete([]byte("a")) },
+			expSize: types.ContractStateSize{},
+		},
+	}
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			// when
+			trackedCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			spec.do(k.contractStateStore(trackedCtx, contractAddr))
+
+			// then
+			assert.Equal(t, spec.expSize, k.GetContractStateSize(ctx, contractAddr))
+			// and the bookkeeping is charged
+			cachedCtx, _ := ctx.CacheContext()
+			plainCtx := cachedCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			spec.do(prefix.NewStore(plainCtx.KVStore(k.storeKey), types.GetContractStorePrefix(contractAddr)))
+			assert.Greater(t, trackedCtx.GasMeter().GasConsumed(), plainCtx.GasMeter().GasConsumed())
+		})
+	}
+	assert.False(t, ctx.KVStore(k.storeKey).Has(types.GetContractStateSizeKey(contractAddr)))
+}
+
+func TestContractStateSizeOnContractCalls(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+
+	// when instantiated
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	// then
+	got := k.GetContractStateSize(ctx, example.Contract)
+	assert.NotZero(t, got.Keys)
+	assert.Equal(t, countContractState(ctx, k, example.Contract), got)
+
+	// when migrated to a contract that removes all state
+	burner := StoreBurnerExampleContract(t, ctx, keepers)
+	migMsgBz := BurnerExampleInitMsg{Payout: example.CreatorAddr}.GetBytes(t)
+	_, err := keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, burner.CodeID, migMsgBz)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, countContractState(ctx, k, example.Contract), k.GetContractStateSize(ctx, example.Contract))
+}
+
+func countContractState(ctx sdk.Context, k *Keeper, contractAddr sdk.AccAddress) types.ContractStateSize {
+	var size types.ContractStateSize
+	k.IterateContractState(ctx, contractAddr, func(key, value []byte) bool {
+		size.Keys++
+		size.Bytes += uint64(len(key) + len(value))
+		return false
+	})
+	return size
+}
@@ -395,7 +395,7 @@ func createTestInput(
 		distribution.NewAppModule(appCodec, distKeeper, accountKeeper, bankKeeper, stakingKeeper),
 	)
 	am.RegisterServices(module.NewConfigurator(appCodec, msgRouter, querier))
-	types.RegisterMsgServer(msgRouter, NewMsgServerImpl(NewDefaultPermissionKeeper(keeper)))
+	types.RegisterMsgServer(msgRouter, NewMsgServerImpl(NewDefaultPermissionKeeper(keeper), keeper))
 	types.RegisterQueryServer(querier, NewGrpcQuerier(appCodec, keys[types.ModuleName], keeper, keeper.queryGasLimit))
 
 	govRouter := govtypes.NewRouter().
@@ -529,41 +529,47 @@ type ExampleContract struct {
 }
 
 func StoreHackatomExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
-	return StoreExampleContract(t, ctx, keepers, "./testdata/hackatom.wasm")
+	return StoreExampleContractWasm(t, ctx, keepers, HackatomContractWasm())
 }
 
 func StoreBurnerExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
-	return StoreExampleContract(t, ctx, keepers, "./testdata/burner.wasm")
+	return StoreExampleContractWasm(t, ctx, keepers, BurnerContractWasm())
 }
 
 func StoreIBCReflectContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
-	return StoreExampleContract(t, ctx, keepers, "./testdata/ibc_reflect.wasm")
+	return StoreExampleContractWasm(t, ctx, keepers, IBCReflectContractWasm())
 }
 
 func StoreReflectContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) uint64 {
-	wasmCode, err := ioutil.ReadFile("./testdata/reflect.wasm")
-	require.NoError(t, err)
-
 	_, _, creatorAddr := keyPubAddr()
-	codeID, err := keepers.ContractKeeper.Create(ctx, creatorAddr, wasmCode, nil)
+	codeID, err := keepers.ContractKeeper.Create(ctx, creatorAddr, ReflectContractWasm(), nil)
 	require.NoError(t, err)
 	return codeID
 }
 
 func StoreExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers, wasmFile string) ExampleContract {
+	wasmCode, err := ioutil.ReadFile(wasmFile)
+	require.NoError(t, err)
+	return StoreExampleContractWasm(t, ctx, keepers, wasmCode)
+}
+
+// StoreExampleContractWasm stores the given wasm code with a new funded creator account
+func StoreExampleContractWasm(t testing.TB, ctx sdk.Context, keepers TestKeepers, wasmCode []byte) ExampleContract {
 	anyAmount := sdk.NewCoins(sdk.NewInt64Coin("denom", 1000))
 	creator, _, creatorAddr := keyPubAddr()
 	fundAccounts(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, creatorAddr, anyAmount)
 
-	wasmCode, err := ioutil.ReadFile(wasmFile)
-	require.NoError(t, err)
-
 	codeID, err := keepers.ContractKeeper.Create(ctx, creatorAddr, wasmCode, nil)
 	require.NoError(t, err)
 	return ExampleContract{anyAmount, creator, creatorAddr, codeID}
 }
 
-var wasmIdent = []byte("\x00\x61\x73\x6D")
+// randomWasmCode returns an empty wasm module with a random custom section so that each code has a unique checksum
+func randomWasmCode() []byte {
+	header := []byte("\x00asm\x01\x00\x00\x00")
+	// custom section with an empty name and 10 random bytes
+	return append(append(header, 0x00, 11, 0x00), rand.Bytes(10)...)
+}
 
 type ExampleContractInstance struct {
 	ExampleContract
@@ -574,7 +580,7 @@ type ExampleContractInstance struct {
 func SeedNewContractInstance(t testing.TB, ctx sdk.Context, keepers TestKeepers, mock types.WasmerEngine) ExampleContractInstance {
 	t.Helper()
 	exampleContract := StoreRandomContract(t, ctx, keepers, mock)
-	contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, exampleContract.CodeID, exampleContract.CreatorAddr, exampleContract.CreatorAddr, []byte(`{}`), "", nil)
+	contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, exampleContract.CodeID, exampleContract.CreatorAddr, exampleContract.CreatorAddr, []byte(`{}`), "testing", nil)
 	require.NoError(t, err)
 	return ExampleContractInstance{
 		ExampleContract: exampleContract,
@@ -589,7 +595,7 @@ func StoreRandomContract(t testing.TB, ctx sdk.Context, keepers TestKeepers, moc
 	creator, _, creatorAddr := keyPubAddr()
 	fundAccounts(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, creatorAddr, anyAmount)
 	keepers.WasmKeeper.wasmVM = mock
-	wasmCode := append(wasmIdent, rand.Bytes(10)...) //nolint:gocritic
+	wasmCode := randomWasmCode()
 	codeID, err := keepers.ContractKeeper.Create(ctx, creatorAddr, wasmCode, nil)
 	require.NoError(t, err)
 	exampleContract := ExampleContract{InitialAmount: anyAmount, Creator: creator, CreatorAddr: creatorAddr, CodeID: codeID}
@@ -605,7 +611,7 @@ type HackatomExampleInstance struct {
 	BeneficiaryAddr sdk.AccAddress
 }
 
-// InstantiateHackatomExampleContract load and instantiate the "./testdata/hackatom.wasm" contract
+// InstantiateHackatomExampleContract load and instantiate the embedded hackatom contract
 func InstantiateHackatomExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) HackatomExampleInstance {
 	contract := StoreHackatomExampleContract(t, ctx, keepers)
 
@@ -650,7 +656,7 @@ type IBCReflectExampleInstance struct {
 	ReflectCodeID uint64
 }
 
-// InstantiateIBCReflectContract load and instantiate the "./testdata/ibc_reflect.wasm" contract
+// InstantiateIBCReflectContract load and instantiate the embedded ibc-reflect contract
 func InstantiateIBCReflectContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) IBCReflectExampleInstance {
 	reflectID := StoreReflectContract(t, ctx, keepers)
 	ibcReflectID := StoreIBCReflectContract(t, ctx, keepers).CodeID
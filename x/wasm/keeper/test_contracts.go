@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	_ "embed"
+)
+
+var (
+	//go:embed testdata/hackatom.wasm
+	hackatomContract []byte
+	//go:embed testdata/burner.wasm
+	burnerContract []byte
+	//go:embed testdata/reflect.wasm
+	reflectContract []byte
+	//go:embed testdata/ibc_reflect.wasm
+	ibcReflectContract []byte
+)
+
+// HackatomContractWasm returns the compiled hackatom example contract
+func HackatomContractWasm() []byte {
+	return hackatomContract
+}
+
+// BurnerContractWasm returns the compiled burner example contract
+func BurnerContractWasm() []byte {
+	return burnerContract
+}
+
+// ReflectContractWasm returns the compiled reflect example contract
+func ReflectContractWasm() []byte {
+	return reflectContract
+}
+
+// IBCReflectContractWasm returns the compiled ibc-reflect example contract
+func IBCReflectContractWasm() []byte {
+	return ibcReflectContract
+}
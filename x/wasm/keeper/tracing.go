@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation name of the wasm module spans
+const tracerName = "github.com/CosmWasm/wasmd/x/wasm"
+
+// defaultTracer returns the tracer of the global OpenTelemetry provider. It does not record
+// anything unless the node operator registers a provider with an exporter.
+func defaultTracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// startContractSpan starts the span for a call to a contract entry point. The returned context carries the span
+// so that the spans of the contract calls from queries, message dispatch and replies become its children. The
+// caller must end the span after the contract response was handled. The msg size is only added for raw messages.
+func (k Keeper) startContractSpan(ctx sdk.Context, operation string, codeID uint64, contractAddr sdk.AccAddress, msg []byte) (sdk.Context, trace.Span) {
+	goCtx := ctx.Context()
+	if goCtx == nil {
+		goCtx = context.Background()
+	}
+	if k.tracer == nil {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+	attrs := []attribute.KeyValue{
+		attribute.Int64("wasm.code_id", int64(codeID)),
+		attribute.String("wasm.contract", contractAddr.String()),
+	}
+	if msg != nil {
+		attrs = append(attrs, attribute.Int("wasm.msg_size", len(msg)))
+	}
+	goCtx, span := k.tracer.Start(goCtx, "wasm."+operation, trace.WithAttributes(attrs...))
+	return ctx.WithContext(goCtx), span
+}
+
+// traceVMCall adds the gas used by the VM and the error of the VM call, if any, to the span of the contract call
+func (k Keeper) traceVMCall(span trace.Span, vmGasUsed uint64, err error) {
+	span.SetAttributes(attribute.Int64("wasm.gas_used", int64(k.gasRegister.FromWasmVMGas(vmGasUsed))))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,149 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+)
+
+func TestStartContractSpan(t *testing.T) {
+	contractAddr := RandomAccountAddress(t)
+	var tracer recordingTracer
+	k := Keeper{gasRegister: NewDefaultWasmGasRegister()}
+	WithTracerProvider(recordingTracerProvider{&tracer}).apply(&k)
+
+	// when
+	ctx, span := k.startContractSpan(sdk.Context{}, "execute", 1, contractAddr, []byte(`{"foo":{}}`))
+	_, nestedSpan := k.startContractSpan(ctx, "reply", 2, contractAddr, nil)
+
+	// then
+	require.Len(t, tracer.spans, 2)
+	assert.Equal(t, span, trace.SpanFromContext(ctx.Context()))
+	assert.Equal(t, "wasm.execute", tracer.spans[0].name)
+	expAttrs := []attribute.KeyValue{
+		attribute.Int64("wasm.code_id", 1),
+		attribute.String("wasm.contract", contractAddr.String()),
+		attribute.Int("wasm.msg_size", 10),
+	}
+	assert.Equal(t, expAttrs, tracer.spans[0].cfg.Attributes())
+	// and the nested call is a child
+	assert.Equal(t, "wasm.reply", tracer.spans[1].name)
+	assert.Equal(t, span, tracer.spans[1].parent)
+	assert.Equal(t, nestedSpan, tracer.spans[1])
+	expAttrs = []attribute.KeyValue{
+		attribute.Int64("wasm.code_id", 2),
+		attribute.String("wasm.contract", contractAddr.String()),
+	}
+	assert.Equal(t, expAttrs, tracer.spans[1].cfg.Attributes())
+}
+
+func TestTraceVMCall(t *testing.T) {
+	specs := map[string]struct {
+		err       error
+		expStatus codes.Code
+	}{
+		"success": {
+			expStatus: codes.Unset,
+		},
+		"vm error": {
+			err:       errors.New("testing"),
+			expStatus: codes.Error,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			k := Keeper{gasRegister: NewDefaultWasmGasRegister()}
+			span := &recordingSpan{}
+
+			// when
+			k.traceVMCall(span, 3*DefaultGasMultiplier, spec.err)
+
+			// then
+			assert.Equal(t, []attribute.KeyValue{attribute.Int64("wasm.gas_used", 3)}, span.attrs)
+			assert.Equal(t, spec.expStatus, span.status)
+		})
+	}
+}
+
+func TestContractCallSpans(t *testing.T) {
+	var tracer recordingTracer
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	mock.IBCChannelOpenFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCChannelOpenMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (uint64, error) {
+		return 0, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithTracerProvider(recordingTracerProvider{&tracer}))
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	tracer.spans = nil
+
+	// when
+	_, err := keepers.WasmKeeper.Sudo(ctx, example.Contract, []byte(`{}`))
+	require.NoError(t, err)
+	err = keepers.WasmKeeper.OnOpenChannel(ctx, example.Contract, wasmvmtypes.IBCChannelOpenMsg{})
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, tracer.spans, 2)
+	assert.Equal(t, "wasm.sudo", tracer.spans[0].name)
+	assert.True(t, tracer.spans[0].ended)
+	assert.Equal(t, "wasm.ibc-open-channel", tracer.spans[1].name)
+	assert.True(t, tracer.spans[1].ended)
+}
+
+type recordingTracerProvider struct {
+	tracer *recordingTracer
+}
+
+func (p recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return p.tracer
+}
+
+type recordingTracer struct {
+	spans []*recordingSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	span := &recordingSpan{Span: parent, parent: parent, name: name, cfg: trace.NewSpanStartConfig(opts...)}
+	r.spans = append(r.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// recordingSpan captures the parent, start config, attributes, status and end of a span. All other methods are noops.
+type recordingSpan struct {
+	trace.Span
+	parent trace.Span
+	name   string
+	cfg    trace.SpanConfig
+	attrs  []attribute.KeyValue
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attrs = append(s.attrs, kv...)
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+
+func (s *recordingSpan) RecordError(error, ...trace.EventOption) {}
+
+func (s *recordingSpan) End(...trace.SpanEndOption) {
+	s.ended = true
+}
@@ -0,0 +1,101 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// MigrationErrorPolicy defines how MigrateContractsByCode handles contracts that fail to migrate
+type MigrationErrorPolicy int
+
+const (
+	// MigrationFailOnError aborts the batch migration with the first failing contract
+	MigrationFailOnError MigrationErrorPolicy = iota
+	// MigrationSkipOnError skips failing contracts. Their state is not modified and they stay on the old code.
+	MigrationSkipOnError
+)
+
+// chainUpgradeSudoMsg is sent to the sudo entry point of contracts when the chain is upgraded
+type chainUpgradeSudoMsg struct {
+	ChainUpgrade chainUpgrade `json:"chain_upgrade"`
+}
+
+type chainUpgrade struct {
+	Name   string `json:"name"`
+	Height uint64 `json:"height"`
+}
+
+// NotifyContractsOnUpgrade calls the sudo entry point of the given contracts with a `chain_upgrade` message that
+// contains the upgrade name and height. The contracts are called in the given order, the first failure is returned.
+func (k Keeper) NotifyContractsOnUpgrade(ctx sdk.Context, plan upgradetypes.Plan, contracts []sdk.AccAddress) error {
+	msg, err := json.Marshal(chainUpgradeSudoMsg{ChainUpgrade: chainUpgrade{Name: plan.Name, Height: uint64(plan.Height)}})
+	if err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	for _, contractAddr := range contracts {
+		if _, err := k.Sudo(ctx, contractAddr, msg); err != nil {
+			return sdkerrors.Wrapf(err, "contract %s", contractAddr)
+		}
+	}
+	return nil
+}
+
+// NewContractsNotifyingUpgradeHandler returns an upgrade handler that runs the next handler first and then
+// notifies the given contracts via sudo so that they can adjust their state to the upgraded chain.
+// The next handler is usually the one that runs the module migrations and can be nil.
+func NewContractsNotifyingUpgradeHandler(k *Keeper, contracts []sdk.AccAddress, next upgradetypes.UpgradeHandler) upgradetypes.UpgradeHandler {
+	return func(ctx sdk.Context, plan upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+		toVM := fromVM
+		if next != nil {
+			var err error
+			if toVM, err = next(ctx, plan, fromVM); err != nil {
+				return nil, err
+			}
+		}
+		if err := k.NotifyContractsOnUpgrade(ctx, plan, contracts); err != nil {
+			return nil, err
+		}
+		return toVM, nil
+	}
+}
+
+// MigrateContractsByCode migrates all contracts of the old code id to the new code id with the given migrate msg.
+// It is intended to be used in upgrade handlers for coordinated fixes of many contract instances and does not
+// require the contracts to have an admin. Failing contracts are handled according to the error policy, the
+// addresses of the contracts that were skipped are returned.
+func (k Keeper) MigrateContractsByCode(ctx sdk.Context, oldCodeID, newCodeID uint64, msg []byte, policy MigrationErrorPolicy) ([]sdk.AccAddress, error) {
+	if k.GetCodeInfo(ctx, oldCodeID) == nil {
+		return nil, sdkerrors.Wrap(types.ErrNotFound, "old code")
+	}
+	if k.GetCodeInfo(ctx, newCodeID) == nil {
+		return nil, sdkerrors.Wrap(types.ErrNotFound, "new code")
+	}
+	var contracts []sdk.AccAddress
+	// collect first to not write into the store while iterating
+	k.IterateContractsByCode(ctx, oldCodeID, func(contractAddr sdk.AccAddress) bool {
+		contracts = append(contracts, contractAddr)
+		return false
+	})
+	var skipped []sdk.AccAddress
+	for _, contractAddr := range contracts {
+		cacheCtx, commit := ctx.CacheContext()
+		// the contract is the caller as for migrations by governance
+		if _, err := k.migrate(cacheCtx, contractAddr, contractAddr, newCodeID, msg, GovAuthorizationPolicy{}); err != nil {
+			if policy == MigrationSkipOnError {
+				k.Logger(ctx).Error("contract migration skipped", "contract", contractAddr.String(), "error", err)
+				skipped = append(skipped, contractAddr)
+				continue
+			}
+			return nil, sdkerrors.Wrapf(err, "contract %s", contractAddr)
+		}
+		commit()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+	return skipped, nil
+}
@@ -0,0 +1,172 @@
+package keeper
+
+import (
+	"errors"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestNewContractsNotifyingUpgradeHandler(t *testing.T) {
+	myPlan := upgradetypes.Plan{Name: "v2", Height: 100}
+	myVersions := module.VersionMap{"wasm": 4}
+	specs := map[string]struct {
+		srcNext     upgradetypes.UpgradeHandler
+		srcSudoErr  error
+		expCalls    int
+		expErr      bool
+		expVersions module.VersionMap
+	}{
+		"all contracts notified": {
+			expCalls:    2,
+			expVersions: myVersions,
+		},
+		"next handler called": {
+			srcNext: func(ctx sdk.Context, plan upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+				return module.VersionMap{"wasm": 5}, nil
+			},
+			expCalls:    2,
+			expVersions: module.VersionMap{"wasm": 5},
+		},
+		"next handler fails": {
+			srcNext: func(ctx sdk.Context, plan upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+				return nil, errors.New("testing")
+			},
+			expErr: true,
+		},
+		"contract fails": {
+			srcSudoErr: errors.New("testing"),
+			expCalls:   1,
+			expErr:     true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			contracts := []sdk.AccAddress{
+				SeedNewContractInstance(t, ctx, keepers, &mock).Contract,
+				SeedNewContractInstance(t, ctx, keepers, &mock).Contract,
+			}
+			var gotMsgs []string
+			mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+				gotMsgs = append(gotMsgs, string(sudoMsg))
+				return &wasmvmtypes.Response{}, 0, spec.srcSudoErr
+			}
+			h := NewContractsNotifyingUpgradeHandler(keepers.WasmKeeper, contracts, spec.srcNext)
+
+			// when
+			gotVersions, gotErr := h(ctx, myPlan, myVersions)
+
+			// then
+			require.Len(t, gotMsgs, spec.expCalls)
+			for _, m := range gotMsgs {
+				assert.JSONEq(t, `{"chain_upgrade":{"name":"v2","height":100}}`, m)
+			}
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expVersions, gotVersions)
+		})
+	}
+}
+
+func TestMigrateContractsByCode(t *testing.T) {
+	var (
+		myKey   = []byte("foo")
+		myValue = []byte("bar")
+	)
+	specs := map[string]struct {
+		srcPolicy   MigrationErrorPolicy
+		srcFailing  bool
+		expErr      bool
+		expSkipped  int
+		expMigrated int
+	}{
+		"all migrated": {
+			expMigrated: 3,
+		},
+		"fail on error": {
+			srcPolicy:  MigrationFailOnError,
+			srcFailing: true,
+			expErr:     true,
+		},
+		"skip on error": {
+			srcPolicy:   MigrationSkipOnError,
+			srcFailing:  true,
+			expSkipped:  1,
+			expMigrated: 2,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			k := keepers.WasmKeeper
+			example := StoreRandomContract(t, ctx, keepers, &mock)
+			var contracts []sdk.AccAddress
+			for i := 0; i < 3; i++ {
+				contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "testing", nil)
+				require.NoError(t, err)
+				contracts = append(contracts, contractAddr)
+			}
+			newCodeID := StoreRandomContract(t, ctx, keepers, &mock).CodeID
+			failingContract := contracts[1].String()
+			mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+				store.Set(myKey, myValue)
+				if spec.srcFailing && env.Contract.Address == failingContract {
+					return nil, 0, errors.New("testing")
+				}
+				return &wasmvmtypes.Response{}, 0, nil
+			}
+
+			// when
+			gotSkipped, gotErr := k.MigrateContractsByCode(ctx, example.CodeID, newCodeID, []byte(`{}`), spec.srcPolicy)
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Len(t, gotSkipped, spec.expSkipped)
+			var migrated int
+			for _, contractAddr := range contracts {
+				info := k.GetContractInfo(ctx, contractAddr)
+				if info.CodeID != newCodeID {
+					assert.Equal(t, example.CodeID, info.CodeID)
+					assert.Nil(t, k.QueryRaw(ctx, contractAddr, myKey))
+					continue
+				}
+				migrated++
+				assert.Equal(t, myValue, k.QueryRaw(ctx, contractAddr, myKey))
+			}
+			assert.Equal(t, spec.expMigrated, migrated)
+		})
+	}
+}
+
+func TestMigrateContractsByCodeUnknownCode(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+
+	_, err := keepers.WasmKeeper.MigrateContractsByCode(ctx, example.CodeID, example.CodeID+1, []byte(`{}`), MigrationFailOnError)
+	assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+	_, err = keepers.WasmKeeper.MigrateContractsByCode(ctx, example.CodeID+1, example.CodeID, []byte(`{}`), MigrationFailOnError)
+	assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+}
@@ -0,0 +1,287 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// wasm binary format constants, see https://webassembly.github.io/spec/core/binary/index.html
+const (
+	wasmSectionCustom   = 0
+	wasmSectionType     = 1
+	wasmSectionImport   = 2
+	wasmSectionFunction = 3
+	wasmSectionGlobal   = 6
+	wasmSectionCode     = 10
+
+	wasmImportFunc   = 0x00
+	wasmValTypeF32   = 0x7d
+	wasmValTypeF64   = 0x7c
+	wasmBlockTypeNil = 0x40
+
+	// wasmImportModule is the only module that contracts can import functions from
+	wasmImportModule = "env"
+)
+
+var wasmHeader = []byte("\x00asm\x01\x00\x00\x00")
+
+// validateWasmCode runs a static, deterministic validation pass over the uncompressed wasm byte code.
+// It rejects code that uses floating point types or instructions, imports anything other than functions
+// from the "env" module or has more than types.MaxWasmFunctions functions. The VM does a full validation
+// on compile, this pass ensures that the consensus critical rules are enforced by wasmd itself.
+func validateWasmCode(code []byte) error {
+	if !bytes.HasPrefix(code, wasmHeader) {
+		return sdkerrors.Wrap(types.ErrInvalid, "wasm header")
+	}
+	r := &wasmReader{bz: code[len(wasmHeader):]}
+	var functions uint64
+	for !r.done() {
+		id := r.byte()
+		section := &wasmReader{bz: r.bytes(r.u32())}
+		if r.err != nil {
+			return sdkerrors.Wrap(types.ErrInvalid, r.err.Error())
+		}
+		var err error
+		switch id {
+		case wasmSectionCustom:
+			continue
+		case wasmSectionType:
+			err = validateTypeSection(section)
+		case wasmSectionImport:
+			var imported uint64
+			imported, err = validateImportSection(section)
+			functions += imported
+		case wasmSectionFunction:
+			functions += uint64(section.u32())
+			err = section.err
+		case wasmSectionGlobal:
+			err = validateGlobalSection(section)
+		case wasmSectionCode:
+			err = validateCodeSection(section)
+		}
+		if err != nil {
+			return sdkerrors.Wrap(types.ErrInvalid, err.Error())
+		}
+	}
+	if functions > uint64(types.MaxWasmFunctions) {
+		return sdkerrors.Wrapf(types.ErrLimit, "wasm code has %d functions, max %d", functions, types.MaxWasmFunctions)
+	}
+	return nil
+}
+
+func validateTypeSection(r *wasmReader) error {
+	for i, n := uint32(0), r.u32(); i < n && r.err == nil; i++ {
+		if form := r.byte(); form != 0x60 {
+			return fmt.Errorf("unsupported type form: %#x", form)
+		}
+		// params and results
+		for j := 0; j < 2; j++ {
+			if err := validateValTypes(r.bytes(r.u32())); err != nil {
+				return err
+			}
+		}
+	}
+	return r.err
+}
+
+func validateImportSection(r *wasmReader) (uint64, error) {
+	var functions uint64
+	for i, n := uint32(0), r.u32(); i < n && r.err == nil; i++ {
+		module := string(r.bytes(r.u32()))
+		name := string(r.bytes(r.u32()))
+		if kind := r.byte(); kind != wasmImportFunc {
+			return 0, fmt.Errorf("import %s.%s: only function imports are allowed", module, name)
+		}
+		if module != wasmImportModule {
+			return 0, fmt.Errorf("import %s.%s: only imports from %q are allowed", module, name, wasmImportModule)
+		}
+		r.u32() // type index
+		functions++
+	}
+	return functions, r.err
+}
+
+func validateGlobalSection(r *wasmReader) error {
+	for i, n := uint32(0), r.u32(); i < n && r.err == nil; i++ {
+		if err := validateValTypes([]byte{r.byte()}); err != nil {
+			return err
+		}
+		r.byte() // mutability
+		if err := validateInstructions(r); err != nil {
+			return err
+		}
+	}
+	return r.err
+}
+
+func validateCodeSection(r *wasmReader) error {
+	for i, n := uint32(0), r.u32(); i < n && r.err == nil; i++ {
+		body := &wasmReader{bz: r.bytes(r.u32())}
+		for j, locals := uint32(0), body.u32(); j < locals && body.err == nil; j++ {
+			body.u32() // count
+			if err := validateValTypes([]byte{body.byte()}); err != nil {
+				return err
+			}
+		}
+		if body.err != nil {
+			return body.err
+		}
+		for !body.done() {
+			if err := validateInstructions(body); err != nil {
+				return err
+			}
+		}
+		if body.err != nil {
+			return body.err
+		}
+	}
+	return r.err
+}
+
+func validateValTypes(valTypes []byte) error {
+	for _, t := range valTypes {
+		if t == wasmValTypeF32 || t == wasmValTypeF64 {
+			return fmt.Errorf("float types are not supported")
+		}
+	}
+	return nil
+}
+
+// validateInstructions reads instructions until the end opcode of the current expression and rejects
+// floating point and unknown instructions.
+func validateInstructions(r *wasmReader) error {
+	depth := 0
+	for r.err == nil {
+		op := r.byte()
+		switch {
+		case op == 0x0b: // end
+			if depth == 0 {
+				return r.err
+			}
+			depth--
+		case op == 0x02, op == 0x03, op == 0x04: // block, loop, if
+			if t := r.byte(); t != wasmBlockTypeNil {
+				if t == wasmValTypeF32 || t == wasmValTypeF64 {
+					return fmt.Errorf("float types are not supported")
+				}
+				if t&0x80 != 0 { // multi byte type index
+					r.skipLEB()
+				}
+			}
+			depth++
+		case op == 0x00, op == 0x01, op == 0x05, op == 0x0f, op == 0x1a, op == 0x1b: // unreachable, nop, else, return, drop, select
+		case op == 0x0c, op == 0x0d, op == 0x10: // br, br_if, call
+			r.u32()
+		case op == 0x0e: // br_table
+			for i, n := uint32(0), r.u32(); i <= n && r.err == nil; i++ {
+				r.u32()
+			}
+		case op == 0x11: // call_indirect
+			r.u32()
+			r.u32()
+		case op >= 0x20 && op <= 0x24: // local and global access
+			r.u32()
+		case op == 0x2a, op == 0x2b, op == 0x38, op == 0x39, // float load and store
+			op == 0x43, op == 0x44, // float const
+			op >= 0x5b && op <= 0x66, // float comparison
+			op >= 0x8b && op <= 0xbf && op != 0xa7 && op != 0xac && op != 0xad: // float arithmetic and conversion
+			return fmt.Errorf("float instruction %#x is not supported", op)
+		case op >= 0x28 && op <= 0x3e: // integer load and store with memarg
+			r.u32()
+			r.u32()
+		case op == 0x3f, op == 0x40: // memory.size, memory.grow
+			r.byte()
+		case op == 0x41, op == 0x42: // i32.const, i64.const
+			r.skipLEB()
+		case op >= 0x45 && op <= 0x5a, op >= 0x67 && op <= 0x8a, op == 0xa7, op == 0xac, op == 0xad, op >= 0xc0 && op <= 0xc4:
+			// integer instructions and sign extension without immediates
+		case op == 0xfc:
+			sub := r.u32()
+			switch {
+			case sub <= 7: // saturating float to int conversion
+				return fmt.Errorf("float instruction 0xfc %d is not supported", sub)
+			case sub == 8, sub == 12, sub == 14: // memory.init, table.init, table.copy
+				r.u32()
+				r.u32()
+			case sub == 10: // memory.copy
+				r.byte()
+				r.byte()
+			case sub == 9, sub == 11, sub == 13, sub >= 15 && sub <= 17: // data.drop, memory.fill, elem.drop, table ops
+				r.u32()
+			default:
+				return fmt.Errorf("unsupported instruction 0xfc %d", sub)
+			}
+		default:
+			return fmt.Errorf("unsupported instruction %#x", op)
+		}
+	}
+	return r.err
+}
+
+// wasmReader reads the wasm binary format. The first error is kept and all following reads return
+// zero values.
+type wasmReader struct {
+	bz  []byte
+	err error
+}
+
+func (r *wasmReader) done() bool {
+	return r.err != nil || len(r.bz) == 0
+}
+
+func (r *wasmReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.bz) == 0 {
+		r.err = fmt.Errorf("unexpected end of wasm code")
+		return 0
+	}
+	b := r.bz[0]
+	r.bz = r.bz[1:]
+	return b
+}
+
+func (r *wasmReader) bytes(n uint32) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if uint64(len(r.bz)) < uint64(n) {
+		r.err = fmt.Errorf("unexpected end of wasm code")
+		return nil
+	}
+	bz := r.bz[:n]
+	r.bz = r.bz[n:]
+	return bz
+}
+
+// u32 reads an unsigned LEB128 encoded 32 bit integer
+func (r *wasmReader) u32() uint32 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.bz)
+	if n <= 0 || n > 5 || v > 0xffffffff {
+		r.err = fmt.Errorf("invalid integer encoding")
+		return 0
+	}
+	r.bz = r.bz[n:]
+	return uint32(v)
+}
+
+// skipLEB skips a signed or unsigned LEB128 encoded integer of up to 64 bit
+func (r *wasmReader) skipLEB() {
+	for i := 0; i < 10; i++ {
+		if r.byte()&0x80 == 0 {
+			return
+		}
+	}
+	if r.err == nil {
+		r.err = fmt.Errorf("invalid integer encoding")
+	}
+}
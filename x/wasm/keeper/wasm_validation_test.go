@@ -0,0 +1,134 @@
+package keeper
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestValidateWasmCode(t *testing.T) {
+	section := func(id byte, content ...byte) []byte {
+		return append([]byte{id, byte(len(content))}, content...)
+	}
+	module := func(sections ...[]byte) []byte {
+		r := []byte("\x00asm\x01\x00\x00\x00")
+		for _, s := range sections {
+			r = append(r, s...)
+		}
+		return r
+	}
+	// a single function without params and results
+	funcType := section(wasmSectionType, 0x01, 0x60, 0x00, 0x00)
+	oneFunc := section(wasmSectionFunction, 0x01, 0x00)
+	codeWithBody := func(body ...byte) []byte {
+		return section(wasmSectionCode, append([]byte{0x01, byte(len(body))}, body...)...)
+	}
+
+	specs := map[string]struct {
+		src    []byte
+		expErr *sdkerrors.Error
+	}{
+		"empty module": {
+			src: module(),
+		},
+		"int instructions": {
+			// no locals, i32.const 1, drop, end
+			src: module(funcType, oneFunc, codeWithBody(0x00, 0x41, 0x01, 0x1a, 0x0b)),
+		},
+		"nested blocks": {
+			// no locals, block, loop, br 1, end, end, end
+			src: module(funcType, oneFunc, codeWithBody(0x00, 0x02, 0x40, 0x03, 0x40, 0x0c, 0x01, 0x0b, 0x0b, 0x0b)),
+		},
+		"env function import": {
+			src: module(funcType, section(wasmSectionImport, 0x01, 0x03, 'e', 'n', 'v', 0x01, 'f', wasmImportFunc, 0x00)),
+		},
+		"custom section": {
+			src: module(section(wasmSectionCustom, 0x01, 'x', 0xff)),
+		},
+		"invalid header": {
+			src:    []byte("\x00asm\x02\x00\x00\x00"),
+			expErr: types.ErrInvalid,
+		},
+		"truncated section": {
+			src:    module(section(wasmSectionType, 0x01, 0x60))[:11],
+			expErr: types.ErrInvalid,
+		},
+		"truncated function body": {
+			// 2 local declarations of i32 but only one given
+			src:    module(funcType, oneFunc, codeWithBody(0x02, 0x01, 0x7f)),
+			expErr: types.ErrInvalid,
+		},
+		"float param": {
+			src:    module(section(wasmSectionType, 0x01, 0x60, 0x01, wasmValTypeF64, 0x00)),
+			expErr: types.ErrInvalid,
+		},
+		"float local": {
+			src:    module(funcType, oneFunc, codeWithBody(0x01, 0x01, wasmValTypeF32, 0x0b)),
+			expErr: types.ErrInvalid,
+		},
+		"float const": {
+			// no locals, f64.const 0, drop, end
+			src:    module(funcType, oneFunc, codeWithBody(0x00, 0x44, 0, 0, 0, 0, 0, 0, 0, 0, 0x1a, 0x0b)),
+			expErr: types.ErrInvalid,
+		},
+		"float conversion": {
+			// no locals, i32.const 1, f32.convert_i32_s, drop, end
+			src:    module(funcType, oneFunc, codeWithBody(0x00, 0x41, 0x01, 0xb2, 0x1a, 0x0b)),
+			expErr: types.ErrInvalid,
+		},
+		"saturating float conversion": {
+			src:    module(funcType, oneFunc, codeWithBody(0x00, 0xfc, 0x00, 0x0b)),
+			expErr: types.ErrInvalid,
+		},
+		"simd instruction": {
+			src:    module(funcType, oneFunc, codeWithBody(0x00, 0xfd, 0x00, 0x0b)),
+			expErr: types.ErrInvalid,
+		},
+		"import from other module": {
+			src:    module(funcType, section(wasmSectionImport, 0x01, 0x03, 'f', 'o', 'o', 0x01, 'f', wasmImportFunc, 0x00)),
+			expErr: types.ErrInvalid,
+		},
+		"memory import": {
+			src:    module(section(wasmSectionImport, 0x01, 0x03, 'e', 'n', 'v', 0x01, 'm', 0x02, 0x00, 0x01)),
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateWasmCode(spec.src)
+			if spec.expErr == nil {
+				require.NoError(t, gotErr)
+				return
+			}
+			assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+		})
+	}
+}
+
+func TestValidateWasmCodeMaxFunctions(t *testing.T) {
+	defer func(old int) { types.MaxWasmFunctions = old }(types.MaxWasmFunctions)
+	types.MaxWasmFunctions = 1
+	// two imported functions
+	src := []byte("\x00asm\x01\x00\x00\x00\x01\x04\x01\x60\x00\x00\x02\x11\x02\x03env\x01a\x00\x00\x03env\x01b\x00\x00")
+	gotErr := validateWasmCode(src)
+	assert.True(t, types.ErrLimit.Is(gotErr), "got %+v", gotErr)
+}
+
+func TestValidateWasmCodeAcceptsTestContracts(t *testing.T) {
+	files, err := filepath.Glob("./testdata/*.wasm")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, f := range files {
+		t.Run(filepath.Base(f), func(t *testing.T) {
+			code, err := ioutil.ReadFile(f)
+			require.NoError(t, err)
+			assert.NoError(t, validateWasmCode(code))
+		})
+	}
+}
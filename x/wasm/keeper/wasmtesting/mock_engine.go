@@ -290,6 +290,20 @@ type contractExecutable interface {
 	) (*wasmvmtypes.Response, uint64, error)
 }
 
+type contractSudoable interface {
+	Sudo(
+		codeID wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		sudoMsg []byte,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.Response, uint64, error)
+}
+
 // MakeInstantiable adds some noop functions to not fail when contract is used for instantiation
 func MakeInstantiable(m *MockWasmer) {
 	m.CreateFn = HashOnlyCreateFn
@@ -320,6 +334,9 @@ func NewIBCContractMockWasmer(c IBCContractCallbacks) *MockWasmer {
 	if e, ok := c.(contractExecutable); ok { // optional function
 		m.ExecuteFn = e.Execute
 	}
+	if e, ok := c.(contractSudoable); ok { // optional function
+		m.SudoFn = e.Sudo
+	}
 	return m
 }
 
@@ -1,6 +1,7 @@
 package wasmtesting
 
 import (
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
 	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
@@ -110,3 +111,93 @@ func (m MockIBCTransferKeeper) GetPort(ctx sdk.Context) string {
 	}
 	return m.GetPortFn(ctx)
 }
+
+var _ types.IBCContractKeeper = &MockIBCContractKeeper{}
+
+type MockIBCContractKeeper struct {
+	OnOpenChannelFn          func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelOpenMsg) error
+	OnConnectChannelFn       func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelConnectMsg) error
+	OnCloseChannelFn         func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelCloseMsg) error
+	OnRecvPacketFn           func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCPacketReceiveMsg) ([]byte, error)
+	OnAckPacketFn            func(ctx sdk.Context, contractAddr sdk.AccAddress, acknowledgement wasmvmtypes.IBCPacketAckMsg) error
+	OnTimeoutPacketFn        func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCPacketTimeoutMsg) error
+	ClaimCapabilityFn        func(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error
+	AuthenticateCapabilityFn func(ctx sdk.Context, cap *capabilitytypes.Capability, name string) bool
+}
+
+func (m *MockIBCContractKeeper) OnOpenChannel(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelOpenMsg) error {
+	if m.OnOpenChannelFn == nil {
+		panic("not expected to be called")
+	}
+	return m.OnOpenChannelFn(ctx, contractAddr, msg)
+}
+
+func (m *MockIBCContractKeeper) OnConnectChannel(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelConnectMsg) error {
+	if m.OnConnectChannelFn == nil {
+		panic("not expected to be called")
+	}
+	return m.OnConnectChannelFn(ctx, contractAddr, msg)
+}
+
+func (m *MockIBCContractKeeper) OnCloseChannel(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelCloseMsg) error {
+	if m.OnCloseChannelFn == nil {
+		panic("not expected to be called")
+	}
+	return m.OnCloseChannelFn(ctx, contractAddr, msg)
+}
+
+func (m *MockIBCContractKeeper) OnRecvPacket(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCPacketReceiveMsg) ([]byte, error) {
+	if m.OnRecvPacketFn == nil {
+		panic("not expected to be called")
+	}
+	return m.OnRecvPacketFn(ctx, contractAddr, msg)
+}
+
+func (m *MockIBCContractKeeper) OnAckPacket(ctx sdk.Context, contractAddr sdk.AccAddress, acknowledgement wasmvmtypes.IBCPacketAckMsg) error {
+	if m.OnAckPacketFn == nil {
+		panic("not expected to be called")
+	}
+	return m.OnAckPacketFn(ctx, contractAddr, acknowledgement)
+}
+
+func (m *MockIBCContractKeeper) OnTimeoutPacket(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCPacketTimeoutMsg) error {
+	if m.OnTimeoutPacketFn == nil {
+		panic("not expected to be called")
+	}
+	return m.OnTimeoutPacketFn(ctx, contractAddr, msg)
+}
+
+func (m *MockIBCContractKeeper) ClaimCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error {
+	if m.ClaimCapabilityFn == nil {
+		panic("not expected to be called")
+	}
+	return m.ClaimCapabilityFn(ctx, cap, name)
+}
+
+func (m *MockIBCContractKeeper) AuthenticateCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) bool {
+	if m.AuthenticateCapabilityFn == nil {
+		panic("not expected to be called")
+	}
+	return m.AuthenticateCapabilityFn(ctx, cap, name)
+}
+
+var _ types.ICS20CallbackKeeper = &MockICS20CallbackKeeper{}
+
+type MockICS20CallbackKeeper struct {
+	HasContractCapabilityFn func(ctx sdk.Context, contractAddr sdk.AccAddress, capability string) bool
+	SudoFn                  func(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error)
+}
+
+func (m *MockICS20CallbackKeeper) HasContractCapability(ctx sdk.Context, contractAddr sdk.AccAddress, capability string) bool {
+	if m.HasContractCapabilityFn == nil {
+		panic("not expected to be called")
+	}
+	return m.HasContractCapabilityFn(ctx, contractAddr, capability)
+}
+
+func (m *MockICS20CallbackKeeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
+	if m.SudoFn == nil {
+		panic("not expected to be called")
+	}
+	return m.SudoFn(ctx, contractAddress, msg)
+}
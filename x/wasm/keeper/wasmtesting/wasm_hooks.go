@@ -0,0 +1,36 @@
+package wasmtesting
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+var _ types.WasmHooks = &MockWasmHooks{}
+
+type MockWasmHooks struct {
+	AfterContractInstantiatedFn func(ctx sdk.Context, contractAddr, creator sdk.AccAddress, codeID uint64) error
+	AfterContractExecutedFn     func(ctx sdk.Context, contractAddr, caller sdk.AccAddress, msg []byte, funds sdk.Coins) error
+	AfterContractMigratedFn     func(ctx sdk.Context, contractAddr sdk.AccAddress, oldCodeID, newCodeID uint64) error
+}
+
+func (m *MockWasmHooks) AfterContractInstantiated(ctx sdk.Context, contractAddr, creator sdk.AccAddress, codeID uint64) error {
+	if m.AfterContractInstantiatedFn == nil {
+		panic("not expected to be called")
+	}
+	return m.AfterContractInstantiatedFn(ctx, contractAddr, creator, codeID)
+}
+
+func (m *MockWasmHooks) AfterContractExecuted(ctx sdk.Context, contractAddr, caller sdk.AccAddress, msg []byte, funds sdk.Coins) error {
+	if m.AfterContractExecutedFn == nil {
+		panic("not expected to be called")
+	}
+	return m.AfterContractExecutedFn(ctx, contractAddr, caller, msg, funds)
+}
+
+func (m *MockWasmHooks) AfterContractMigrated(ctx sdk.Context, contractAddr sdk.AccAddress, oldCodeID, newCodeID uint64) error {
+	if m.AfterContractMigratedFn == nil {
+		panic("not expected to be called")
+	}
+	return m.AfterContractMigratedFn(ctx, contractAddr, oldCodeID, newCodeID)
+}
@@ -34,9 +34,13 @@ var (
 
 // Module init related flags
 const (
-	flagWasmMemoryCacheSize    = "wasm.memory_cache_size"
-	flagWasmQueryGasLimit      = "wasm.query_gas_limit"
-	flagWasmSimulationGasLimit = "wasm.simulation_gas_limit"
+	flagWasmMemoryCacheSize     = "wasm.memory_cache_size"
+	flagWasmQueryGasLimit       = "wasm.query_gas_limit"
+	flagWasmSimulationGasLimit  = "wasm.simulation_gas_limit"
+	flagWasmMetricsContracts    = "wasm.metrics_contracts"
+	flagWasmContractDebugMode   = "wasm.contract_debug_mode"
+	flagWasmSmartQueryDisabled  = "wasm.smart_query_disabled"
+	flagWasmSmartQueryRateLimit = "wasm.smart_query_rate_limit"
 )
 
 // AppModuleBasic defines the basic application module used by the wasm module.
@@ -47,7 +51,10 @@ func (b AppModuleBasic) RegisterLegacyAminoCodec(amino *codec.LegacyAmino) { //n
 }
 
 func (b AppModuleBasic) RegisterGRPCGatewayRoutes(clientCtx client.Context, serveMux *runtime.ServeMux) {
-	err := types.RegisterQueryHandlerClient(context.Background(), serveMux, types.NewQueryClient(clientCtx))
+	queryClient := types.NewQueryClient(clientCtx)
+	// register before the generated handlers to accept URL-safe base64 in contract state queries
+	types.RegisterContractStateHandlerClient(serveMux, queryClient)
+	err := types.RegisterQueryHandlerClient(context.Background(), serveMux, queryClient)
 	if err != nil {
 		panic(err)
 	}
@@ -112,7 +119,7 @@ type AppModule struct {
 // module. It should be incremented on each consensus-breaking change
 // introduced by the module. To avoid wrong/empty versions, the initial version
 // should be set to 1.
-func (AppModule) ConsensusVersion() uint64 { return 1 }
+func (AppModule) ConsensusVersion() uint64 { return 8 }
 
 // NewAppModule creates a new AppModule object
 func NewAppModule(
@@ -133,12 +140,42 @@ func NewAppModule(
 }
 
 func (am AppModule) RegisterServices(cfg module.Configurator) {
-	types.RegisterMsgServer(cfg.MsgServer(), keeper.NewMsgServerImpl(keeper.NewDefaultPermissionKeeper(am.keeper)))
+	types.RegisterMsgServer(cfg.MsgServer(), keeper.NewMsgServerImpl(keeper.NewDefaultPermissionKeeper(am.keeper), am.keeper))
 	types.RegisterQueryServer(cfg.QueryServer(), NewQuerier(am.keeper))
+
+	m := keeper.NewMigrator(*am.keeper)
+	err := cfg.RegisterMigration(types.ModuleName, 1, m.Migrate1to2)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 2, m.Migrate2to3)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 3, m.Migrate3to4)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 4, m.Migrate4to5)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 5, m.Migrate5to6)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 6, m.Migrate6to7)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 7, m.Migrate7to8)
+	if err != nil {
+		panic(err)
+	}
 }
 
 func (am AppModule) LegacyQuerierHandler(amino *codec.LegacyAmino) sdk.Querier { //nolint:staticcheck
-	return keeper.NewLegacyQuerier(am.keeper, am.keeper.QueryGasLimit())
+	return keeper.LegacyQuerier(am.keeper)
 }
 
 // RegisterInvariants registers the wasm module invariants.
@@ -146,7 +183,7 @@ func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {}
 
 // Route returns the message routing key for the wasm module.
 func (am AppModule) Route() sdk.Route {
-	return sdk.NewRoute(RouterKey, NewHandler(keeper.NewDefaultPermissionKeeper(am.keeper)))
+	return sdk.NewRoute(RouterKey, NewHandler(keeper.NewDefaultPermissionKeeper(am.keeper), am.keeper))
 }
 
 // QuerierRoute returns the wasm module's querier route name.
@@ -173,12 +210,15 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 	return cdc.MustMarshalJSON(gs)
 }
 
-// BeginBlock returns the begin blocker for the wasm module.
-func (am AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) {}
+// BeginBlock returns the begin blocker for the wasm module. It calls the cron contracts.
+func (am AppModule) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) {
+	am.keeper.BeginBlocker(ctx)
+}
 
-// EndBlock returns the end blocker for the wasm module. It returns no validator
-// updates.
-func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+// EndBlock returns the end blocker for the wasm module. It calls the cron contracts and
+// returns no validator updates.
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	am.keeper.EndBlocker(ctx)
 	return []abci.ValidatorUpdate{}
 }
 
@@ -218,6 +258,10 @@ func AddModuleInitFlags(startCmd *cobra.Command) {
 	startCmd.Flags().Uint32(flagWasmMemoryCacheSize, defaults.MemoryCacheSize, "Sets the size in MiB (NOT bytes) of an in-memory cache for Wasm modules. Set to 0 to disable.")
 	startCmd.Flags().Uint64(flagWasmQueryGasLimit, defaults.SmartQueryGasLimit, "Set the max gas that can be spent on executing a query with a Wasm contract")
 	startCmd.Flags().String(flagWasmSimulationGasLimit, "", "Set the max gas that can be spent when executing a simulation TX")
+	startCmd.Flags().Bool(flagWasmContractDebugMode, defaults.ContractDebugMode, "Print the output of contract debug calls and log each contract call. Do not use in production")
+	startCmd.Flags().StringSlice(flagWasmMetricsContracts, nil, "Set the contract addresses that are labeled individually in the VM call metrics")
+	startCmd.Flags().Bool(flagWasmSmartQueryDisabled, defaults.SmartQueryDisabled, "Reject smart contract state queries on the query server of this node")
+	startCmd.Flags().Uint32(flagWasmSmartQueryRateLimit, defaults.SmartQueryRateLimit, "Set the max number of smart queries per second and client on the gRPC query server. Set to 0 to disable")
 }
 
 // ReadWasmConfig reads the wasm specifig configuration
@@ -235,19 +279,41 @@ func ReadWasmConfig(opts servertypes.AppOptions) (types.WasmConfig, error) {
 		}
 	}
 	if v := opts.Get(flagWasmSimulationGasLimit); v != nil {
-		if raw, ok := v.(string); ok && raw != "" {
-			limit, err := cast.ToUint64E(v) // non empty string set
+		if raw, ok := v.(string); !ok || raw != "" {
+			limit, err := cast.ToUint64E(v) // non empty string or number set
 			if err != nil {
 				return cfg, err
 			}
 			cfg.SimulationGasLimit = &limit
 		}
 	}
-	// attach contract debugging to global "trace" flag
-	if v := opts.Get(server.FlagTrace); v != nil {
+	if v := opts.Get(flagWasmMetricsContracts); v != nil {
+		if cfg.MetricsContracts, err = cast.ToStringSliceE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmContractDebugMode); v != nil {
 		if cfg.ContractDebugMode, err = cast.ToBoolE(v); err != nil {
 			return cfg, err
 		}
 	}
+	if v := opts.Get(flagWasmSmartQueryDisabled); v != nil {
+		if cfg.SmartQueryDisabled, err = cast.ToBoolE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmSmartQueryRateLimit); v != nil {
+		if cfg.SmartQueryRateLimit, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
+	// contract debugging is also enabled with the global "trace" flag
+	if v := opts.Get(server.FlagTrace); v != nil {
+		trace, err := cast.ToBoolE(v)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.ContractDebugMode = cfg.ContractDebugMode || trace
+	}
 	return cfg, nil
 }
@@ -1,12 +1,14 @@
 package wasm
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
@@ -100,6 +102,15 @@ func TestHandleCreate(t *testing.T) {
 			},
 			isValid: true,
 		},
+		"valid wasm with source and builder": {
+			msg: &MsgStoreCode{
+				Sender:       addr1,
+				WASMByteCode: testContract,
+				Source:       "https://github.com/CosmWasm/cosmwasm/tree/v1.0.0/contracts/hackatom",
+				Builder:      "cosmwasm/rust-optimizer:0.12.6",
+			},
+			isValid: true,
+		},
 		"old wasm (0.7)": {
 			msg: &MsgStoreCode{
 				Sender:       addr1,
@@ -126,6 +137,13 @@ func TestHandleCreate(t *testing.T) {
 			}
 			require.NoError(t, err)
 			assertCodeList(t, q, data.ctx, 1)
+			var resp MsgStoreCodeResponse
+			require.NoError(t, resp.Unmarshal(res.Data))
+			expChecksum := sha256.Sum256(tc.msg.(*MsgStoreCode).WASMByteCode)
+			assert.Equal(t, expChecksum[:], resp.Checksum)
+			codeInfo := data.keeper.GetCodeInfo(data.ctx, resp.CodeID)
+			assert.Equal(t, tc.msg.(*MsgStoreCode).Source, codeInfo.Source)
+			assert.Equal(t, tc.msg.(*MsgStoreCode).Builder, codeInfo.Builder)
 		})
 	}
 }
@@ -170,6 +188,7 @@ func TestHandleInstantiate(t *testing.T) {
 	initCmd := MsgInstantiateContract{
 		Sender: creator.String(),
 		CodeID: firstCodeID,
+		Label:  "testing",
 		Msg:    initMsgBz,
 		Funds:  nil,
 	}
@@ -198,6 +217,116 @@ func TestHandleInstantiate(t *testing.T) {
 	})
 }
 
+func TestHandleStoreAndInstantiate(t *testing.T) {
+	data := setupTest(t)
+	creator := data.faucet.NewFundedAccount(data.ctx, sdk.NewInt64Coin("denom", 100000))
+
+	h := data.module.Route().Handler()
+	q := data.module.LegacyQuerierHandler(nil)
+
+	_, _, bob := keyPubAddr()
+	_, _, fred := keyPubAddr()
+	initMsgBz, err := json.Marshal(initMsg{Verifier: fred, Beneficiary: bob})
+	require.NoError(t, err)
+
+	msg := &types.MsgStoreAndInstantiateContract{
+		Sender:       creator.String(),
+		WASMByteCode: testContract,
+		Admin:        creator.String(),
+		Label:        "testing",
+		Msg:          initMsgBz,
+		Funds:        sdk.NewCoins(sdk.NewInt64Coin("denom", 100)),
+	}
+	res, err := h(data.ctx, msg)
+	require.NoError(t, err)
+
+	var pResp types.MsgStoreAndInstantiateContractResponse
+	require.NoError(t, pResp.Unmarshal(res.Data))
+	assert.Equal(t, uint64(firstCodeID), pResp.CodeID)
+	assert.Equal(t, data.keeper.GetCodeInfo(data.ctx, firstCodeID).CodeHash, pResp.Checksum)
+	require.Equal(t, "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr", pResp.Address)
+
+	var gotEventTypes []string
+	for _, e := range res.Events {
+		gotEventTypes = append(gotEventTypes, e.Type)
+	}
+	expEventTypes := []string{"message", "store_code", "coin_spent", "coin_received", "transfer", "instantiate", "wasm"}
+	assert.Equal(t, expEventTypes, gotEventTypes, prettyEvents(res.Events))
+
+	assertCodeList(t, q, data.ctx, 1)
+	assertCodeBytes(t, q, data.ctx, 1, testContract)
+	assertContractList(t, q, data.ctx, 1, []string{pResp.Address})
+	assertContractInfo(t, q, data.ctx, pResp.Address, 1, creator)
+	assertContractState(t, q, data.ctx, pResp.Address, state{
+		Verifier:    fred.String(),
+		Beneficiary: bob.String(),
+		Funder:      creator.String(),
+	})
+}
+
+func TestHandleStoreAndMigrate(t *testing.T) {
+	data := setupTest(t)
+	creator := data.faucet.NewFundedAccount(data.ctx, sdk.NewInt64Coin("denom", 100000))
+	other := data.faucet.NewFundedAccount(data.ctx, sdk.NewInt64Coin("denom", 100000))
+
+	h := data.module.Route().Handler()
+	_, err := h(data.ctx, &MsgStoreCode{Sender: creator.String(), WASMByteCode: testContract})
+	require.NoError(t, err)
+
+	_, _, bob := keyPubAddr()
+	_, _, fred := keyPubAddr()
+	initMsgBz, err := json.Marshal(initMsg{Verifier: fred, Beneficiary: bob})
+	require.NoError(t, err)
+	instantiate := func(admin sdk.AccAddress) string {
+		res, err := h(data.ctx, &MsgInstantiateContract{
+			Sender: creator.String(),
+			Admin:  admin.String(),
+			CodeID: firstCodeID,
+			Label:  "testing",
+			Msg:    initMsgBz,
+		})
+		require.NoError(t, err)
+		return parseInitResponse(t, res.Data)
+	}
+	myContracts := []string{instantiate(creator), instantiate(creator)}
+	otherContract := instantiate(other)
+
+	migMsgBz, err := json.Marshal(map[string]string{"verifier": bob.String()})
+	require.NoError(t, err)
+
+	// when sender is not admin of all contracts
+	ctx, _ := data.ctx.CacheContext()
+	_, err = h(ctx, &types.MsgStoreAndMigrateContract{
+		Sender:       creator.String(),
+		WASMByteCode: testContract,
+		Contracts:    append(myContracts, otherContract),
+		Msg:          migMsgBz,
+	})
+	// then
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+
+	// when sender is admin of all contracts
+	res, err := h(data.ctx, &types.MsgStoreAndMigrateContract{
+		Sender:       creator.String(),
+		WASMByteCode: testContract,
+		Contracts:    myContracts,
+		Msg:          migMsgBz,
+	})
+	// then
+	require.NoError(t, err)
+	var pResp types.MsgStoreAndMigrateContractResponse
+	require.NoError(t, pResp.Unmarshal(res.Data))
+	const expCodeID = firstCodeID + 1
+	assert.Equal(t, uint64(expCodeID), pResp.CodeID)
+	assert.Equal(t, data.keeper.GetCodeInfo(data.ctx, expCodeID).CodeHash, pResp.Checksum)
+	assert.Len(t, pResp.Data, len(myContracts))
+	for _, c := range myContracts {
+		contractAddr, err := sdk.AccAddressFromBech32(c)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(expCodeID), data.keeper.GetContractInfo(data.ctx, contractAddr).CodeID)
+	}
+}
+
 func TestHandleExecute(t *testing.T) {
 	data := setupTest(t)
 
@@ -229,6 +358,7 @@ func TestHandleExecute(t *testing.T) {
 	initCmd := MsgInstantiateContract{
 		Sender: creator.String(),
 		CodeID: firstCodeID,
+		Label:  "testing",
 		Msg:    initMsgBz,
 		Funds:  deposit,
 	}
@@ -362,6 +492,7 @@ func TestHandleExecuteEscrow(t *testing.T) {
 	initCmd := MsgInstantiateContract{
 		Sender: creator.String(),
 		CodeID: firstCodeID,
+		Label:  "testing",
 		Msg:    initMsgBz,
 		Funds:  deposit,
 	}
@@ -400,6 +531,58 @@ func TestHandleExecuteEscrow(t *testing.T) {
 	assert.Equal(t, sdk.Coins{}, data.bankKeeper.GetAllBalances(data.ctx, contractAcct.GetAddress()))
 }
 
+func TestHandleDeniedFundsDenoms(t *testing.T) {
+	data := setupTest(t)
+	params := data.keeper.GetParams(data.ctx)
+	params.DeniedFundsDenoms = []string{"locked"}
+	data.keeper.SetParams(data.ctx, params)
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
+	locked := sdk.NewCoins(sdk.NewInt64Coin("locked", 100))
+	creator := data.faucet.NewFundedAccount(data.ctx, deposit.Add(locked...)...)
+
+	h := data.module.Route().Handler()
+	_, err := h(data.ctx, &MsgStoreCode{Sender: creator.String(), WASMByteCode: testContract})
+	require.NoError(t, err)
+
+	_, _, bob := keyPubAddr()
+	initMsgBz, err := json.Marshal(map[string]interface{}{
+		"verifier":    creator.String(),
+		"beneficiary": bob.String(),
+	})
+	require.NoError(t, err)
+
+	// when instantiated with a denied denom
+	initCmd := MsgInstantiateContract{
+		Sender: creator.String(),
+		CodeID: firstCodeID,
+		Label:  "testing",
+		Msg:    initMsgBz,
+		Funds:  deposit.Add(locked...),
+	}
+	_, err = h(data.ctx, &initCmd)
+	// then
+	assert.True(t, types.ErrDeniedFundsDenom.Is(err), "got %+v", err)
+
+	// when instantiated with allowed denoms only
+	initCmd.Funds = deposit
+	res, err := h(data.ctx, &initCmd)
+	require.NoError(t, err)
+	contractBech32Addr := parseInitResponse(t, res.Data)
+
+	// when executed with a denied denom
+	execCmd := MsgExecuteContract{
+		Sender:   creator.String(),
+		Contract: contractBech32Addr,
+		Msg:      []byte(`{"release":{}}`),
+		Funds:    locked,
+	}
+	_, err = h(data.ctx, &execCmd)
+	// then
+	assert.True(t, types.ErrDeniedFundsDenom.Is(err), "got %+v", err)
+	assert.Equal(t, locked, data.bankKeeper.GetAllBalances(data.ctx, creator))
+}
+
 func TestReadWasmConfig(t *testing.T) {
 	defaults := DefaultWasmConfig()
 	specs := map[string]struct {
@@ -424,6 +607,77 @@ func TestReadWasmConfig(t *testing.T) {
 				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
 			},
 		},
+		"set simulation gas limit via opts": {
+			src: AppOptionsMock{
+				"wasm.simulation_gas_limit": 3,
+			},
+			exp: types.WasmConfig{
+				SimulationGasLimit: func() *uint64 { v := uint64(3); return &v }(),
+				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:    defaults.MemoryCacheSize,
+			},
+		},
+		"set simulation gas limit via string opts": {
+			src: AppOptionsMock{
+				"wasm.simulation_gas_limit": "3",
+			},
+			exp: types.WasmConfig{
+				SimulationGasLimit: func() *uint64 { v := uint64(3); return &v }(),
+				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:    defaults.MemoryCacheSize,
+			},
+		},
+		"set metrics contracts via opts": {
+			src: AppOptionsMock{
+				"wasm.metrics_contracts": []string{"cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"},
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:    defaults.MemoryCacheSize,
+				MetricsContracts:   []string{"cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"},
+			},
+		},
+		"set contract debug mode via opts": {
+			src: AppOptionsMock{
+				"wasm.contract_debug_mode": true,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:    defaults.MemoryCacheSize,
+				ContractDebugMode:  true,
+			},
+		},
+		"trace does not disable contract debug mode": {
+			src: AppOptionsMock{
+				"wasm.contract_debug_mode": true,
+				"trace":                    false,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:    defaults.MemoryCacheSize,
+				ContractDebugMode:  true,
+			},
+		},
+		"disable smart queries via opts": {
+			src: AppOptionsMock{
+				"wasm.smart_query_disabled": true,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:    defaults.MemoryCacheSize,
+				SmartQueryDisabled: true,
+			},
+		},
+		"set smart query rate limit via opts": {
+			src: AppOptionsMock{
+				"wasm.smart_query_rate_limit": 5,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit:  defaults.SmartQueryGasLimit,
+				MemoryCacheSize:     defaults.MemoryCacheSize,
+				SmartQueryRateLimit: 5,
+			},
+		},
 		"set debug via opts": {
 			src: AppOptionsMock{
 				"trace": true,
@@ -0,0 +1,101 @@
+package wasm_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+	"testing"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	wasmibctesting "github.com/CosmWasm/wasmd/x/wasm/ibctesting"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestQueryContractStateAtHeight(t *testing.T) {
+	// scenario: given a contract that was migrated to a new verifier
+	//           when the contract state is queried via gRPC with the block height header
+	//           then the results reflect the state at that height
+	var (
+		coord = wasmibctesting.NewCoordinator(t, 1)
+		chain = coord.GetChain(wasmibctesting.GetChainID(0))
+	)
+	codeID := chain.StoreCodeFile("./keeper/testdata/hackatom.wasm").CodeID
+	myAddr := chain.SenderAccount.GetAddress()
+	initMsg, err := json.Marshal(keeper.HackatomExampleInitMsg{Verifier: myAddr, Beneficiary: myAddr})
+	require.NoError(t, err)
+	contractAddr := chain.InstantiateContract(codeID, initMsg)
+	coord.CommitBlock(chain)
+	heightBeforeMigration := chain.App.LastBlockHeight()
+
+	newVerifier := keeper.RandomAccountAddress(t)
+	_, err = chain.SendMsgs(&types.MsgMigrateContract{
+		Sender:   myAddr.String(),
+		Contract: contractAddr.String(),
+		CodeID:   codeID,
+		Msg:      []byte(`{"verifier":"` + newVerifier.String() + `"}`),
+	})
+	require.NoError(t, err)
+	coord.CommitBlock(chain)
+
+	// start a gRPC server for the app
+	listener := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	chain.GetTestSupport().GetBaseApp().RegisterGRPCServer(srv)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	queryClient := types.NewQueryClient(conn)
+
+	specs := map[string]struct {
+		height      int64
+		expVerifier string
+	}{
+		"latest height": {
+			expVerifier: newVerifier.String(),
+		},
+		"before migration": {
+			height:      heightBeforeMigration,
+			expVerifier: myAddr.String(),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if spec.height != 0 {
+				ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(spec.height, 10))
+			}
+			// smart query
+			smartRsp, err := queryClient.SmartContractState(ctx, &types.QuerySmartContractStateRequest{
+				Address:   contractAddr.String(),
+				QueryData: []byte(`{"verifier":{}}`),
+			})
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"verifier":"`+spec.expVerifier+`"}`, string(smartRsp.Data))
+
+			// raw query
+			rawRsp, err := queryClient.RawContractState(ctx, &types.QueryRawContractStateRequest{
+				Address:   contractAddr.String(),
+				QueryData: []byte("config"),
+			})
+			require.NoError(t, err)
+			var state struct {
+				Verifier string `json:"verifier"`
+			}
+			require.NoError(t, json.Unmarshal(rawRsp.Data, &state))
+			assert.Equal(t, spec.expVerifier, state.Verifier)
+		})
+	}
+}
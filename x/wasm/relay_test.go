@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/CosmWasm/wasmd/x/wasm"
 	wasmibctesting "github.com/CosmWasm/wasmd/x/wasm/ibctesting"
 	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
 	wasmtesting "github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
@@ -146,6 +147,9 @@ func TestContractCanInitiateIBCTransferMsg(t *testing.T) {
 		chainB      = coordinator.GetChain(wasmibctesting.GetChainID(1))
 	)
 	myContractAddr := chainA.SeedNewContractInstance()
+	// and the contract opted in to the transfer callbacks
+	govKeeper := wasmkeeper.NewGovPermissionKeeper(chainA.GetTestSupport().WasmKeeper())
+	require.NoError(t, govKeeper.GrantContractCapability(chainA.GetContext(), myContractAddr, wasm.ICS20CallbackCapability))
 	coordinator.CommitBlock(chainA, chainB)
 
 	path := wasmibctesting.NewPath(chainA, chainB)
@@ -191,6 +195,14 @@ func TestContractCanInitiateIBCTransferMsg(t *testing.T) {
 	require.Equal(t, 0, len(chainA.PendingSendPackets))
 	require.Equal(t, 0, len(chainB.PendingSendPackets))
 
+	// and the contract was called back with the ack
+	require.Len(t, myContract.callbacks, 1)
+	gotAck := myContract.callbacks[0].Ack
+	require.NotNil(t, gotAck)
+	assert.True(t, gotAck.Success)
+	assert.Equal(t, path.EndpointA.ChannelID, gotAck.ChannelID)
+	assert.Equal(t, uint64(1), gotAck.Sequence)
+
 	// and dest chain balance contains voucher
 	bankKeeperB := chainB.GetTestSupport().BankKeeper()
 	expBalance := ibctransfertypes.GetTransferCoin(path.EndpointB.ChannelConfig.PortID, path.EndpointB.ChannelID, coinToSendToB.Denom, coinToSendToB.Amount)
@@ -394,6 +406,72 @@ func TestContractHandlesChannelClose(t *testing.T) {
 	assert.True(t, myContractB.closeCalled)
 }
 
+func TestContractCanInitiateChannelClose(t *testing.T) {
+	// scenario: given two chains with an open channel between two contracts
+	//           when the contract on chain A returns a CloseChannel message
+	//           then the channel is closed on chain A and the contract is called back
+	myContractA := &closeChannelContract{}
+	var (
+		chainAOpts = []wasmkeeper.Option{wasmkeeper.WithWasmEngine(
+			wasmtesting.NewIBCContractMockWasmer(myContractA)),
+		}
+		chainBOpts = []wasmkeeper.Option{wasmkeeper.WithWasmEngine(
+			wasmtesting.NewIBCContractMockWasmer(&captureCloseContract{})),
+		}
+		coordinator = wasmibctesting.NewCoordinator(t, 2, chainAOpts, chainBOpts)
+
+		chainA = coordinator.GetChain(wasmibctesting.GetChainID(0))
+		chainB = coordinator.GetChain(wasmibctesting.GetChainID(1))
+	)
+	coordinator.CommitBlock(chainA, chainB)
+	myContractAddrA := chainA.SeedNewContractInstance()
+	myContractAddrB := chainB.SeedNewContractInstance()
+
+	path := wasmibctesting.NewPath(chainA, chainB)
+	path.EndpointA.ChannelConfig = &ibctesting.ChannelConfig{
+		PortID:  chainA.ContractInfo(myContractAddrA).IBCPortID,
+		Version: ibctransfertypes.Version,
+		Order:   channeltypes.UNORDERED,
+	}
+	path.EndpointB.ChannelConfig = &ibctesting.ChannelConfig{
+		PortID:  chainB.ContractInfo(myContractAddrB).IBCPortID,
+		Version: ibctransfertypes.Version,
+		Order:   channeltypes.UNORDERED,
+	}
+	coordinator.SetupConnections(path)
+	coordinator.CreateChannels(path)
+
+	// when
+	_, err := chainA.SendMsgs(&types.MsgExecuteContract{
+		Sender:   chainA.SenderAccount.GetAddress().String(),
+		Contract: myContractAddrA.String(),
+		Msg:      []byte(`{"channel_id":"` + path.EndpointA.ChannelID + `"}`),
+	})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, channeltypes.CLOSED, path.EndpointA.GetChannel().State)
+	assert.True(t, myContractA.closeCalled)
+}
+
+var _ wasmtesting.IBCContractCallbacks = &closeChannelContract{}
+
+// contract that closes the channel given in the execute msg
+type closeChannelContract struct {
+	captureCloseContract
+}
+
+func (c *closeChannelContract) Execute(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+	var in struct {
+		ChannelID string `json:"channel_id"`
+	}
+	if err := json.Unmarshal(executeMsg, &in); err != nil {
+		return nil, 0, err
+	}
+	ibcMsg := &wasmvmtypes.IBCMsg{CloseChannel: &wasmvmtypes.CloseChannelMsg{ChannelID: in.ChannelID}}
+	return &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{{ReplyOn: wasmvmtypes.ReplyNever, Msg: wasmvmtypes.CosmosMsg{IBC: ibcMsg}}}}, 0, nil
+}
+
 var _ wasmtesting.IBCContractCallbacks = &captureCloseContract{}
 
 // contract that sets a flag on IBC channel close only.
@@ -412,7 +490,8 @@ var _ wasmtesting.IBCContractCallbacks = &sendViaIBCTransferContract{}
 // contract that initiates an ics-20 transfer on execute via sdk message
 type sendViaIBCTransferContract struct {
 	contractStub
-	t *testing.T
+	t         *testing.T
+	callbacks []wasm.ICS20Callback
 }
 
 func (s *sendViaIBCTransferContract) Execute(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
@@ -435,6 +514,16 @@ func (s *sendViaIBCTransferContract) Execute(code wasmvm.Checksum, env wasmvmtyp
 	return &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{{ReplyOn: wasmvmtypes.ReplyNever, Msg: wasmvmtypes.CosmosMsg{IBC: ibcMsg}}}}, 0, nil
 }
 
+func (s *sendViaIBCTransferContract) Sudo(code wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+	var in wasm.ICS20CallbackSudoMsg
+	if err := json.Unmarshal(sudoMsg, &in); err != nil {
+		return nil, 0, err
+	}
+	require.NotNil(s.t, in.ICS20Callback)
+	s.callbacks = append(s.callbacks, *in.ICS20Callback)
+	return &wasmvmtypes.Response{}, 0, nil
+}
+
 var _ wasmtesting.IBCContractCallbacks = &sendEmulatedIBCTransferContract{}
 
 // contract that interacts as an ics20 sending side via IBC packets
@@ -1,10 +1,13 @@
 package simulation
 
 import (
-	_ "embed"
+	"fmt"
+	"math"
 	"math/rand"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
 	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
@@ -12,23 +15,31 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/simulation"
 
 	"github.com/CosmWasm/wasmd/app/params"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
-//go:embed testdata/reflect.wasm
-var reflectContract []byte
+// storeCodeGas is the gas limit for storing the embedded contract. The SDK default is not
+// sufficient to pay for the tx size and compilation.
+const storeCodeGas = 10 * helpers.DefaultGenTxGas
+
+// maxBlockTime is the latest block time that can be passed to a contract as unix nanos. The
+// simulations start with random genesis times that can be later.
+var maxBlockTime = time.Unix(0, math.MaxInt64)
 
 // Simulation operation weights constants
 //nolint:gosec
 const (
 	OpWeightMsgStoreCode           = "op_weight_msg_store_code"
 	OpWeightMsgInstantiateContract = "op_weight_msg_instantiate_contract"
+	OpWeightMsgExecuteContract     = "op_weight_msg_execute_contract"
 )
 
 // WasmKeeper is a subset of the wasm keeper used by simulations
 type WasmKeeper interface {
 	GetParams(ctx sdk.Context) types.Params
 	IterateCodeInfos(ctx sdk.Context, cb func(uint64, types.CodeInfo) bool)
+	IterateContractInfo(ctx sdk.Context, cb func(sdk.AccAddress, types.ContractInfo) bool)
 }
 
 // WeightedOperations returns all the operations from the module with their respective weights
@@ -41,6 +52,7 @@ func WeightedOperations(
 	var (
 		weightMsgStoreCode           int
 		weightMsgInstantiateContract int
+		weightMsgExecuteContract     int
 	)
 
 	simstate.AppParams.GetOrGenerate(simstate.Cdc, OpWeightMsgStoreCode, &weightMsgStoreCode, nil,
@@ -55,6 +67,12 @@ func WeightedOperations(
 		},
 	)
 
+	simstate.AppParams.GetOrGenerate(simstate.Cdc, OpWeightMsgExecuteContract, &weightMsgExecuteContract, nil,
+		func(_ *rand.Rand) {
+			weightMsgExecuteContract = params.DefaultWeightMsgExecuteContract
+		},
+	)
+
 	return simulation.WeightedOperations{
 		simulation.NewWeightedOperation(
 			weightMsgStoreCode,
@@ -64,6 +82,10 @@ func WeightedOperations(
 			weightMsgInstantiateContract,
 			SimulateMsgInstantiateContract(ak, bk, wasmKeeper),
 		),
+		simulation.NewWeightedOperation(
+			weightMsgExecuteContract,
+			SimulateMsgExecuteContract(ak, bk, wasmKeeper),
+		),
 	}
 }
 
@@ -87,7 +109,7 @@ func SimulateMsgStoreCode(ak types.AccountKeeper, bk simulation.BankKeeper, wasm
 		simAccount, _ := simtypes.RandomAcc(r, accs)
 		msg := types.MsgStoreCode{
 			Sender:                simAccount.Address.String(),
-			WASMByteCode:          reflectContract,
+			WASMByteCode:          keeper.ReflectContractWasm(),
 			InstantiatePermission: config,
 		}
 
@@ -105,7 +127,7 @@ func SimulateMsgStoreCode(ak types.AccountKeeper, bk simulation.BankKeeper, wasm
 			ModuleName:    types.ModuleName,
 		}
 
-		return simulation.GenAndDeliverTxWithRandFees(txCtx)
+		return GenAndDeliverTxWithRandFees(txCtx, storeCodeGas)
 	}
 }
 
@@ -118,6 +140,9 @@ func SimulateMsgInstantiateContract(ak types.AccountKeeper, bk simulation.BankKe
 		accs []simtypes.Account,
 		chainID string,
 	) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		if ctx.BlockTime().After(maxBlockTime) {
+			return simtypes.NoOpMsg(types.ModuleName, types.MsgInstantiateContract{}.Type(), "block time out of range"), nil, nil
+		}
 		simAccount, _ := simtypes.RandomAcc(r, accs)
 
 		var codeID uint64
@@ -145,19 +170,129 @@ func SimulateMsgInstantiateContract(ak types.AccountKeeper, bk simulation.BankKe
 		}
 
 		txCtx := simulation.OperationInput{
-			R:             r,
-			App:           app,
-			TxGen:         simappparams.MakeTestEncodingConfig().TxConfig,
-			Cdc:           nil,
-			Msg:           &msg,
-			MsgType:       msg.Type(),
-			Context:       ctx,
-			SimAccount:    simAccount,
-			AccountKeeper: ak,
-			Bankkeeper:    bk,
-			ModuleName:    types.ModuleName,
+			R:               r,
+			App:             app,
+			TxGen:           simappparams.MakeTestEncodingConfig().TxConfig,
+			Cdc:             nil,
+			Msg:             &msg,
+			MsgType:         msg.Type(),
+			Context:         ctx,
+			SimAccount:      simAccount,
+			AccountKeeper:   ak,
+			Bankkeeper:      bk,
+			ModuleName:      types.ModuleName,
+			CoinsSpentInMsg: msg.Funds,
 		}
 
-		return simulation.GenAndDeliverTxWithRandFees(txCtx)
+		return GenAndDeliverTxWithRandFees(txCtx, helpers.DefaultGenTxGas)
 	}
 }
+
+// SimulateMsgExecuteContract generates a MsgExecuteContract with random values for a random contract
+// that was instantiated by a simulation account. The embedded reflect contract accepts execute messages from its owner only which is the simulation account that
+// instantiated it. The owner is set to itself again so that the contract state is kept.
+func SimulateMsgExecuteContract(ak types.AccountKeeper, bk simulation.BankKeeper, wasmKeeper WasmKeeper) simtypes.Operation {
+	return func(
+		r *rand.Rand,
+		app *baseapp.BaseApp,
+		ctx sdk.Context,
+		accs []simtypes.Account,
+		chainID string,
+	) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		if ctx.BlockTime().After(maxBlockTime) {
+			return simtypes.NoOpMsg(types.ModuleName, types.MsgExecuteContract{}.Type(), "block time out of range"), nil, nil
+		}
+		type candidate struct {
+			simAccount simtypes.Account
+			contract   sdk.AccAddress
+		}
+		var candidates []candidate
+		wasmKeeper.IterateContractInfo(ctx, func(addr sdk.AccAddress, info types.ContractInfo) bool {
+			creator, err := sdk.AccAddressFromBech32(info.Creator)
+			if err != nil {
+				return false
+			}
+			acc, found := simtypes.FindAccount(accs, creator)
+			if !found {
+				return false
+			}
+			candidates = append(candidates, candidate{simAccount: acc, contract: addr})
+			return false
+		})
+
+		if len(candidates) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, types.MsgExecuteContract{}.Type(), "no contract instance available"), nil, nil
+		}
+		picked := candidates[r.Intn(len(candidates))]
+		simAccount, contract := picked.simAccount, picked.contract
+
+		spendable := bk.SpendableCoins(ctx, simAccount.Address)
+
+		msg := types.MsgExecuteContract{
+			Sender:   simAccount.Address.String(),
+			Contract: contract.String(),
+			Msg:      []byte(fmt.Sprintf(`{"change_owner":{"owner":%q}}`, simAccount.Address.String())),
+			Funds:    simtypes.RandSubsetCoins(r, spendable),
+		}
+
+		txCtx := simulation.OperationInput{
+			R:               r,
+			App:             app,
+			TxGen:           simappparams.MakeTestEncodingConfig().TxConfig,
+			Cdc:             nil,
+			Msg:             &msg,
+			MsgType:         msg.Type(),
+			Context:         ctx,
+			SimAccount:      simAccount,
+			AccountKeeper:   ak,
+			Bankkeeper:      bk,
+			ModuleName:      types.ModuleName,
+			CoinsSpentInMsg: msg.Funds,
+		}
+
+		return GenAndDeliverTxWithRandFees(txCtx, helpers.DefaultGenTxGas)
+	}
+}
+
+// GenAndDeliverTxWithRandFees generates a transaction with a random fee and delivers it.
+// Same as the SDK version but with a custom gas limit.
+func GenAndDeliverTxWithRandFees(txCtx simulation.OperationInput, gas uint64) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+	account := txCtx.AccountKeeper.GetAccount(txCtx.Context, txCtx.SimAccount.Address)
+	spendable := txCtx.Bankkeeper.SpendableCoins(txCtx.Context, account.GetAddress())
+
+	coins, hasNeg := spendable.SafeSub(txCtx.CoinsSpentInMsg)
+	if hasNeg {
+		return simtypes.NoOpMsg(txCtx.ModuleName, txCtx.MsgType, "message doesn't leave room for fees"), nil, nil
+	}
+
+	fees, err := simtypes.RandomFees(txCtx.R, txCtx.Context, coins)
+	if err != nil {
+		return simtypes.NoOpMsg(txCtx.ModuleName, txCtx.MsgType, "unable to generate fees"), nil, err
+	}
+	return GenAndDeliverTx(txCtx, fees, gas)
+}
+
+// GenAndDeliverTx generates a transaction and delivers it.
+// Same as the SDK version but with a custom gas limit.
+func GenAndDeliverTx(txCtx simulation.OperationInput, fees sdk.Coins, gas uint64) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+	account := txCtx.AccountKeeper.GetAccount(txCtx.Context, txCtx.SimAccount.Address)
+	tx, err := helpers.GenTx(
+		txCtx.TxGen,
+		[]sdk.Msg{txCtx.Msg},
+		fees,
+		gas,
+		txCtx.Context.ChainID(),
+		[]uint64{account.GetAccountNumber()},
+		[]uint64{account.GetSequence()},
+		txCtx.SimAccount.PrivKey,
+	)
+	if err != nil {
+		return simtypes.NoOpMsg(txCtx.ModuleName, txCtx.MsgType, "unable to generate mock tx"), nil, err
+	}
+
+	if _, _, err := txCtx.App.Deliver(txCtx.TxGen.TxEncoder(), tx); err != nil {
+		return simtypes.NoOpMsg(txCtx.ModuleName, txCtx.MsgType, "unable to deliver tx"), nil, err
+	}
+
+	return simtypes.NewOperationMsg(txCtx.Msg, true, "", txCtx.Cdc), nil, nil
+}
@@ -28,15 +28,45 @@ func ParamChanges(r *rand.Rand, cdc codec.Codec) []simtypes.ParamChange {
 				return fmt.Sprintf("%q", params.CodeUploadAccess.Permission.String())
 			},
 		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMaxContractMsgSize),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", params.MaxContractMsgSize)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMaxContractResponseDataSize),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", params.MaxContractResponseDataSize)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyInstanceCost),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", params.InstanceCost)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyCompileCost),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", params.CompileCost)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyDefaultCreatorAdmin),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%t", params.DefaultCreatorAdmin)
+			},
+		),
 	}
 }
 
 func RandomParams(r *rand.Rand) types.Params {
-	permissionType := types.AccessType(simtypes.RandIntBetween(r, 1, 3))
+	permissionType := types.AccessType(simtypes.RandIntBetween(r, 1, 4))
 	account, _ := simtypes.RandomAcc(r, simtypes.RandomAccounts(r, 10))
 	accessConfig := permissionType.With(account.Address)
 	return types.Params{
 		CodeUploadAccess:             accessConfig,
 		InstantiateDefaultPermission: accessConfig.Permission,
+		MaxContractMsgSize:           uint64(simtypes.RandIntBetween(r, 64*1024, types.MaxContractMsgSize+1)),
+		MaxContractResponseDataSize:  uint64(simtypes.RandIntBetween(r, 1024, 1024*1024)),
+		InstanceCost:                 uint64(simtypes.RandIntBetween(r, 10_000, 100_000)),
+		CompileCost:                  uint64(simtypes.RandIntBetween(r, 1, 5)),
+		DefaultCreatorAdmin:          r.Intn(2) == 0,
 	}
 }
@@ -9,6 +9,8 @@ type contextKey int
 const (
 	// private type creates an interface key for Context that cannot be accessed by any other package
 	contextKeyTXCount contextKey = iota
+	// contextKeyCallDepth is the key for the message dispatch depth of nested contract calls
+	contextKeyCallDepth
 )
 
 // WithTXCounter stores a transaction counter value in the context
@@ -22,3 +24,15 @@ func TXCounter(ctx sdk.Context) (uint32, bool) {
 	val, ok := ctx.Value(contextKeyTXCount).(uint32)
 	return val, ok
 }
+
+// WithCallDepth stores the message dispatch depth of nested contract calls in the context
+func WithCallDepth(ctx sdk.Context, depth uint32) sdk.Context {
+	return ctx.WithValue(contextKeyCallDepth, depth)
+}
+
+// CallDepth returns the message dispatch depth from the context. The result is 0 when no
+// contract has dispatched messages, yet.
+func CallDepth(ctx sdk.Context) uint32 {
+	val, _ := ctx.Value(contextKeyCallDepth).(uint32)
+	return val
+}
@@ -0,0 +1,188 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+var _ authztypes.Authorization = &ContractExecutionAuthorization{}
+
+// NewContractExecutionAuthorization constructor
+func NewContractExecutionAuthorization(grants ...ContractGrant) *ContractExecutionAuthorization {
+	return &ContractExecutionAuthorization{Grants: grants}
+}
+
+// MsgTypeURL implements Authorization.MsgTypeURL.
+func (a ContractExecutionAuthorization) MsgTypeURL() string {
+	return sdk.MsgTypeURL(&MsgExecuteContract{})
+}
+
+// Accept implements Authorization.Accept. The execute message is accepted when there is a grant for
+// the contract that allows the message key and has enough funds and calls left. The grant is removed
+// when no calls are left and the authorization is deleted when it has no grants anymore.
+func (a ContractExecutionAuthorization) Accept(ctx sdk.Context, msg sdk.Msg) (authztypes.AcceptResponse, error) {
+	exec, ok := msg.(*MsgExecuteContract)
+	if !ok {
+		return authztypes.AcceptResponse{}, sdkerrors.Wrap(sdkerrors.ErrInvalidType, "type mismatch")
+	}
+	for i, g := range a.Grants {
+		if g.Contract != exec.Contract {
+			continue
+		}
+		if len(g.AllowedMessageKeys) != 0 {
+			if err := IsJSONObjectWithTopLevelKey(exec.Msg, g.AllowedMessageKeys); err != nil {
+				return authztypes.AcceptResponse{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, err.Error())
+			}
+		}
+		remainingFunds, isNegative := g.MaxFunds.SafeSub(exec.Funds)
+		if isNegative {
+			return authztypes.AcceptResponse{}, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "max funds: %s", g.MaxFunds)
+		}
+
+		grants := make([]ContractGrant, 0, len(a.Grants))
+		grants = append(grants, a.Grants[:i]...)
+		if g.MaxCalls != 1 {
+			g.MaxFunds = remainingFunds
+			if g.MaxCalls != 0 {
+				g.MaxCalls--
+			}
+			grants = append(grants, g)
+		}
+		grants = append(grants, a.Grants[i+1:]...)
+		if len(grants) == 0 {
+			return authztypes.AcceptResponse{Accept: true, Delete: true}, nil
+		}
+		return authztypes.AcceptResponse{Accept: true, Updated: NewContractExecutionAuthorization(grants...)}, nil
+	}
+	return authztypes.AcceptResponse{}, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "no grant for contract %s", exec.Contract)
+}
+
+// ValidateBasic implements Authorization.ValidateBasic.
+func (a ContractExecutionAuthorization) ValidateBasic() error {
+	if len(a.Grants) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "grants")
+	}
+	contracts := make(map[string]struct{}, len(a.Grants))
+	for i, g := range a.Grants {
+		if err := g.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "position %d", i)
+		}
+		if _, exists := contracts[g.Contract]; exists {
+			return sdkerrors.Wrapf(ErrDuplicate, "contract %s", g.Contract)
+		}
+		contracts[g.Contract] = struct{}{}
+	}
+	return nil
+}
+
+// ValidateBasic performs a stateless validation of the grant
+func (g ContractGrant) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(g.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	keys := make(map[string]struct{}, len(g.AllowedMessageKeys))
+	for _, k := range g.AllowedMessageKeys {
+		if k == "" {
+			return sdkerrors.Wrap(ErrEmpty, "message key")
+		}
+		if _, exists := keys[k]; exists {
+			return sdkerrors.Wrapf(ErrDuplicate, "message key %q", k)
+		}
+		keys[k] = struct{}{}
+	}
+	if err := g.MaxFunds.Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "max funds")
+	}
+	return nil
+}
+
+var _ authztypes.Authorization = &ContractMigrationAuthorization{}
+
+// NewContractMigrationAuthorization constructor
+func NewContractMigrationAuthorization(grants ...ContractMigrationGrant) *ContractMigrationAuthorization {
+	return &ContractMigrationAuthorization{Grants: grants}
+}
+
+// MsgTypeURL implements Authorization.MsgTypeURL.
+func (a ContractMigrationAuthorization) MsgTypeURL() string {
+	return sdk.MsgTypeURL(&MsgMigrateContract{})
+}
+
+// Accept implements Authorization.Accept. The migrate message is accepted when there is a grant for
+// the contract that allows the new code id and has calls left. The admin check is still done by the
+// keeper with the granter as sender.
+func (a ContractMigrationAuthorization) Accept(ctx sdk.Context, msg sdk.Msg) (authztypes.AcceptResponse, error) {
+	migrate, ok := msg.(*MsgMigrateContract)
+	if !ok {
+		return authztypes.AcceptResponse{}, sdkerrors.Wrap(sdkerrors.ErrInvalidType, "type mismatch")
+	}
+	for i, g := range a.Grants {
+		if g.Contract != migrate.Contract {
+			continue
+		}
+		if len(g.AllowedCodeIDs) != 0 && !containsCodeID(g.AllowedCodeIDs, migrate.CodeID) {
+			return authztypes.AcceptResponse{}, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "code id %d not allowed", migrate.CodeID)
+		}
+
+		grants := make([]ContractMigrationGrant, 0, len(a.Grants))
+		grants = append(grants, a.Grants[:i]...)
+		if g.MaxCalls != 1 {
+			if g.MaxCalls != 0 {
+				g.MaxCalls--
+			}
+			grants = append(grants, g)
+		}
+		grants = append(grants, a.Grants[i+1:]...)
+		if len(grants) == 0 {
+			return authztypes.AcceptResponse{Accept: true, Delete: true}, nil
+		}
+		return authztypes.AcceptResponse{Accept: true, Updated: NewContractMigrationAuthorization(grants...)}, nil
+	}
+	return authztypes.AcceptResponse{}, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "no grant for contract %s", migrate.Contract)
+}
+
+// ValidateBasic implements Authorization.ValidateBasic.
+func (a ContractMigrationAuthorization) ValidateBasic() error {
+	if len(a.Grants) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "grants")
+	}
+	contracts := make(map[string]struct{}, len(a.Grants))
+	for i, g := range a.Grants {
+		if err := g.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "position %d", i)
+		}
+		if _, exists := contracts[g.Contract]; exists {
+			return sdkerrors.Wrapf(ErrDuplicate, "contract %s", g.Contract)
+		}
+		contracts[g.Contract] = struct{}{}
+	}
+	return nil
+}
+
+// ValidateBasic performs a stateless validation of the grant
+func (g ContractMigrationGrant) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(g.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	codeIDs := make(map[uint64]struct{}, len(g.AllowedCodeIDs))
+	for _, id := range g.AllowedCodeIDs {
+		if id == 0 {
+			return sdkerrors.Wrap(ErrEmpty, "code id")
+		}
+		if _, exists := codeIDs[id]; exists {
+			return sdkerrors.Wrapf(ErrDuplicate, "code id %d", id)
+		}
+		codeIDs[id] = struct{}{}
+	}
+	return nil
+}
+
+func containsCodeID(ids []uint64, id uint64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,1121 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmwasm/wasm/v1/authz.proto
+
+package types
+
+import (
+	fmt "fmt"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/regen-network/cosmos-proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// ContractExecutionAuthorization defines authorization for wasm execute.
+type ContractExecutionAuthorization struct {
+	// Grants for contract executions
+	Grants []ContractGrant `protobuf:"bytes,1,rep,name=grants,proto3" json:"grants"`
+}
+
+func (m *ContractExecutionAuthorization) Reset()         { *m = ContractExecutionAuthorization{} }
+func (m *ContractExecutionAuthorization) String() string { return proto.CompactTextString(m) }
+func (*ContractExecutionAuthorization) ProtoMessage()    {}
+func (*ContractExecutionAuthorization) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36ff3a20cf32b258, []int{0}
+}
+func (m *ContractExecutionAuthorization) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContractExecutionAuthorization) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContractExecutionAuthorization.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ContractExecutionAuthorization) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractExecutionAuthorization.Merge(m, src)
+}
+func (m *ContractExecutionAuthorization) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContractExecutionAuthorization) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractExecutionAuthorization.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractExecutionAuthorization proto.InternalMessageInfo
+
+// ContractGrant a granted permission for a single contract
+type ContractGrant struct {
+	// Contract is the bech32 address of the smart contract
+	Contract string `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	// AllowedMessageKeys are the top level keys of the contract messages that
+	// are allowed. All messages are allowed when empty.
+	AllowedMessageKeys []string `protobuf:"bytes,2,rep,name=allowed_message_keys,json=allowedMessageKeys,proto3" json:"allowed_message_keys,omitempty"`
+	// MaxFunds is the total amount of funds that can be sent to the contract
+	// with all executions. No funds can be sent when empty.
+	MaxFunds github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,3,rep,name=max_funds,json=maxFunds,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"max_funds"`
+	// MaxCalls is the number of remaining executions. There is no limit when
+	// zero.
+	MaxCalls uint64 `protobuf:"varint,4,opt,name=max_calls,json=maxCalls,proto3" json:"max_calls,omitempty"`
+}
+
+func (m *ContractGrant) Reset()         { *m = ContractGrant{} }
+func (m *ContractGrant) String() string { return proto.CompactTextString(m) }
+func (*ContractGrant) ProtoMessage()    {}
+func (*ContractGrant) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36ff3a20cf32b258, []int{1}
+}
+func (m *ContractGrant) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContractGrant) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContractGrant.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ContractGrant) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractGrant.Merge(m, src)
+}
+func (m *ContractGrant) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContractGrant) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractGrant.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractGrant proto.InternalMessageInfo
+
+// ContractMigrationAuthorization defines authorization for wasm migrate.
+type ContractMigrationAuthorization struct {
+	// Grants for contract migrations
+	Grants []ContractMigrationGrant `protobuf:"bytes,1,rep,name=grants,proto3" json:"grants"`
+}
+
+func (m *ContractMigrationAuthorization) Reset()         { *m = ContractMigrationAuthorization{} }
+func (m *ContractMigrationAuthorization) String() string { return proto.CompactTextString(m) }
+func (*ContractMigrationAuthorization) ProtoMessage()    {}
+func (*ContractMigrationAuthorization) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36ff3a20cf32b258, []int{2}
+}
+func (m *ContractMigrationAuthorization) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContractMigrationAuthorization) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContractMigrationAuthorization.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ContractMigrationAuthorization) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractMigrationAuthorization.Merge(m, src)
+}
+func (m *ContractMigrationAuthorization) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContractMigrationAuthorization) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractMigrationAuthorization.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractMigrationAuthorization proto.InternalMessageInfo
+
+// ContractMigrationGrant a granted permission to migrate a single contract
+type ContractMigrationGrant struct {
+	// Contract is the bech32 address of the smart contract
+	Contract string `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	// AllowedCodeIDs are the codes the contract can be migrated to. All codes are
+	// allowed when empty.
+	AllowedCodeIDs []uint64 `protobuf:"varint,2,rep,packed,name=allowed_code_ids,json=allowedCodeIds,proto3" json:"allowed_code_ids,omitempty"`
+	// MaxCalls is the number of remaining migrations. There is no limit when
+	// zero.
+	MaxCalls uint64 `protobuf:"varint,3,opt,name=max_calls,json=maxCalls,proto3" json:"max_calls,omitempty"`
+}
+
+func (m *ContractMigrationGrant) Reset()         { *m = ContractMigrationGrant{} }
+func (m *ContractMigrationGrant) String() string { return proto.CompactTextString(m) }
+func (*ContractMigrationGrant) ProtoMessage()    {}
+func (*ContractMigrationGrant) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36ff3a20cf32b258, []int{3}
+}
+func (m *ContractMigrationGrant) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContractMigrationGrant) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContractMigrationGrant.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ContractMigrationGrant) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractMigrationGrant.Merge(m, src)
+}
+func (m *ContractMigrationGrant) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContractMigrationGrant) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractMigrationGrant.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractMigrationGrant proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*ContractExecutionAuthorization)(nil), "cosmwasm.wasm.v1.ContractExecutionAuthorization")
+	proto.RegisterType((*ContractGrant)(nil), "cosmwasm.wasm.v1.ContractGrant")
+	proto.RegisterType((*ContractMigrationAuthorization)(nil), "cosmwasm.wasm.v1.ContractMigrationAuthorization")
+	proto.RegisterType((*ContractMigrationGrant)(nil), "cosmwasm.wasm.v1.ContractMigrationGrant")
+}
+
+func init() { proto.RegisterFile("cosmwasm/wasm/v1/authz.proto", fileDescriptor_36ff3a20cf32b258) }
+
+var fileDescriptor_36ff3a20cf32b258 = []byte{
+	// 461 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0x87, 0xbd, 0x24, 0xaa, 0x9a, 0x45, 0xad, 0x8a, 0x55, 0x21, 0x37, 0xa0, 0x4d, 0x94, 0x03,
+	0xf2, 0xa5, 0x76, 0x03, 0x37, 0x04, 0x87, 0xc6, 0xa5, 0x08, 0xa1, 0x5e, 0x7c, 0x41, 0xe2, 0x62,
+	0x6d, 0xec, 0xc5, 0xb1, 0x1a, 0x7b, 0x2a, 0xcf, 0x3a, 0x4d, 0xca, 0x43, 0xc0, 0x73, 0x70, 0xe6,
+	0x21, 0x72, 0xac, 0x38, 0x71, 0x2a, 0x90, 0xf0, 0x20, 0xc8, 0xde, 0x75, 0x69, 0xc2, 0x1f, 0xf5,
+	0xb2, 0xeb, 0x9f, 0xbf, 0x9d, 0xf1, 0xe8, 0xb3, 0x4d, 0x1f, 0x86, 0x80, 0xe9, 0x39, 0xc7, 0xd4,
+	0xad, 0x96, 0x49, 0xdf, 0xe5, 0x85, 0x1c, 0x5d, 0x38, 0x67, 0x39, 0x48, 0x30, 0x77, 0x6a, 0xea,
+	0x54, 0xcb, 0xa4, 0xdf, 0xde, 0x8d, 0x21, 0x86, 0x0a, 0xba, 0xe5, 0x95, 0x3a, 0xd7, 0xde, 0x2b,
+	0xcf, 0x01, 0x06, 0x0a, 0xa8, 0xa0, 0x11, 0x53, 0xc9, 0x1d, 0x72, 0x14, 0xee, 0xa4, 0x3f, 0x14,
+	0x92, 0xf7, 0xdd, 0x10, 0x92, 0x4c, 0xf1, 0x5e, 0x4e, 0x99, 0x07, 0x99, 0xcc, 0x79, 0x28, 0x5f,
+	0x4c, 0x45, 0x58, 0xc8, 0x04, 0xb2, 0xc3, 0x42, 0x8e, 0x20, 0x4f, 0x2e, 0x78, 0x19, 0xcc, 0xe7,
+	0x74, 0x23, 0xce, 0x79, 0x26, 0xd1, 0x22, 0xdd, 0x86, 0x7d, 0xf7, 0x71, 0xc7, 0x59, 0x9f, 0xca,
+	0xa9, 0x3b, 0xbc, 0x2c, 0xcf, 0x0d, 0x9a, 0xf3, 0xab, 0x8e, 0xe1, 0xeb, 0xa2, 0xa7, 0xf7, 0xbe,
+	0x7c, 0xde, 0xdf, 0x5a, 0xe9, 0xd8, 0xfb, 0x49, 0xe8, 0xd6, 0x4a, 0x89, 0xd9, 0xa6, 0x9b, 0xa1,
+	0xbe, 0x61, 0x91, 0x2e, 0xb1, 0x5b, 0xfe, 0x75, 0x36, 0x0f, 0xe8, 0x2e, 0x1f, 0x8f, 0xe1, 0x5c,
+	0x44, 0x41, 0x2a, 0x10, 0x79, 0x2c, 0x82, 0x53, 0x31, 0x43, 0xeb, 0x4e, 0xb7, 0x61, 0xb7, 0x7c,
+	0x53, 0xb3, 0x13, 0x85, 0x5e, 0x8b, 0x19, 0x9a, 0x23, 0xda, 0x4a, 0xf9, 0x34, 0x78, 0x57, 0x64,
+	0x11, 0x5a, 0x8d, 0x6a, 0xe8, 0x3d, 0x47, 0x5b, 0x29, 0x3d, 0x38, 0xda, 0x83, 0xe3, 0x41, 0x92,
+	0x0d, 0x0e, 0xca, 0x71, 0x3f, 0x7d, 0xeb, 0xd8, 0x71, 0x22, 0x47, 0xc5, 0xd0, 0x09, 0x21, 0xd5,
+	0x0a, 0xf5, 0xb6, 0x8f, 0xd1, 0xa9, 0x2b, 0x67, 0x67, 0x02, 0xab, 0x02, 0xf4, 0x37, 0x53, 0x3e,
+	0x3d, 0x2e, 0x9b, 0x9b, 0x0f, 0xd4, 0x93, 0x42, 0x3e, 0x1e, 0xa3, 0xd5, 0xec, 0x12, 0xbb, 0x59,
+	0x41, 0xaf, 0xcc, 0xbd, 0xf7, 0xbf, 0xd5, 0x9e, 0x24, 0x71, 0xce, 0xff, 0x54, 0x7b, 0xbc, 0xa6,
+	0xd6, 0xfe, 0xb7, 0xda, 0xeb, 0x0e, 0xb7, 0x74, 0xfc, 0x81, 0xd0, 0xfb, 0x7f, 0xaf, 0xfd, 0xaf,
+	0xec, 0x67, 0x74, 0xa7, 0x96, 0x1d, 0x42, 0x24, 0x82, 0x24, 0x52, 0xa2, 0x9b, 0x03, 0x73, 0x71,
+	0xd5, 0xd9, 0x3e, 0x54, 0xcc, 0x83, 0x48, 0xbc, 0x3a, 0x42, 0x7f, 0x9b, 0xdf, 0xc8, 0xeb, 0x3a,
+	0x1a, 0xab, 0x3a, 0x06, 0x47, 0xf3, 0x1f, 0xcc, 0x98, 0x2f, 0x18, 0xb9, 0x5c, 0x30, 0xf2, 0x7d,
+	0xc1, 0xc8, 0xc7, 0x25, 0x33, 0x2e, 0x97, 0xcc, 0xf8, 0xba, 0x64, 0xc6, 0xdb, 0x47, 0x37, 0xec,
+	0x7b, 0x80, 0xe9, 0x9b, 0xfa, 0x9f, 0x88, 0xdc, 0x69, 0xb5, 0xab, 0x37, 0x30, 0xdc, 0xa8, 0x3e,
+	0xdb, 0x27, 0xbf, 0x06, 0x00, 0xb5, 0x04, 0x51, 0x16, 0x39, 0x03, 0x00, 0x00,
+}
+
+func (m *ContractExecutionAuthorization) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractExecutionAuthorization) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractExecutionAuthorization) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Grants) > 0 {
+		for iNdEx := len(m.Grants) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Grants[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAuthz(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ContractGrant) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractGrant) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractGrant) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxCalls != 0 {
+		i = encodeVarintAuthz(dAtA, i, uint64(m.MaxCalls))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.MaxFunds) > 0 {
+		for iNdEx := len(m.MaxFunds) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MaxFunds[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAuthz(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.AllowedMessageKeys) > 0 {
+		for iNdEx := len(m.AllowedMessageKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AllowedMessageKeys[iNdEx])
+			copy(dAtA[i:], m.AllowedMessageKeys[iNdEx])
+			i = encodeVarintAuthz(dAtA, i, uint64(len(m.AllowedMessageKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintAuthz(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ContractMigrationAuthorization) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractMigrationAuthorization) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractMigrationAuthorization) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Grants) > 0 {
+		for iNdEx := len(m.Grants) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Grants[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAuthz(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ContractMigrationGrant) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractMigrationGrant) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractMigrationGrant) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxCalls != 0 {
+		i = encodeVarintAuthz(dAtA, i, uint64(m.MaxCalls))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.AllowedCodeIDs) > 0 {
+		dAtA2 := make([]byte, len(m.AllowedCodeIDs)*10)
+		var j1 int
+		for _, num := range m.AllowedCodeIDs {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintAuthz(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintAuthz(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintAuthz(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAuthz(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ContractExecutionAuthorization) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Grants) > 0 {
+		for _, e := range m.Grants {
+			l = e.Size()
+			n += 1 + l + sovAuthz(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ContractGrant) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovAuthz(uint64(l))
+	}
+	if len(m.AllowedMessageKeys) > 0 {
+		for _, s := range m.AllowedMessageKeys {
+			l = len(s)
+			n += 1 + l + sovAuthz(uint64(l))
+		}
+	}
+	if len(m.MaxFunds) > 0 {
+		for _, e := range m.MaxFunds {
+			l = e.Size()
+			n += 1 + l + sovAuthz(uint64(l))
+		}
+	}
+	if m.MaxCalls != 0 {
+		n += 1 + sovAuthz(uint64(m.MaxCalls))
+	}
+	return n
+}
+
+func (m *ContractMigrationAuthorization) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Grants) > 0 {
+		for _, e := range m.Grants {
+			l = e.Size()
+			n += 1 + l + sovAuthz(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ContractMigrationGrant) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovAuthz(uint64(l))
+	}
+	if len(m.AllowedCodeIDs) > 0 {
+		l = 0
+		for _, e := range m.AllowedCodeIDs {
+			l += sovAuthz(uint64(e))
+		}
+		n += 1 + sovAuthz(uint64(l)) + l
+	}
+	if m.MaxCalls != 0 {
+		n += 1 + sovAuthz(uint64(m.MaxCalls))
+	}
+	return n
+}
+
+func sovAuthz(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAuthz(x uint64) (n int) {
+	return sovAuthz(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ContractExecutionAuthorization) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuthz
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractExecutionAuthorization: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractExecutionAuthorization: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Grants", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Grants = append(m.Grants, ContractGrant{})
+			if err := m.Grants[len(m.Grants)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuthz(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContractGrant) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuthz
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractGrant: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractGrant: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedMessageKeys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedMessageKeys = append(m.AllowedMessageKeys, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxFunds", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MaxFunds = append(m.MaxFunds, types.Coin{})
+			if err := m.MaxFunds[len(m.MaxFunds)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCalls", wireType)
+			}
+			m.MaxCalls = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCalls |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuthz(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContractMigrationAuthorization) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuthz
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractMigrationAuthorization: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractMigrationAuthorization: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Grants", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Grants = append(m.Grants, ContractMigrationGrant{})
+			if err := m.Grants[len(m.Grants)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuthz(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContractMigrationGrant) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuthz
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractMigrationGrant: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractMigrationGrant: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAuthz
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.AllowedCodeIDs = append(m.AllowedCodeIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAuthz
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthAuthz
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthAuthz
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.AllowedCodeIDs) == 0 {
+					m.AllowedCodeIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAuthz
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.AllowedCodeIDs = append(m.AllowedCodeIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedCodeIDs", wireType)
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCalls", wireType)
+			}
+			m.MaxCalls = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCalls |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuthz(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipAuthz(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowAuthz
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthAuthz
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupAuthz
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthAuthz
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthAuthz        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowAuthz          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupAuthz = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,258 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractExecutionAuthorizationValidateBasic(t *testing.T) {
+	myContract := sdk.AccAddress(make([]byte, ContractAddrLen)).String()
+	otherContract := sdk.AccAddress(randBytes(ContractAddrLen)).String()
+	specs := map[string]struct {
+		src    ContractExecutionAuthorization
+		expErr bool
+	}{
+		"all good": {
+			src: *NewContractExecutionAuthorization(ContractGrant{
+				Contract:           myContract,
+				AllowedMessageKeys: []string{"foo", "bar"},
+				MaxFunds:           sdk.NewCoins(sdk.NewInt64Coin("denom", 1)),
+				MaxCalls:           1,
+			}),
+		},
+		"multiple contracts": {
+			src: *NewContractExecutionAuthorization(ContractGrant{Contract: myContract}, ContractGrant{Contract: otherContract}),
+		},
+		"empty grants": {
+			src:    ContractExecutionAuthorization{},
+			expErr: true,
+		},
+		"invalid contract": {
+			src:    *NewContractExecutionAuthorization(ContractGrant{Contract: "invalid"}),
+			expErr: true,
+		},
+		"duplicate contract": {
+			src:    *NewContractExecutionAuthorization(ContractGrant{Contract: myContract}, ContractGrant{Contract: myContract}),
+			expErr: true,
+		},
+		"empty message key": {
+			src:    *NewContractExecutionAuthorization(ContractGrant{Contract: myContract, AllowedMessageKeys: []string{""}}),
+			expErr: true,
+		},
+		"duplicate message key": {
+			src:    *NewContractExecutionAuthorization(ContractGrant{Contract: myContract, AllowedMessageKeys: []string{"foo", "foo"}}),
+			expErr: true,
+		},
+		"invalid max funds": {
+			src:    *NewContractExecutionAuthorization(ContractGrant{Contract: myContract, MaxFunds: sdk.Coins{sdk.Coin{Denom: "denom", Amount: sdk.ZeroInt()}}}),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := spec.src.ValidateBasic()
+			if spec.expErr {
+				assert.Error(t, gotErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestContractExecutionAuthorizationAccept(t *testing.T) {
+	myContract := sdk.AccAddress(make([]byte, ContractAddrLen)).String()
+	otherContract := sdk.AccAddress(randBytes(ContractAddrLen)).String()
+	myFunds := sdk.NewCoins(sdk.NewInt64Coin("denom", 10))
+
+	specs := map[string]struct {
+		auth   *ContractExecutionAuthorization
+		msg    sdk.Msg
+		exp    authztypes.AcceptResponse
+		expErr *sdkerrors.Error
+	}{
+		"unlimited grant": {
+			auth: NewContractExecutionAuthorization(ContractGrant{Contract: myContract}),
+			msg:  &MsgExecuteContract{Contract: myContract, Msg: []byte(`{"foo":{}}`)},
+			exp:  authztypes.AcceptResponse{Accept: true, Updated: NewContractExecutionAuthorization(ContractGrant{Contract: myContract})},
+		},
+		"allowed message key": {
+			auth: NewContractExecutionAuthorization(ContractGrant{Contract: myContract, AllowedMessageKeys: []string{"bar", "foo"}}),
+			msg:  &MsgExecuteContract{Contract: myContract, Msg: []byte(`{"foo":{}}`)},
+			exp:  authztypes.AcceptResponse{Accept: true, Updated: NewContractExecutionAuthorization(ContractGrant{Contract: myContract, AllowedMessageKeys: []string{"bar", "foo"}})},
+		},
+		"message key not allowed": {
+			auth:   NewContractExecutionAuthorization(ContractGrant{Contract: myContract, AllowedMessageKeys: []string{"bar"}}),
+			msg:    &MsgExecuteContract{Contract: myContract, Msg: []byte(`{"foo":{}}`)},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"funds deducted": {
+			auth: NewContractExecutionAuthorization(ContractGrant{Contract: myContract, MaxFunds: myFunds}),
+			msg:  &MsgExecuteContract{Contract: myContract, Msg: []byte(`{"foo":{}}`), Funds: sdk.NewCoins(sdk.NewInt64Coin("denom", 4))},
+			exp:  authztypes.AcceptResponse{Accept: true, Updated: NewContractExecutionAuthorization(ContractGrant{Contract: myContract, MaxFunds: sdk.NewCoins(sdk.NewInt64Coin("denom", 6))})},
+		},
+		"funds exceed max": {
+			auth:   NewContractExecutionAuthorization(ContractGrant{Contract: myContract, MaxFunds: myFunds}),
+			msg:    &MsgExecuteContract{Contract: myContract, Msg: []byte(`{"foo":{}}`), Funds: sdk.NewCoins(sdk.NewInt64Coin("denom", 11))},
+			expErr: sdkerrors.ErrInsufficientFunds,
+		},
+		"funds without max funds": {
+			auth:   NewContractExecutionAuthorization(ContractGrant{Contract: myContract}),
+			msg:    &MsgExecuteContract{Contract: myContract, Msg: []byte(`{"foo":{}}`), Funds: myFunds},
+			expErr: sdkerrors.ErrInsufficientFunds,
+		},
+		"calls decremented": {
+			auth: NewContractExecutionAuthorization(ContractGrant{Contract: myContract, MaxCalls: 2}),
+			msg:  &MsgExecuteContract{Contract: myContract, Msg: []byte(`{"foo":{}}`)},
+			exp:  authztypes.AcceptResponse{Accept: true, Updated: NewContractExecutionAuthorization(ContractGrant{Contract: myContract, MaxCalls: 1})},
+		},
+		"last call removes grant": {
+			auth: NewContractExecutionAuthorization(ContractGrant{Contract: otherContract}, ContractGrant{Contract: myContract, MaxCalls: 1}),
+			msg:  &MsgExecuteContract{Contract: myContract, Msg: []byte(`{"foo":{}}`)},
+			exp:  authztypes.AcceptResponse{Accept: true, Updated: NewContractExecutionAuthorization(ContractGrant{Contract: otherContract})},
+		},
+		"last call of last grant deletes authorization": {
+			auth: NewContractExecutionAuthorization(ContractGrant{Contract: myContract, MaxCalls: 1}),
+			msg:  &MsgExecuteContract{Contract: myContract, Msg: []byte(`{"foo":{}}`)},
+			exp:  authztypes.AcceptResponse{Accept: true, Delete: true},
+		},
+		"no grant for contract": {
+			auth:   NewContractExecutionAuthorization(ContractGrant{Contract: otherContract}),
+			msg:    &MsgExecuteContract{Contract: myContract, Msg: []byte(`{"foo":{}}`)},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"other msg type": {
+			auth:   NewContractExecutionAuthorization(ContractGrant{Contract: myContract}),
+			msg:    &MsgMigrateContract{Contract: myContract, Msg: []byte(`{"foo":{}}`)},
+			expErr: sdkerrors.ErrInvalidType,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := spec.auth.Accept(sdk.Context{}, spec.msg)
+			if spec.expErr != nil {
+				require.Error(t, gotErr)
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestContractMigrationAuthorizationValidateBasic(t *testing.T) {
+	myContract := sdk.AccAddress(make([]byte, ContractAddrLen)).String()
+	otherContract := sdk.AccAddress(randBytes(ContractAddrLen)).String()
+	specs := map[string]struct {
+		src    ContractMigrationAuthorization
+		expErr bool
+	}{
+		"all good": {
+			src: *NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract, AllowedCodeIDs: []uint64{1, 2}, MaxCalls: 1}),
+		},
+		"multiple contracts": {
+			src: *NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract}, ContractMigrationGrant{Contract: otherContract}),
+		},
+		"empty grants": {
+			src:    ContractMigrationAuthorization{},
+			expErr: true,
+		},
+		"invalid contract": {
+			src:    *NewContractMigrationAuthorization(ContractMigrationGrant{Contract: "invalid"}),
+			expErr: true,
+		},
+		"duplicate contract": {
+			src:    *NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract}, ContractMigrationGrant{Contract: myContract}),
+			expErr: true,
+		},
+		"empty code id": {
+			src:    *NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract, AllowedCodeIDs: []uint64{0}}),
+			expErr: true,
+		},
+		"duplicate code id": {
+			src:    *NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract, AllowedCodeIDs: []uint64{1, 1}}),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := spec.src.ValidateBasic()
+			if spec.expErr {
+				assert.Error(t, gotErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestContractMigrationAuthorizationAccept(t *testing.T) {
+	myContract := sdk.AccAddress(make([]byte, ContractAddrLen)).String()
+	otherContract := sdk.AccAddress(randBytes(ContractAddrLen)).String()
+
+	specs := map[string]struct {
+		auth   *ContractMigrationAuthorization
+		msg    sdk.Msg
+		exp    authztypes.AcceptResponse
+		expErr *sdkerrors.Error
+	}{
+		"unlimited grant": {
+			auth: NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract}),
+			msg:  &MsgMigrateContract{Contract: myContract, CodeID: 2, Msg: []byte(`{}`)},
+			exp:  authztypes.AcceptResponse{Accept: true, Updated: NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract})},
+		},
+		"allowed code id": {
+			auth: NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract, AllowedCodeIDs: []uint64{1, 2}}),
+			msg:  &MsgMigrateContract{Contract: myContract, CodeID: 2, Msg: []byte(`{}`)},
+			exp:  authztypes.AcceptResponse{Accept: true, Updated: NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract, AllowedCodeIDs: []uint64{1, 2}})},
+		},
+		"code id not allowed": {
+			auth:   NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract, AllowedCodeIDs: []uint64{1}}),
+			msg:    &MsgMigrateContract{Contract: myContract, CodeID: 2, Msg: []byte(`{}`)},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"calls decremented": {
+			auth: NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract, MaxCalls: 2}),
+			msg:  &MsgMigrateContract{Contract: myContract, CodeID: 2, Msg: []byte(`{}`)},
+			exp:  authztypes.AcceptResponse{Accept: true, Updated: NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract, MaxCalls: 1})},
+		},
+		"last call removes grant": {
+			auth: NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract, MaxCalls: 1}, ContractMigrationGrant{Contract: otherContract}),
+			msg:  &MsgMigrateContract{Contract: myContract, CodeID: 2, Msg: []byte(`{}`)},
+			exp:  authztypes.AcceptResponse{Accept: true, Updated: NewContractMigrationAuthorization(ContractMigrationGrant{Contract: otherContract})},
+		},
+		"last call of last grant deletes authorization": {
+			auth: NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract, MaxCalls: 1}),
+			msg:  &MsgMigrateContract{Contract: myContract, CodeID: 2, Msg: []byte(`{}`)},
+			exp:  authztypes.AcceptResponse{Accept: true, Delete: true},
+		},
+		"no grant for contract": {
+			auth:   NewContractMigrationAuthorization(ContractMigrationGrant{Contract: otherContract}),
+			msg:    &MsgMigrateContract{Contract: myContract, CodeID: 2, Msg: []byte(`{}`)},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"other msg type": {
+			auth:   NewContractMigrationAuthorization(ContractMigrationGrant{Contract: myContract}),
+			msg:    &MsgExecuteContract{Contract: myContract, Msg: []byte(`{}`)},
+			expErr: sdkerrors.ErrInvalidType,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := spec.auth.Accept(sdk.Context{}, spec.msg)
+			if spec.expErr != nil {
+				require.Error(t, gotErr)
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/msgservice"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
@@ -17,6 +18,14 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) { //nolint:staticcheck
 	cdc.RegisterConcrete(&MsgMigrateContract{}, "wasm/MsgMigrateContract", nil)
 	cdc.RegisterConcrete(&MsgUpdateAdmin{}, "wasm/MsgUpdateAdmin", nil)
 	cdc.RegisterConcrete(&MsgClearAdmin{}, "wasm/MsgClearAdmin", nil)
+	cdc.RegisterConcrete(&MsgUpdateExecuteGasLimit{}, "wasm/MsgUpdateExecuteGasLimit", nil)
+	cdc.RegisterConcrete(&MsgStoreAndInstantiateContract{}, "wasm/MsgStoreAndInstantiateContract", nil)
+	cdc.RegisterConcrete(&MsgStoreAndMigrateContract{}, "wasm/MsgStoreAndMigrateContract", nil)
+	cdc.RegisterConcrete(&MsgScheduleContractCall{}, "wasm/MsgScheduleContractCall", nil)
+	cdc.RegisterConcrete(&MsgDepositRent{}, "wasm/MsgDepositRent", nil)
+	cdc.RegisterConcrete(&MsgUpdateOperator{}, "wasm/MsgUpdateOperator", nil)
+	cdc.RegisterConcrete(&MsgUpdateLabel{}, "wasm/MsgUpdateLabel", nil)
+	cdc.RegisterConcrete(&MsgOperatorSudo{}, "wasm/MsgOperatorSudo", nil)
 
 	cdc.RegisterConcrete(&PinCodesProposal{}, "wasm/PinCodesProposal", nil)
 	cdc.RegisterConcrete(&UnpinCodesProposal{}, "wasm/UnpinCodesProposal", nil)
@@ -27,6 +36,17 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) { //nolint:staticcheck
 	cdc.RegisterConcrete(&ExecuteContractProposal{}, "wasm/ExecuteContractProposal", nil)
 	cdc.RegisterConcrete(&UpdateAdminProposal{}, "wasm/UpdateAdminProposal", nil)
 	cdc.RegisterConcrete(&ClearAdminProposal{}, "wasm/ClearAdminProposal", nil)
+	cdc.RegisterConcrete(&DeactivateContractsProposal{}, "wasm/DeactivateContractsProposal", nil)
+	cdc.RegisterConcrete(&ActivateContractsProposal{}, "wasm/ActivateContractsProposal", nil)
+	cdc.RegisterConcrete(&UpdateExecuteGasLimitProposal{}, "wasm/UpdateExecuteGasLimitProposal", nil)
+	cdc.RegisterConcrete(&RegisterCronContractsProposal{}, "wasm/RegisterCronContractsProposal", nil)
+	cdc.RegisterConcrete(&UnregisterCronContractsProposal{}, "wasm/UnregisterCronContractsProposal", nil)
+	cdc.RegisterConcrete(&PruneCodesProposal{}, "wasm/PruneCodesProposal", nil)
+	cdc.RegisterConcrete(&GrantContractCapabilitiesProposal{}, "wasm/GrantContractCapabilitiesProposal", nil)
+	cdc.RegisterConcrete(&RevokeContractCapabilitiesProposal{}, "wasm/RevokeContractCapabilitiesProposal", nil)
+
+	cdc.RegisterConcrete(&ContractExecutionAuthorization{}, "wasm/ContractExecutionAuthorization", nil)
+	cdc.RegisterConcrete(&ContractMigrationAuthorization{}, "wasm/ContractMigrationAuthorization", nil)
 }
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
@@ -38,6 +58,14 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&MsgMigrateContract{},
 		&MsgUpdateAdmin{},
 		&MsgClearAdmin{},
+		&MsgUpdateExecuteGasLimit{},
+		&MsgStoreAndInstantiateContract{},
+		&MsgStoreAndMigrateContract{},
+		&MsgScheduleContractCall{},
+		&MsgDepositRent{},
+		&MsgUpdateOperator{},
+		&MsgUpdateLabel{},
+		&MsgOperatorSudo{},
 		&MsgIBCCloseChannel{},
 		&MsgIBCSend{},
 	)
@@ -52,10 +80,24 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&ClearAdminProposal{},
 		&PinCodesProposal{},
 		&UnpinCodesProposal{},
+		&DeactivateContractsProposal{},
+		&ActivateContractsProposal{},
+		&UpdateExecuteGasLimitProposal{},
+		&RegisterCronContractsProposal{},
+		&UnregisterCronContractsProposal{},
+		&PruneCodesProposal{},
+		&GrantContractCapabilitiesProposal{},
+		&RevokeContractCapabilitiesProposal{},
 	)
 
 	registry.RegisterInterface("ContractInfoExtension", (*ContractInfoExtension)(nil))
 
+	registry.RegisterImplementations(
+		(*authz.Authorization)(nil),
+		&ContractExecutionAuthorization{},
+		&ContractMigrationAuthorization{},
+	)
+
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
 }
 
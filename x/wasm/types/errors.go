@@ -1,6 +1,11 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
@@ -84,6 +89,15 @@ var (
 
 	// ErrTopKevelKeyNotAllowed error if a JSON object has a top-level key that is not allowed
 	ErrTopKevelKeyNotAllowed = sdkErrors.Register(DefaultCodespace, 26, "top-level key is not allowed")
+
+	// ErrInactiveContract error if the contract was deactivated by governance
+	ErrInactiveContract = sdkErrors.Register(DefaultCodespace, 27, "inactive contract")
+
+	// ErrDeniedFundsDenom error if funds with a denom are sent to a contract that is on the deny list
+	ErrDeniedFundsDenom = sdkErrors.Register(DefaultCodespace, 28, "denom can not be sent to contracts")
+
+	// ErrPrunedCode error if the byte code of a code id was pruned by governance
+	ErrPrunedCode = sdkErrors.Register(DefaultCodespace, 29, "pruned code")
 )
 
 type ErrNoSuchContract struct {
@@ -101,3 +115,63 @@ func (m *ErrNoSuchContract) ABCICode() uint32 {
 func (m *ErrNoSuchContract) Codespace() string {
 	return DefaultCodespace
 }
+
+// Failure classes of contract calls into the wasm VM
+const (
+	// FailureClassOutOfGas the contract ran out of the gas that was available for the call
+	FailureClassOutOfGas = "out_of_gas"
+	// FailureClassContractError the contract returned an error
+	FailureClassContractError = "contract_error"
+	// FailureClassVMError the VM failed with a system error
+	FailureClassVMError = "vm_error"
+)
+
+// ErrContractFailure is returned when a contract call into the wasm VM fails. It adds the contract address and
+// the failure class to the wrapped error so that failing contracts can be identified. ABCI code and codespace
+// are the ones of the wrapped error.
+type ErrContractFailure struct {
+	Contract string
+	Class    string
+	Err      error
+}
+
+// NewErrContractFailure wraps the VM error with the given sdk error and the failure details
+func NewErrContractFailure(contractAddr sdk.AccAddress, vmErr error, wrapper *sdkErrors.Error) *ErrContractFailure {
+	return &ErrContractFailure{
+		Contract: contractAddr.String(),
+		Class:    FailureClass(vmErr),
+		Err:      sdkErrors.Wrap(wrapper, vmErr.Error()),
+	}
+}
+
+// FailureClass returns the failure class of an error from the wasm VM. The class is derived from the
+// type of the wrapped error only. wasmvm returns the errors of a contract and most errors of the VM
+// itself as plain errors with a message. The message can be chosen by the contract so that these
+// errors are classified as contract errors.
+func FailureClass(vmErr error) string {
+	var (
+		outOfGas    wasmvmtypes.OutOfGasError
+		systemError wasmvmtypes.SystemError
+	)
+	switch {
+	case errors.As(vmErr, &outOfGas):
+		return FailureClassOutOfGas
+	case errors.As(vmErr, &systemError):
+		return FailureClassVMError
+	}
+	return FailureClassContractError
+}
+
+func (e *ErrContractFailure) Error() string {
+	return fmt.Sprintf("contract %s failed with %s: %s", e.Contract, e.Class, e.Err)
+}
+
+// Cause returns the wrapped error for the sdk error handling
+func (e *ErrContractFailure) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the wrapped error for the standard library error handling
+func (e *ErrContractFailure) Unwrap() error {
+	return e.Err
+}
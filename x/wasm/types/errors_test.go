@@ -0,0 +1,55 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewErrContractFailure(t *testing.T) {
+	contractAddr := sdk.AccAddress(make([]byte, ContractAddrLen))
+	specs := map[string]struct {
+		vmErr    error
+		expClass string
+		expLog   string
+	}{
+		"out of gas": {
+			vmErr:    wasmvmtypes.OutOfGasError{},
+			expClass: FailureClassOutOfGas,
+			expLog:   "contract " + contractAddr.String() + " failed with out_of_gas: Out of gas: execute wasm contract failed",
+		},
+		"contract error": {
+			vmErr:    errors.New("testing"),
+			expClass: FailureClassContractError,
+			expLog:   "contract " + contractAddr.String() + " failed with contract_error: testing: execute wasm contract failed",
+		},
+		"vm error": {
+			vmErr:    wasmvmtypes.SystemError{NoSuchContract: &wasmvmtypes.NoSuchContract{Addr: "foo"}},
+			expClass: FailureClassVMError,
+			expLog:   "contract " + contractAddr.String() + " failed with vm_error: no such contract: foo: execute wasm contract failed",
+		},
+		"vm error message from contract": {
+			vmErr:    errors.New("Error calling the VM: Error executing Wasm: unreachable"),
+			expClass: FailureClassContractError,
+			expLog:   "contract " + contractAddr.String() + " failed with contract_error: Error calling the VM: Error executing Wasm: unreachable: execute wasm contract failed",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got := NewErrContractFailure(contractAddr, spec.vmErr, ErrExecuteFailed)
+			assert.Equal(t, contractAddr.String(), got.Contract)
+			assert.Equal(t, spec.expClass, got.Class)
+			// and the wrapped error is preserved
+			assert.True(t, ErrExecuteFailed.Is(got))
+			assert.True(t, errors.Is(got, ErrExecuteFailed))
+			codespace, code, log := sdkerrors.ABCIInfo(got, false)
+			assert.Equal(t, ErrExecuteFailed.Codespace(), codespace)
+			assert.Equal(t, ErrExecuteFailed.ABCICode(), code)
+			assert.Equal(t, spec.expLog, log)
+		})
+	}
+}
@@ -12,9 +12,32 @@ const (
 	EventTypeMigrate           = "migrate"
 	EventTypePinCode           = "pin_code"
 	EventTypeUnpinCode         = "unpin_code"
+	EventTypePruneCode         = "prune_code"
+	EventTypeDeactivate        = "deactivate_contract"
+	EventTypeActivate          = "activate_contract"
+	EventTypeRegisterCron      = "register_cron_contract"
+	EventTypeUnregisterCron    = "unregister_cron_contract"
+	EventTypeScheduleCall      = "schedule_contract_call"
+	EventTypeDepositRent       = "deposit_rent"
+	EventTypeRentExhausted     = "state_rent_exhausted"
+	EventTypePendingAdmin      = "pending_admin_change"
+	EventTypePendingMigration  = "pending_migration"
+	EventTypePendingOperator   = "pending_operator_change"
+	EventTypePendingSudo       = "pending_operator_sudo"
+	EventTypeExecuteGasLimit   = "update_execute_gas_limit"
+	EventTypeUpdateAdmin       = "update_admin"
+	EventTypeClearAdmin        = "clear_admin"
+	EventTypeUpdateOperator    = "update_contract_operator"
+	EventTypeUpdateLabel       = "update_contract_label"
+	EventTypeGrantCapability   = "grant_contract_capability"
+	EventTypeRevokeCapability  = "revoke_contract_capability"
 	EventTypeSudo              = "sudo"
 	EventTypeReply             = "reply"
 	EventTypeGovContractResult = "gov_contract_result"
+	EventTypeICS20Callback     = "ics20_callback"
+
+	// EventTypeUpdateContractInfoExtension is emitted when the extension data of a contract info is set or removed
+	EventTypeUpdateContractInfoExtension = "update_contract_info_extension"
 )
 
 // event attributes returned from contract execution
@@ -23,6 +46,19 @@ const (
 
 	AttributeKeyContractAddr  = "_contract_address"
 	AttributeKeyCodeID        = "code_id"
+	AttributeKeyChecksum      = "code_checksum"
 	AttributeKeyResultDataHex = "result"
 	AttributeKeyFeature       = "feature"
+	AttributeKeyCallbackError = "error"
+	AttributeKeyGasLimit      = "gas_limit"
+	AttributeKeyAdmin         = "admin"
+	AttributeKeyOperator      = "operator"
+	AttributeKeyLabel         = "label"
+	AttributeKeyMsgIndex      = "_msg_index"
+	AttributeKeyReplyID       = "_reply_id"
+	AttributeKeyExtensionType = "extension_type"
+	AttributeKeyDeferredID    = "deferred_call_id"
+	AttributeKeyDeposit       = "deposit"
+	AttributeKeyHeight        = "height"
+	AttributeKeyCapability    = "capability"
 )
@@ -13,13 +13,22 @@ type ViewKeeper interface {
 	QueryRaw(ctx sdk.Context, contractAddress sdk.AccAddress, key []byte) []byte
 	HasContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) bool
 	GetContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) *ContractInfo
+	ReadContractInfoExtension(ctx sdk.Context, contractAddress sdk.AccAddress, ext ContractInfoExtension) error
 	IterateContractInfo(ctx sdk.Context, cb func(sdk.AccAddress, ContractInfo) bool)
 	IterateContractsByCode(ctx sdk.Context, codeID uint64, cb func(address sdk.AccAddress) bool)
+	IterateContractsByCreator(ctx sdk.Context, creator sdk.AccAddress, cb func(address sdk.AccAddress) bool)
+	IterateContractsByAdmin(ctx sdk.Context, admin sdk.AccAddress, cb func(address sdk.AccAddress) bool)
 	IterateContractState(ctx sdk.Context, contractAddress sdk.AccAddress, cb func(key, value []byte) bool)
+	GetContractStateSize(ctx sdk.Context, contractAddress sdk.AccAddress) ContractStateSize
+	GetContractRent(ctx sdk.Context, contractAddress sdk.AccAddress) ContractRent
 	GetCodeInfo(ctx sdk.Context, codeID uint64) *CodeInfo
 	IterateCodeInfos(ctx sdk.Context, cb func(uint64, CodeInfo) bool)
 	GetByteCode(ctx sdk.Context, codeID uint64) ([]byte, error)
 	IsPinnedCode(ctx sdk.Context, codeID uint64) bool
+	GetVMCacheMetrics() (*wasmvmtypes.Metrics, error)
+	IsInactiveContract(ctx sdk.Context, contractAddress sdk.AccAddress) bool
+	IsCronContract(ctx sdk.Context, contractAddress sdk.AccAddress) bool
+	GetParams(ctx sdk.Context) Params
 }
 
 // ContractOpsKeeper contains mutable operations on a contract.
@@ -34,14 +43,23 @@ type ContractOpsKeeper interface {
 	Execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error)
 
 	// Migrate allows to upgrade a contract to a new code with data migration.
+	// The capabilities that were granted to the contract are revoked.
 	Migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte) ([]byte, error)
 
+	// ScheduleMigrate stores a migration that takes effect after the admin timelock.
+	// It returns the block height in which the migration is applied.
+	ScheduleMigrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte) (uint64, error)
+
 	// Sudo allows to call privileged entry point of a contract.
 	Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error)
 
 	// UpdateContractAdmin sets the admin value on the ContractInfo. It must be a valid address (use ClearContractAdmin to remove it)
 	UpdateContractAdmin(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newAdmin sdk.AccAddress) error
 
+	// ScheduleContractAdminUpdate stores an admin change that takes effect after the admin timelock.
+	// It returns the block height in which the change is applied.
+	ScheduleContractAdminUpdate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newAdmin sdk.AccAddress) (uint64, error)
+
 	// ClearContractAdmin sets the admin value on the ContractInfo to nil, to disable further migrations/ updates.
 	ClearContractAdmin(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress) error
 
@@ -51,8 +69,63 @@ type ContractOpsKeeper interface {
 	// UnpinCode removes the wasm contract from wasmvm cache
 	UnpinCode(ctx sdk.Context, codeID uint64) error
 
+	// PruneCode deletes the byte code of a code id without contracts and pin. The code info is kept.
+	PruneCode(ctx sdk.Context, codeID uint64) error
+
+	// GrantContractCapability grants a privileged capability to the contract
+	GrantContractCapability(ctx sdk.Context, contractAddress sdk.AccAddress, capability string) error
+
+	// RevokeContractCapability removes a privileged capability from the contract
+	RevokeContractCapability(ctx sdk.Context, contractAddress sdk.AccAddress, capability string) error
+
+	// DeactivateContract marks the contract inactive. Execute, migrate and IBC calls to it are rejected.
+	DeactivateContract(ctx sdk.Context, contractAddress sdk.AccAddress) error
+
+	// ActivateContract removes the inactive mark from the contract
+	ActivateContract(ctx sdk.Context, contractAddress sdk.AccAddress) error
+
+	// RegisterCronContract adds the contract to the set of contracts whose sudo entry point is called
+	// in every begin and end block
+	RegisterCronContract(ctx sdk.Context, contractAddress sdk.AccAddress) error
+
+	// UnregisterCronContract removes the contract from the set of cron contracts
+	UnregisterCronContract(ctx sdk.Context, contractAddress sdk.AccAddress) error
+
+	// ScheduleContractCall stores a message that is delivered to the sudo entry point of the contract at the
+	// given block height or time, wrapped in a `deferred_call` envelope. It returns the id of the deferred call.
+	ScheduleContractCall(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte, height, time uint64) (uint64, error)
+
+	// DepositRent adds the amount to the state rent deposit of the contract
+	DepositRent(ctx sdk.Context, sender, contractAddress sdk.AccAddress, amount sdk.Coin) error
+
+	// UpdateExecuteGasLimit sets the max gas a single execution of the contract may consume. Zero removes the limit.
+	UpdateExecuteGasLimit(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, gasLimit uint64) error
+
+	// UpdateContractOperator sets the operator of the contract and the sudo messages it is allowed to send.
+	// Only the admin can set the operator, a nil operator removes it.
+	UpdateContractOperator(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, operator sdk.AccAddress, sudoMsgs []string) error
+
+	// ScheduleContractOperatorUpdate stores an operator change that takes effect after the admin timelock.
+	// It returns the block height in which the change is applied.
+	ScheduleContractOperatorUpdate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, operator sdk.AccAddress, sudoMsgs []string) (uint64, error)
+
+	// UpdateContractLabel sets a new label for the contract. It can be set by the admin or the operator.
+	UpdateContractLabel(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, label string) error
+
+	// OperatorSudo calls the sudo entry point of the contract on behalf of the admin or operator. The top level key
+	// of the message must be allowed for the operator.
+	OperatorSudo(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte) ([]byte, error)
+
+	// ScheduleOperatorSudo stores an operator sudo call that is executed after the admin timelock.
+	// It returns the block height in which the call is executed.
+	ScheduleOperatorSudo(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte) (uint64, error)
+
 	// SetContractInfoExtension updates the extension point data that is stored with the contract info
 	SetContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra ContractInfoExtension) error
+
+	// SetCodeSource stores the source url and builder image of the code for reproducible build verification.
+	// Only the creator of the code can set them.
+	SetCodeSource(ctx sdk.Context, codeID uint64, caller sdk.AccAddress, source, builder string) error
 }
 
 // IBCContractKeeper IBC lifecycle event handler
@@ -93,3 +166,10 @@ type IBCContractKeeper interface {
 	// AuthenticateCapability wraps the scopedKeeper's AuthenticateCapability function
 	AuthenticateCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) bool
 }
+
+// ICS20CallbackKeeper notifies contracts about the outcome of the ICS-20 transfers they have sent
+type ICS20CallbackKeeper interface {
+	HasContractCapability(ctx sdk.Context, contractAddr sdk.AccAddress, capability string) bool
+	// Sudo allows to call privileged entry point of a contract.
+	Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error)
+}
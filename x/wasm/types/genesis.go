@@ -37,6 +37,31 @@ func (s GenesisState) ValidateBasic() error {
 			return sdkerrors.Wrapf(err, "gen message: %d", i)
 		}
 	}
+	for i := range s.DeferredCalls {
+		if err := s.DeferredCalls[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "deferred call: %d", i)
+		}
+	}
+	for i := range s.PendingAdminChanges {
+		if err := s.PendingAdminChanges[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "pending admin change: %d", i)
+		}
+	}
+	for i := range s.PendingMigrations {
+		if err := s.PendingMigrations[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "pending migration: %d", i)
+		}
+	}
+	for i := range s.PendingOperatorChanges {
+		if err := s.PendingOperatorChanges[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "pending operator change: %d", i)
+		}
+	}
+	for i := range s.PendingOperatorSudos {
+		if err := s.PendingOperatorSudos[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "pending operator sudo: %d", i)
+		}
+	}
 	return nil
 }
 
@@ -47,6 +72,15 @@ func (c Code) ValidateBasic() error {
 	if err := c.CodeInfo.ValidateBasic(); err != nil {
 		return sdkerrors.Wrap(err, "code info")
 	}
+	if c.Pruned {
+		if c.Pinned {
+			return sdkerrors.Wrap(ErrInvalid, "pruned code can not be pinned")
+		}
+		if len(c.CodeBytes) != 0 {
+			return sdkerrors.Wrap(ErrInvalid, "pruned code must not have code bytes")
+		}
+		return nil
+	}
 	if err := validateWasmCode(c.CodeBytes); err != nil {
 		return sdkerrors.Wrap(err, "code bytes")
 	}
@@ -69,6 +103,19 @@ func (c Contract) ValidateBasic() error {
 			return sdkerrors.Wrapf(err, "contract state %d", i)
 		}
 	}
+	for i := range c.ContractCodeHistory {
+		if err := c.ContractCodeHistory[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "contract code history %d", i)
+		}
+	}
+	if c.Rent != nil {
+		if err := c.Rent.Deposit.Validate(); err != nil {
+			return sdkerrors.Wrap(err, "rent deposit")
+		}
+	}
+	if err := validateCapabilities(c.Capabilities); err != nil {
+		return sdkerrors.Wrap(err, "capabilities")
+	}
 	return nil
 }
 
@@ -31,6 +31,19 @@ type GenesisState struct {
 	Contracts []Contract             `protobuf:"bytes,3,rep,name=contracts,proto3" json:"contracts,omitempty"`
 	Sequences []Sequence             `protobuf:"bytes,4,rep,name=sequences,proto3" json:"sequences,omitempty"`
 	GenMsgs   []GenesisState_GenMsgs `protobuf:"bytes,5,rep,name=gen_msgs,json=genMsgs,proto3" json:"gen_msgs,omitempty"`
+	// DeferredCalls are the scheduled contract calls that were not delivered yet
+	DeferredCalls []DeferredCall `protobuf:"bytes,6,rep,name=deferred_calls,json=deferredCalls,proto3" json:"deferred_calls,omitempty"`
+	// PendingAdminChanges are the timelocked admin changes that are not applied
+	// yet
+	PendingAdminChanges []PendingAdminChange `protobuf:"bytes,7,rep,name=pending_admin_changes,json=pendingAdminChanges,proto3" json:"pending_admin_changes,omitempty"`
+	// PendingMigrations are the timelocked migrations that are not applied yet
+	PendingMigrations []PendingMigration `protobuf:"bytes,8,rep,name=pending_migrations,json=pendingMigrations,proto3" json:"pending_migrations,omitempty"`
+	// PendingOperatorChanges are the timelocked operator changes that are not
+	// applied yet
+	PendingOperatorChanges []PendingOperatorChange `protobuf:"bytes,9,rep,name=pending_operator_changes,json=pendingOperatorChanges,proto3" json:"pending_operator_changes,omitempty"`
+	// PendingOperatorSudos are the timelocked operator sudo calls that are not
+	// executed yet
+	PendingOperatorSudos []PendingOperatorSudo `protobuf:"bytes,10,rep,name=pending_operator_sudos,json=pendingOperatorSudos,proto3" json:"pending_operator_sudos,omitempty"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -101,8 +114,45 @@ func (m *GenesisState) GetGenMsgs() []GenesisState_GenMsgs {
 	return nil
 }
 
+func (m *GenesisState) GetDeferredCalls() []DeferredCall {
+	if m != nil {
+		return m.DeferredCalls
+	}
+	return nil
+}
+
+func (m *GenesisState) GetPendingAdminChanges() []PendingAdminChange {
+	if m != nil {
+		return m.PendingAdminChanges
+	}
+	return nil
+}
+
+func (m *GenesisState) GetPendingMigrations() []PendingMigration {
+	if m != nil {
+		return m.PendingMigrations
+	}
+	return nil
+}
+
+func (m *GenesisState) GetPendingOperatorChanges() []PendingOperatorChange {
+	if m != nil {
+		return m.PendingOperatorChanges
+	}
+	return nil
+}
+
+func (m *GenesisState) GetPendingOperatorSudos() []PendingOperatorSudo {
+	if m != nil {
+		return m.PendingOperatorSudos
+	}
+	return nil
+}
+
 // GenMsgs define the messages that can be executed during genesis phase in
 // order. The intention is to have more human readable data that is auditable.
+// Contracts instantiated by these messages get an address derived from the
+// code checksum and label so that it is known before the chain starts.
 type GenesisState_GenMsgs struct {
 	// sum is a single message
 	//
@@ -210,6 +260,8 @@ type Code struct {
 	CodeBytes []byte   `protobuf:"bytes,3,opt,name=code_bytes,json=codeBytes,proto3" json:"code_bytes,omitempty"`
 	// Pinned to wasmvm cache
 	Pinned bool `protobuf:"varint,4,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// Pruned code without byte code. The code can not be instantiated anymore.
+	Pruned bool `protobuf:"varint,5,opt,name=pruned,proto3" json:"pruned,omitempty"`
 }
 
 func (m *Code) Reset()         { *m = Code{} }
@@ -273,11 +325,29 @@ func (m *Code) GetPinned() bool {
 	return false
 }
 
+func (m *Code) GetPruned() bool {
+	if m != nil {
+		return m.Pruned
+	}
+	return false
+}
+
 // Contract struct encompasses ContractAddress, ContractInfo, and ContractState
 type Contract struct {
 	ContractAddress string       `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
 	ContractInfo    ContractInfo `protobuf:"bytes,2,opt,name=contract_info,json=contractInfo,proto3" json:"contract_info"`
 	ContractState   []Model      `protobuf:"bytes,3,rep,name=contract_state,json=contractState,proto3" json:"contract_state"`
+	// ContractCodeHistory contains the code history entries. When empty a
+	// genesis entry is created on import.
+	ContractCodeHistory []ContractCodeHistoryEntry `protobuf:"bytes,4,rep,name=contract_code_history,json=contractCodeHistory,proto3" json:"contract_code_history"`
+	// Inactive contracts are rejected on execute, migrate and IBC calls
+	Inactive bool `protobuf:"varint,5,opt,name=inactive,proto3" json:"inactive,omitempty"`
+	// Cron contracts are called via sudo in every begin and end block
+	Cron bool `protobuf:"varint,6,opt,name=cron,proto3" json:"cron,omitempty"`
+	// Rent is the state rent account of the contract, optional
+	Rent *ContractRent `protobuf:"bytes,7,opt,name=rent,proto3" json:"rent,omitempty"`
+	// Capabilities are the privileges granted to the contract by governance
+	Capabilities []string `protobuf:"bytes,8,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 }
 
 func (m *Contract) Reset()         { *m = Contract{} }
@@ -334,6 +404,41 @@ func (m *Contract) GetContractState() []Model {
 	return nil
 }
 
+func (m *Contract) GetContractCodeHistory() []ContractCodeHistoryEntry {
+	if m != nil {
+		return m.ContractCodeHistory
+	}
+	return nil
+}
+
+func (m *Contract) GetInactive() bool {
+	if m != nil {
+		return m.Inactive
+	}
+	return false
+}
+
+func (m *Contract) GetCron() bool {
+	if m != nil {
+		return m.Cron
+	}
+	return false
+}
+
+func (m *Contract) GetRent() *ContractRent {
+	if m != nil {
+		return m.Rent
+	}
+	return nil
+}
+
+func (m *Contract) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 // Sequence key and value of an id generation counter
 type Sequence struct {
 	IDKey []byte `protobuf:"bytes,1,opt,name=id_key,json=idKey,proto3" json:"id_key,omitempty"`
@@ -398,48 +503,66 @@ func init() {
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/genesis.proto", fileDescriptor_2ab3f539b23472a6) }
 
 var fileDescriptor_2ab3f539b23472a6 = []byte{
-	// 646 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0xcf, 0x6e, 0xd3, 0x4e,
-	0x10, 0xc7, 0xe3, 0x26, 0x4e, 0x93, 0x69, 0x7e, 0xbf, 0x56, 0xdb, 0xaa, 0x35, 0x06, 0x9c, 0x28,
-	0xa0, 0x2a, 0x48, 0x28, 0x51, 0x8b, 0xc4, 0x0d, 0x01, 0x6e, 0x2b, 0x6a, 0x55, 0x95, 0xc0, 0x15,
-	0x42, 0x42, 0xaa, 0x22, 0xd7, 0xde, 0x1a, 0x8b, 0xda, 0x1b, 0xb2, 0x9b, 0x52, 0x9f, 0x79, 0x01,
-	0x1e, 0x01, 0x5e, 0x06, 0xf5, 0xd8, 0x23, 0xa7, 0x08, 0xa5, 0x37, 0x9e, 0x02, 0xed, 0x1f, 0xbb,
-	0x06, 0xa7, 0x17, 0x2b, 0x3b, 0xf3, 0x9d, 0xcf, 0xfc, 0xc9, 0xec, 0x82, 0xe5, 0x13, 0x1a, 0x7f,
-	0xf6, 0x68, 0x3c, 0x10, 0x9f, 0xf3, 0xad, 0x41, 0x88, 0x13, 0x4c, 0x23, 0xda, 0x1f, 0x8d, 0x09,
-	0x23, 0x68, 0x25, 0xf3, 0xf7, 0xc5, 0xe7, 0x7c, 0xcb, 0x5c, 0x0b, 0x49, 0x48, 0x84, 0x73, 0xc0,
-	0x7f, 0x49, 0x9d, 0x79, 0xaf, 0xc4, 0x61, 0xe9, 0x08, 0x2b, 0x8a, 0x79, 0xa7, 0xec, 0xbd, 0x90,
-	0xae, 0xee, 0x37, 0x1d, 0x5a, 0xaf, 0x64, 0xca, 0x23, 0xe6, 0x31, 0x8c, 0x9e, 0x42, 0x7d, 0xe4,
-	0x8d, 0xbd, 0x98, 0x1a, 0x5a, 0x47, 0xeb, 0x2d, 0x6d, 0x1b, 0xfd, 0x7f, 0x4b, 0xe8, 0xbf, 0x16,
-	0x7e, 0xbb, 0x76, 0x39, 0x6d, 0x57, 0x5c, 0xa5, 0x46, 0x7b, 0xa0, 0xfb, 0x24, 0xc0, 0xd4, 0x58,
-	0xe8, 0x54, 0x7b, 0x4b, 0xdb, 0xeb, 0xe5, 0xb0, 0x1d, 0x12, 0x60, 0x7b, 0x83, 0x07, 0xfd, 0x9e,
-	0xb6, 0x97, 0x85, 0xf8, 0x31, 0x89, 0x23, 0x86, 0xe3, 0x11, 0x4b, 0x5d, 0x19, 0x8d, 0xde, 0x42,
-	0xd3, 0x27, 0x09, 0x1b, 0x7b, 0x3e, 0xa3, 0x46, 0x55, 0xa0, 0xcc, 0x79, 0x28, 0x29, 0xb1, 0xef,
-	0x2a, 0xdc, 0x6a, 0x1e, 0x54, 0x40, 0xde, 0x90, 0x38, 0x96, 0xe2, 0x4f, 0x13, 0x9c, 0xf8, 0x98,
-	0x1a, 0xb5, 0xdb, 0xb0, 0x47, 0x4a, 0x72, 0x83, 0xcd, 0x83, 0x8a, 0xd8, 0xdc, 0x88, 0x8e, 0xa1,
-	0x11, 0xe2, 0x64, 0x18, 0xd3, 0x90, 0x1a, 0xba, 0xa0, 0x6e, 0x96, 0xa9, 0xc5, 0xf1, 0xf2, 0xc3,
-	0x21, 0x0d, 0xa9, 0x6d, 0xaa, 0x0c, 0x28, 0x8b, 0x2f, 0x24, 0x58, 0x0c, 0xa5, 0xc8, 0xfc, 0xb2,
-	0x00, 0x8b, 0x2a, 0x00, 0x3d, 0x07, 0xa0, 0x8c, 0x8c, 0xf1, 0x90, 0xcf, 0x49, 0xfd, 0x37, 0x56,
-	0x39, 0xd9, 0x21, 0x0d, 0x8f, 0xb8, 0x8c, 0x0f, 0x7b, 0xbf, 0xe2, 0x36, 0x69, 0x76, 0x40, 0xc7,
-	0xb0, 0x16, 0x25, 0x94, 0x79, 0x09, 0x8b, 0x3c, 0xc6, 0x31, 0x72, 0x36, 0xc6, 0x82, 0x40, 0xf5,
-	0xe6, 0xa2, 0x9c, 0x9b, 0x80, 0x6c, 0xe4, 0xfb, 0x15, 0x77, 0x35, 0x2a, 0x9b, 0xd1, 0x1b, 0x58,
-	0xc1, 0x17, 0xd8, 0x9f, 0x14, 0xd1, 0x55, 0x81, 0x7e, 0x38, 0x17, 0xbd, 0x27, 0xc5, 0x05, 0xec,
-	0x32, 0xfe, 0xdb, 0x64, 0xeb, 0x50, 0xa5, 0x93, 0xb8, 0xfb, 0x5d, 0x83, 0x9a, 0xe8, 0xe0, 0x01,
-	0x2c, 0xf2, 0xe6, 0x87, 0x51, 0x20, 0xfa, 0xaf, 0xd9, 0x30, 0x9b, 0xb6, 0xeb, 0xdc, 0xe5, 0xec,
-	0xba, 0x75, 0xee, 0x72, 0x02, 0xf4, 0x8c, 0x2f, 0x10, 0x17, 0x25, 0xa7, 0x44, 0xf5, 0x66, 0xce,
-	0xdf, 0x45, 0x27, 0x39, 0x25, 0x6a, 0x89, 0x1b, 0xbe, 0x3a, 0xa3, 0xfb, 0x00, 0x22, 0xfc, 0x24,
-	0x65, 0x98, 0x8a, 0x06, 0x5a, 0xae, 0x00, 0xda, 0xdc, 0x80, 0xd6, 0xa1, 0x3e, 0x8a, 0x92, 0x04,
-	0x07, 0x46, 0xad, 0xa3, 0xf5, 0x1a, 0xae, 0x3a, 0x75, 0x7f, 0x68, 0xd0, 0xc8, 0x47, 0xf1, 0x08,
-	0x56, 0xb2, 0x11, 0x0c, 0xbd, 0x20, 0x18, 0x63, 0x2a, 0x2f, 0x53, 0xd3, 0x5d, 0xce, 0xec, 0x2f,
-	0xa5, 0x19, 0x39, 0xf0, 0x5f, 0x2e, 0x2d, 0x54, 0x6c, 0xdd, 0xbe, 0xf2, 0x85, 0xaa, 0x5b, 0x7e,
-	0xc1, 0x86, 0x76, 0xe1, 0xff, 0x1c, 0x45, 0xf9, 0xae, 0xa9, 0xeb, 0xb3, 0x31, 0x67, 0xfc, 0x24,
-	0xc0, 0x67, 0x0a, 0x92, 0xe7, 0x17, 0xfb, 0xd9, 0xb5, 0xa1, 0x91, 0xdd, 0x02, 0xd4, 0x81, 0x7a,
-	0x14, 0x0c, 0x3f, 0xe2, 0x54, 0x54, 0xdf, 0xb2, 0x9b, 0xb3, 0x69, 0x5b, 0x77, 0x76, 0x0f, 0x70,
-	0xea, 0xea, 0x51, 0x70, 0x80, 0x53, 0xb4, 0x06, 0xfa, 0xb9, 0x77, 0x36, 0xc1, 0xa2, 0xec, 0x9a,
-	0x2b, 0x0f, 0xf6, 0x8b, 0xcb, 0x99, 0xa5, 0x5d, 0xcd, 0x2c, 0xed, 0xd7, 0xcc, 0xd2, 0xbe, 0x5e,
-	0x5b, 0x95, 0xab, 0x6b, 0xab, 0xf2, 0xf3, 0xda, 0xaa, 0xbc, 0xdf, 0x0c, 0x23, 0xf6, 0x61, 0x72,
-	0xd2, 0xf7, 0x49, 0x3c, 0xd8, 0x21, 0x34, 0x7e, 0x97, 0xbd, 0x49, 0xc1, 0xe0, 0x42, 0xbe, 0x4d,
-	0xe2, 0xd9, 0x3a, 0xa9, 0x8b, 0xc7, 0xe9, 0xc9, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x7c, 0x05,
-	0x87, 0xde, 0x1f, 0x05, 0x00, 0x00,
+	// 937 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xf7, 0xc6, 0xff, 0x5f, 0xdd, 0x26, 0x4c, 0xd2, 0x74, 0x31, 0xc5, 0xb6, 0x4c, 0x69, 0x4d,
+	0x85, 0x6c, 0x35, 0x48, 0xdc, 0x10, 0x74, 0x93, 0x88, 0x5a, 0x55, 0x04, 0x6c, 0x84, 0x90, 0x90,
+	0x2a, 0x6b, 0xb2, 0x33, 0xd9, 0x8c, 0xf0, 0xce, 0x2c, 0x3b, 0xe3, 0x34, 0x3e, 0x22, 0xc4, 0x11,
+	0x89, 0x4f, 0xc0, 0xa7, 0xe0, 0xc2, 0x37, 0xe8, 0xb1, 0x47, 0x4e, 0x11, 0x72, 0x6e, 0x7c, 0x0a,
+	0xb4, 0xb3, 0xb3, 0x9b, 0x4d, 0x77, 0xad, 0x5c, 0x6c, 0xcf, 0x7b, 0xbf, 0x3f, 0xef, 0xcd, 0xce,
+	0x3c, 0x2f, 0xf4, 0x3c, 0x21, 0x83, 0xd7, 0x58, 0x06, 0x13, 0xfd, 0x71, 0xfe, 0x6c, 0xe2, 0x53,
+	0x4e, 0x25, 0x93, 0xe3, 0x30, 0x12, 0x4a, 0xa0, 0xad, 0x34, 0x3f, 0xd6, 0x1f, 0xe7, 0xcf, 0xba,
+	0x3b, 0xbe, 0xf0, 0x85, 0x4e, 0x4e, 0xe2, 0x5f, 0x09, 0xae, 0xfb, 0xb0, 0xa0, 0xa3, 0x96, 0x21,
+	0x35, 0x2a, 0xdd, 0xf7, 0x8b, 0xd9, 0x8b, 0x24, 0x35, 0xfc, 0x13, 0xa0, 0xf3, 0x75, 0x62, 0x79,
+	0xac, 0xb0, 0xa2, 0xe8, 0x73, 0x68, 0x84, 0x38, 0xc2, 0x81, 0xb4, 0xad, 0x81, 0x35, 0xba, 0xb3,
+	0x67, 0x8f, 0xdf, 0x2d, 0x61, 0xfc, 0xad, 0xce, 0x3b, 0xb5, 0x37, 0x97, 0xfd, 0x8a, 0x6b, 0xd0,
+	0xe8, 0x10, 0xea, 0x9e, 0x20, 0x54, 0xda, 0x1b, 0x83, 0xea, 0xe8, 0xce, 0xde, 0x6e, 0x91, 0xb6,
+	0x2f, 0x08, 0x75, 0x1e, 0xc4, 0xa4, 0xff, 0x2e, 0xfb, 0x9b, 0x1a, 0xfc, 0xa9, 0x08, 0x98, 0xa2,
+	0x41, 0xa8, 0x96, 0x6e, 0xc2, 0x46, 0xdf, 0x43, 0xdb, 0x13, 0x5c, 0x45, 0xd8, 0x53, 0xd2, 0xae,
+	0x6a, 0xa9, 0x6e, 0x99, 0x54, 0x02, 0x71, 0x3e, 0x30, 0x72, 0xdb, 0x19, 0x29, 0x27, 0x79, 0xad,
+	0x14, 0xcb, 0x4a, 0xfa, 0xf3, 0x82, 0x72, 0x8f, 0x4a, 0xbb, 0xb6, 0x4e, 0xf6, 0xd8, 0x40, 0xae,
+	0x65, 0x33, 0x52, 0x5e, 0x36, 0x0b, 0xa2, 0x57, 0xd0, 0xf2, 0x29, 0x9f, 0x05, 0xd2, 0x97, 0x76,
+	0x5d, 0xab, 0x3e, 0x2e, 0xaa, 0xe6, 0xb7, 0x37, 0x5e, 0x1c, 0x49, 0x5f, 0x3a, 0x5d, 0xe3, 0x80,
+	0x52, 0x7e, 0xce, 0xa0, 0xe9, 0x27, 0x20, 0xe4, 0xc3, 0x3d, 0x42, 0x4f, 0x69, 0x14, 0x51, 0x32,
+	0xf3, 0xf0, 0x7c, 0x2e, 0xed, 0x86, 0x36, 0xe9, 0x15, 0x4d, 0x0e, 0x0c, 0x6e, 0x1f, 0xcf, 0xe7,
+	0xce, 0xc0, 0x88, 0xdb, 0x37, 0xd9, 0x39, 0x8b, 0xbb, 0x24, 0x87, 0x97, 0xe8, 0x17, 0x0b, 0xee,
+	0x87, 0x94, 0x13, 0xc6, 0xfd, 0x19, 0x26, 0x01, 0xe3, 0x33, 0xef, 0x0c, 0x73, 0x9f, 0x4a, 0xbb,
+	0xa9, 0x0d, 0x1f, 0x95, 0x1c, 0x82, 0x04, 0xfe, 0x3c, 0x46, 0xef, 0x6b, 0xb0, 0xf3, 0xc4, 0xd8,
+	0xf6, 0x4b, 0xa5, 0x72, 0xee, 0xdb, 0x61, 0x81, 0x2c, 0xd1, 0x6b, 0x40, 0x29, 0x2f, 0x60, 0x7e,
+	0x84, 0x15, 0x13, 0x5c, 0xda, 0x2d, 0xed, 0x3f, 0x5c, 0xeb, 0x7f, 0x94, 0x42, 0x9d, 0x47, 0xc6,
+	0xfd, 0x61, 0x51, 0x25, 0x67, 0xfd, 0x5e, 0xf8, 0x0e, 0x4f, 0xa2, 0xdf, 0x2d, 0xb0, 0x53, 0x8e,
+	0x08, 0x69, 0x84, 0x95, 0x88, 0xb2, 0xfe, 0xdb, 0xda, 0xff, 0xc9, 0x5a, 0xff, 0x6f, 0x0c, 0xc1,
+	0x6c, 0xc1, 0x53, 0x53, 0xc4, 0x70, 0x9d, 0x60, 0xae, 0x94, 0xdd, 0xb0, 0x4c, 0x42, 0xa2, 0xdf,
+	0x2c, 0xd8, 0x2d, 0xd0, 0xe5, 0x82, 0x08, 0x69, 0x83, 0xae, 0xe6, 0xe3, 0x5b, 0xab, 0x39, 0x5e,
+	0x10, 0xe1, 0x8c, 0x4c, 0x2d, 0x83, 0x72, 0xb1, 0x5c, 0x25, 0x3b, 0x61, 0x91, 0x2e, 0xbb, 0xbf,
+	0x6e, 0x40, 0xd3, 0x1c, 0x57, 0xf4, 0x25, 0x80, 0x54, 0x22, 0xa2, 0xb3, 0xf8, 0x96, 0x9a, 0xc9,
+	0x50, 0x72, 0x0a, 0x8f, 0xa4, 0x7f, 0x1c, 0xc3, 0xe2, 0xab, 0xfe, 0xa2, 0xe2, 0xb6, 0x65, 0xba,
+	0x40, 0xaf, 0x60, 0x87, 0x71, 0xa9, 0x30, 0x57, 0x0c, 0x2b, 0x3a, 0x4b, 0x6f, 0xa6, 0xbd, 0xa1,
+	0xa5, 0x46, 0xa5, 0x52, 0xd3, 0x6b, 0x42, 0x7a, 0xe1, 0x5f, 0x54, 0xdc, 0x6d, 0x56, 0x0c, 0xa3,
+	0xef, 0x60, 0x8b, 0x5e, 0x50, 0x6f, 0x91, 0x97, 0xae, 0x0e, 0xac, 0xf2, 0xa3, 0x7b, 0x24, 0xfd,
+	0xc3, 0x04, 0x9c, 0x93, 0xdd, 0xa4, 0x37, 0x43, 0x4e, 0x1d, 0xaa, 0x72, 0x11, 0x0c, 0xff, 0xb6,
+	0xa0, 0xa6, 0x3b, 0xf8, 0x08, 0x9a, 0x71, 0xf3, 0x33, 0x46, 0x74, 0xff, 0x35, 0x07, 0x56, 0x97,
+	0xfd, 0x46, 0x9c, 0x9a, 0x1e, 0xb8, 0x8d, 0x38, 0x35, 0x25, 0xe8, 0x0b, 0x68, 0x27, 0x20, 0x7e,
+	0x2a, 0x4c, 0x6f, 0xdd, 0xf2, 0x49, 0x38, 0xe5, 0xa7, 0xc2, 0x8c, 0xd0, 0x96, 0x67, 0xd6, 0xe8,
+	0x43, 0x00, 0x4d, 0x3f, 0x59, 0x2a, 0x2a, 0x75, 0x03, 0x1d, 0x57, 0x0b, 0x3a, 0x71, 0x00, 0xed,
+	0x42, 0x23, 0x64, 0x9c, 0x53, 0x62, 0xd7, 0x06, 0xd6, 0xa8, 0xe5, 0x9a, 0x95, 0x8e, 0x47, 0x8b,
+	0x38, 0x5e, 0x37, 0x71, 0xbd, 0x1a, 0xfe, 0x55, 0x85, 0x56, 0xb6, 0x45, 0x9f, 0xc0, 0x56, 0xba,
+	0x35, 0x33, 0x4c, 0x48, 0x44, 0x65, 0x32, 0xe2, 0xdb, 0xee, 0x66, 0x1a, 0x7f, 0x9e, 0x84, 0xd1,
+	0x14, 0xee, 0x66, 0xd0, 0x5c, 0x27, 0xbd, 0xf5, 0x83, 0x38, 0xd7, 0x4d, 0xc7, 0xcb, 0xc5, 0xd0,
+	0x01, 0xdc, 0xcb, 0xa4, 0xa4, 0xc2, 0x8a, 0x9a, 0xa1, 0xfe, 0xa0, 0xe4, 0xb1, 0x08, 0x42, 0xe7,
+	0x46, 0x24, 0xf3, 0x4f, 0xfe, 0x94, 0x08, 0xdc, 0xcf, 0x54, 0xf4, 0x06, 0x9d, 0xb1, 0xf8, 0x68,
+	0x2d, 0xcd, 0x28, 0x7f, 0xba, 0xbe, 0x30, 0x7d, 0x12, 0x13, 0xf0, 0x21, 0x57, 0xd1, 0xd2, 0xe8,
+	0x6f, 0x7b, 0xc5, 0x3c, 0xea, 0x42, 0x8b, 0x71, 0xec, 0x29, 0x76, 0x4e, 0xcd, 0x46, 0x66, 0x6b,
+	0x84, 0xa0, 0xe6, 0x45, 0x82, 0xdb, 0x0d, 0x1d, 0xd7, 0xbf, 0xd1, 0x1e, 0xd4, 0x22, 0xca, 0x95,
+	0xdd, 0xbc, 0x6d, 0x77, 0x5c, 0xca, 0x95, 0xab, 0xb1, 0x68, 0x08, 0x1d, 0x0f, 0x87, 0xf8, 0x84,
+	0xcd, 0x99, 0x62, 0x34, 0x99, 0x6f, 0x6d, 0xf7, 0x46, 0x6c, 0xe8, 0x40, 0x2b, 0xfd, 0x27, 0x42,
+	0x03, 0x68, 0x30, 0x32, 0xfb, 0x89, 0x2e, 0xf5, 0xb3, 0xea, 0x38, 0xed, 0xd5, 0x65, 0xbf, 0x3e,
+	0x3d, 0x78, 0x49, 0x97, 0x6e, 0x9d, 0x91, 0x97, 0x74, 0x89, 0x76, 0xa0, 0x7e, 0x8e, 0xe7, 0x0b,
+	0xaa, 0x1f, 0x52, 0xcd, 0x4d, 0x16, 0xce, 0x57, 0x6f, 0x56, 0x3d, 0xeb, 0xed, 0xaa, 0x67, 0xfd,
+	0xbb, 0xea, 0x59, 0x7f, 0x5c, 0xf5, 0x2a, 0x6f, 0xaf, 0x7a, 0x95, 0x7f, 0xae, 0x7a, 0x95, 0x1f,
+	0x1f, 0xfb, 0x4c, 0x9d, 0x2d, 0x4e, 0xc6, 0x9e, 0x08, 0x26, 0xfb, 0x42, 0x06, 0x3f, 0xa4, 0xef,
+	0x05, 0x64, 0x72, 0xa1, 0xbf, 0x93, 0x57, 0x87, 0x93, 0x86, 0x7e, 0x41, 0xf8, 0xec, 0xff, 0x01,
+	0x00, 0xcb, 0xfd, 0xab, 0x09, 0xa3, 0x08, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -462,6 +585,76 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.PendingOperatorSudos) > 0 {
+		for iNdEx := len(m.PendingOperatorSudos) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PendingOperatorSudos[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.PendingOperatorChanges) > 0 {
+		for iNdEx := len(m.PendingOperatorChanges) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PendingOperatorChanges[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.PendingMigrations) > 0 {
+		for iNdEx := len(m.PendingMigrations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PendingMigrations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.PendingAdminChanges) > 0 {
+		for iNdEx := len(m.PendingAdminChanges) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PendingAdminChanges[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.DeferredCalls) > 0 {
+		for iNdEx := len(m.DeferredCalls) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DeferredCalls[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.GenMsgs) > 0 {
 		for iNdEx := len(m.GenMsgs) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -646,6 +839,16 @@ func (m *Code) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Pruned {
+		i--
+		if m.Pruned {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.Pinned {
 		i--
 		if m.Pinned {
@@ -701,6 +904,61 @@ func (m *Contract) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Capabilities[iNdEx])
+			copy(dAtA[i:], m.Capabilities[iNdEx])
+			i = encodeVarintGenesis(dAtA, i, uint64(len(m.Capabilities[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.Rent != nil {
+		{
+			size, err := m.Rent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenesis(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Cron {
+		i--
+		if m.Cron {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Inactive {
+		i--
+		if m.Inactive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.ContractCodeHistory) > 0 {
+		for iNdEx := len(m.ContractCodeHistory) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ContractCodeHistory[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if len(m.ContractState) > 0 {
 		for iNdEx := len(m.ContractState) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -813,6 +1071,36 @@ func (m *GenesisState) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if len(m.DeferredCalls) > 0 {
+		for _, e := range m.DeferredCalls {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.PendingAdminChanges) > 0 {
+		for _, e := range m.PendingAdminChanges {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.PendingMigrations) > 0 {
+		for _, e := range m.PendingMigrations {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.PendingOperatorChanges) > 0 {
+		for _, e := range m.PendingOperatorChanges {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.PendingOperatorSudos) > 0 {
+		for _, e := range m.PendingOperatorSudos {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -882,6 +1170,9 @@ func (m *Code) Size() (n int) {
 	if m.Pinned {
 		n += 2
 	}
+	if m.Pruned {
+		n += 2
+	}
 	return n
 }
 
@@ -903,6 +1194,28 @@ func (m *Contract) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if len(m.ContractCodeHistory) > 0 {
+		for _, e := range m.ContractCodeHistory {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if m.Inactive {
+		n += 2
+	}
+	if m.Cron {
+		n += 2
+	}
+	if m.Rent != nil {
+		l = m.Rent.Size()
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, s := range m.Capabilities {
+			l = len(s)
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1126,6 +1439,176 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeferredCalls", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeferredCalls = append(m.DeferredCalls, DeferredCall{})
+			if err := m.DeferredCalls[len(m.DeferredCalls)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingAdminChanges", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PendingAdminChanges = append(m.PendingAdminChanges, PendingAdminChange{})
+			if err := m.PendingAdminChanges[len(m.PendingAdminChanges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingMigrations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PendingMigrations = append(m.PendingMigrations, PendingMigration{})
+			if err := m.PendingMigrations[len(m.PendingMigrations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingOperatorChanges", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PendingOperatorChanges = append(m.PendingOperatorChanges, PendingOperatorChange{})
+			if err := m.PendingOperatorChanges[len(m.PendingOperatorChanges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingOperatorSudos", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PendingOperatorSudos = append(m.PendingOperatorSudos, PendingOperatorSudo{})
+			if err := m.PendingOperatorSudos[len(m.PendingOperatorSudos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1437,6 +1920,26 @@ func (m *Code) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Pinned = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pruned", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Pruned = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1586,6 +2089,148 @@ func (m *Contract) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractCodeHistory", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContractCodeHistory = append(m.ContractCodeHistory, ContractCodeHistoryEntry{})
+			if err := m.ContractCodeHistory[len(m.ContractCodeHistory)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inactive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Inactive = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cron", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Cron = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Rent == nil {
+				m.Rent = &ContractRent{}
+			}
+			if err := m.Rent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Capabilities = append(m.Capabilities, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -121,6 +121,26 @@ func TestCodeValidateBasic(t *testing.T) {
 			},
 			expError: true,
 		},
+		"pruned without codeBytes": {
+			srcMutator: func(c *Code) {
+				c.CodeBytes = nil
+				c.Pruned = true
+			},
+		},
+		"pruned with codeBytes": {
+			srcMutator: func(c *Code) {
+				c.Pruned = true
+			},
+			expError: true,
+		},
+		"pruned and pinned": {
+			srcMutator: func(c *Code) {
+				c.CodeBytes = nil
+				c.Pruned = true
+				c.Pinned = true
+			},
+			expError: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -165,6 +185,31 @@ func TestContractValidateBasic(t *testing.T) {
 			},
 			expError: true,
 		},
+		"contract with history": {
+			srcMutator: func(c *Contract) {
+				c.ContractCodeHistory = []ContractCodeHistoryEntry{
+					{Operation: ContractCodeHistoryOperationTypeInit, CodeID: 1, Updated: &AbsoluteTxPosition{BlockHeight: 1}},
+					{Operation: ContractCodeHistoryOperationTypeMigrate, CodeID: 2, Updated: &AbsoluteTxPosition{BlockHeight: 2}},
+				}
+			},
+		},
+		"contract history invalid": {
+			srcMutator: func(c *Contract) {
+				c.ContractCodeHistory = []ContractCodeHistoryEntry{{}}
+			},
+			expError: true,
+		},
+		"contract with capabilities": {
+			srcMutator: func(c *Contract) {
+				c.Capabilities = []string{"gov_hooks", "mint"}
+			},
+		},
+		"contract capability invalid": {
+			srcMutator: func(c *Contract) {
+				c.Capabilities = []string{"Mint"}
+			},
+			expError: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
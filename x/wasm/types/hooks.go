@@ -0,0 +1,53 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WasmHooks are called by the keeper on contract lifecycle events so that other modules can react on them
+// without changes to the keeper. The hooks run in the context of the contract call, a returned error fails the call.
+type WasmHooks interface {
+	// AfterContractInstantiated is called after the contract was instantiated and the response was handled
+	AfterContractInstantiated(ctx sdk.Context, contractAddr, creator sdk.AccAddress, codeID uint64) error
+	// AfterContractExecuted is called after the contract was executed and the response was handled
+	AfterContractExecuted(ctx sdk.Context, contractAddr, caller sdk.AccAddress, msg []byte, funds sdk.Coins) error
+	// AfterContractMigrated is called after the contract was migrated and the response was handled
+	AfterContractMigrated(ctx sdk.Context, contractAddr sdk.AccAddress, oldCodeID, newCodeID uint64) error
+}
+
+var _ WasmHooks = MultiWasmHooks{}
+
+// MultiWasmHooks combines multiple hooks. They are called in order until the first error.
+type MultiWasmHooks []WasmHooks
+
+// NewMultiWasmHooks constructor
+func NewMultiWasmHooks(hooks ...WasmHooks) MultiWasmHooks {
+	return hooks
+}
+
+func (h MultiWasmHooks) AfterContractInstantiated(ctx sdk.Context, contractAddr, creator sdk.AccAddress, codeID uint64) error {
+	for _, v := range h {
+		if err := v.AfterContractInstantiated(ctx, contractAddr, creator, codeID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h MultiWasmHooks) AfterContractExecuted(ctx sdk.Context, contractAddr, caller sdk.AccAddress, msg []byte, funds sdk.Coins) error {
+	for _, v := range h {
+		if err := v.AfterContractExecuted(ctx, contractAddr, caller, msg, funds); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h MultiWasmHooks) AfterContractMigrated(ctx sdk.Context, contractAddr sdk.AccAddress, oldCodeID, newCodeID uint64) error {
+	for _, v := range h {
+		if err := v.AfterContractMigrated(ctx, contractAddr, oldCodeID, newCodeID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiWasmHooks(t *testing.T) {
+	myErr := errors.New("testing")
+	specs := map[string]struct {
+		hooks    []*recordingHooks
+		expErr   error
+		expCalls []int
+	}{
+		"all called": {
+			hooks:    []*recordingHooks{{}, {}},
+			expCalls: []int{1, 1},
+		},
+		"stop on first error": {
+			hooks:    []*recordingHooks{{err: myErr}, {}},
+			expErr:   myErr,
+			expCalls: []int{1, 0},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			for _, call := range []func(h WasmHooks) error{
+				func(h WasmHooks) error {
+					return h.AfterContractInstantiated(sdk.Context{}, nil, nil, 1)
+				},
+				func(h WasmHooks) error {
+					return h.AfterContractExecuted(sdk.Context{}, nil, nil, nil, nil)
+				},
+				func(h WasmHooks) error {
+					return h.AfterContractMigrated(sdk.Context{}, nil, 1, 2)
+				},
+			} {
+				var hooks []WasmHooks
+				for _, h := range spec.hooks {
+					h.calls = 0
+					hooks = append(hooks, h)
+				}
+				gotErr := call(NewMultiWasmHooks(hooks...))
+				assert.Equal(t, spec.expErr, gotErr)
+				for i, h := range spec.hooks {
+					assert.Equal(t, spec.expCalls[i], h.calls)
+				}
+			}
+		})
+	}
+}
+
+type recordingHooks struct {
+	calls int
+	err   error
+}
+
+func (r *recordingHooks) AfterContractInstantiated(sdk.Context, sdk.AccAddress, sdk.AccAddress, uint64) error {
+	r.calls++
+	return r.err
+}
+
+func (r *recordingHooks) AfterContractExecuted(sdk.Context, sdk.AccAddress, sdk.AccAddress, []byte, sdk.Coins) error {
+	r.calls++
+	return r.err
+}
+
+func (r *recordingHooks) AfterContractMigrated(sdk.Context, sdk.AccAddress, uint64, uint64) error {
+	r.calls++
+	return r.err
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
 )
 
 const (
@@ -31,9 +32,31 @@ var (
 	ContractByCodeIDAndCreatedSecondaryIndexPrefix = []byte{0x06}
 	PinnedCodeIndexPrefix                          = []byte{0x07}
 	TXCounterPrefix                                = []byte{0x08}
+	ContractsByCreatorPrefix                       = []byte{0x09}
+	ContractsByAdminPrefix                         = []byte{0x0a}
+	ContractStateSizePrefix                        = []byte{0x0b}
+	InactiveContractPrefix                         = []byte{0x0c}
+	CronContractPrefix                             = []byte{0x0d}
+	DeferredCallByHeightPrefix                     = []byte{0x0e}
+	DeferredCallByTimePrefix                       = []byte{0x0f}
+	ContractRentPrefix                             = []byte{0x10}
+	PendingAdminChangePrefix                       = []byte{0x11}
+	PendingMigrationPrefix                         = []byte{0x12}
+	PrunedCodeIndexPrefix                          = []byte{0x13}
+	ContractCapabilityPrefix                       = []byte{0x14}
+	DeferredCallCountPrefix                        = []byte{0x15}
+	PendingAdminChangeByHeightPrefix               = []byte{0x16}
+	PendingMigrationByHeightPrefix                 = []byte{0x17}
+	PendingOperatorChangePrefix                    = []byte{0x18}
+	PendingOperatorChangeByHeightPrefix            = []byte{0x19}
+	PendingOperatorSudoPrefix                      = []byte{0x1a}
+	PendingOperatorSudoByHeightPrefix              = []byte{0x1b}
+	// StateRentCursorKey stores the next contract of a state rent charge round that did not complete yet
+	StateRentCursorKey = []byte{0x1c}
 
-	KeyLastCodeID     = append(SequenceKeyPrefix, []byte("lastCodeId")...)
-	KeyLastInstanceID = append(SequenceKeyPrefix, []byte("lastContractId")...)
+	KeyLastCodeID         = append(SequenceKeyPrefix, []byte("lastCodeId")...)
+	KeyLastInstanceID     = append(SequenceKeyPrefix, []byte("lastContractId")...)
+	KeyLastDeferredCallID = append(SequenceKeyPrefix, []byte("lastDeferredCallId")...)
 )
 
 // GetCodeKey constructs the key for retreiving the ID for the WASM code
@@ -52,6 +75,87 @@ func GetContractStorePrefix(addr sdk.AccAddress) []byte {
 	return append(ContractStorePrefix, addr...)
 }
 
+// GetContractStateSizeKey returns the key for the state size counters of the WASM contract instance
+func GetContractStateSizeKey(addr sdk.AccAddress) []byte {
+	return append(ContractStateSizePrefix, addr...)
+}
+
+// GetInactiveContractKey returns the key of the inactive flag for the WASM contract instance
+func GetInactiveContractKey(addr sdk.AccAddress) []byte {
+	return append(InactiveContractPrefix, addr...)
+}
+
+// GetCronContractKey returns the key of the cron flag for the WASM contract instance
+func GetCronContractKey(addr sdk.AccAddress) []byte {
+	return append(CronContractPrefix, addr...)
+}
+
+// GetContractRentKey returns the key of the state rent account of a contract
+func GetContractRentKey(addr sdk.AccAddress) []byte {
+	return append(ContractRentPrefix, addr...)
+}
+
+// GetPendingAdminChangeKey returns the key of the timelocked admin change of a contract
+func GetPendingAdminChangeKey(addr sdk.AccAddress) []byte {
+	return append(PendingAdminChangePrefix, addr...)
+}
+
+// GetPendingMigrationKey returns the key of the timelocked migration of a contract
+func GetPendingMigrationKey(addr sdk.AccAddress) []byte {
+	return append(PendingMigrationPrefix, addr...)
+}
+
+// GetPendingAdminChangeByHeightKey returns the key of the secondary index for timelocked admin changes by height:
+// `<prefix><height><contractAddr>`
+func GetPendingAdminChangeByHeightKey(height uint64, addr sdk.AccAddress) []byte {
+	return append(append(PendingAdminChangeByHeightPrefix, sdk.Uint64ToBigEndian(height)...), addr...)
+}
+
+// GetPendingMigrationByHeightKey returns the key of the secondary index for timelocked migrations by height:
+// `<prefix><height><contractAddr>`
+func GetPendingMigrationByHeightKey(height uint64, addr sdk.AccAddress) []byte {
+	return append(append(PendingMigrationByHeightPrefix, sdk.Uint64ToBigEndian(height)...), addr...)
+}
+
+// GetPendingOperatorChangeKey returns the key of the timelocked operator change of a contract
+func GetPendingOperatorChangeKey(addr sdk.AccAddress) []byte {
+	return append(PendingOperatorChangePrefix, addr...)
+}
+
+// GetPendingOperatorChangeByHeightKey returns the key of the secondary index for timelocked operator changes by
+// height: `<prefix><height><contractAddr>`
+func GetPendingOperatorChangeByHeightKey(height uint64, addr sdk.AccAddress) []byte {
+	return append(append(PendingOperatorChangeByHeightPrefix, sdk.Uint64ToBigEndian(height)...), addr...)
+}
+
+// GetPendingOperatorSudoKey returns the key of the timelocked operator sudo call of a contract
+func GetPendingOperatorSudoKey(addr sdk.AccAddress) []byte {
+	return append(PendingOperatorSudoPrefix, addr...)
+}
+
+// GetPendingOperatorSudoByHeightKey returns the key of the secondary index for timelocked operator sudo calls by
+// height: `<prefix><height><contractAddr>`
+func GetPendingOperatorSudoByHeightKey(height uint64, addr sdk.AccAddress) []byte {
+	return append(append(PendingOperatorSudoByHeightPrefix, sdk.Uint64ToBigEndian(height)...), addr...)
+}
+
+// GetDeferredCallCountKey returns the key of the number of pending deferred calls of a contract
+func GetDeferredCallCountKey(addr sdk.AccAddress) []byte {
+	return append(DeferredCallCountPrefix, addr...)
+}
+
+// GetDeferredCallByHeightKey returns the key of a deferred call in the queue ordered by block height:
+// `<prefix><height><id>`
+func GetDeferredCallByHeightKey(height, id uint64) []byte {
+	return append(append(DeferredCallByHeightPrefix, sdk.Uint64ToBigEndian(height)...), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetDeferredCallByTimeKey returns the key of a deferred call in the queue ordered by block time:
+// `<prefix><time><id>`
+func GetDeferredCallByTimeKey(time, id uint64) []byte {
+	return append(append(DeferredCallByTimePrefix, sdk.Uint64ToBigEndian(time)...), sdk.Uint64ToBigEndian(id)...)
+}
+
 // GetContractByCreatedSecondaryIndexKey returns the key for the secondary index:
 // `<prefix><codeID><created/last-migrated><contractAddr>`
 func GetContractByCreatedSecondaryIndexKey(contractAddr sdk.AccAddress, c ContractCodeHistoryEntry) []byte {
@@ -75,6 +179,52 @@ func GetContractByCodeIDSecondaryIndexPrefix(codeID uint64) []byte {
 	return r
 }
 
+// GetContractByCreatorSecondaryIndexKey returns the key for the secondary index:
+// `<prefix><creatorAddress length><creatorAddress><created><contractAddr>`
+func GetContractByCreatorSecondaryIndexKey(creator sdk.AccAddress, created *AbsoluteTxPosition, contractAddr sdk.AccAddress) []byte {
+	prefix := GetContractsByCreatorPrefix(creator)
+	prefixLen := len(prefix)
+	contractAddrLen := len(contractAddr)
+	r := make([]byte, prefixLen+AbsoluteTxPositionLen+contractAddrLen)
+	copy(r[0:], prefix)
+	copy(r[prefixLen:], created.Bytes())
+	copy(r[prefixLen+AbsoluteTxPositionLen:], contractAddr)
+	return r
+}
+
+// GetContractsByCreatorPrefix returns the prefix for the secondary index: `<prefix><creatorAddress length><creatorAddress>`
+func GetContractsByCreatorPrefix(creator sdk.AccAddress) []byte {
+	bz := address.MustLengthPrefix(creator)
+	prefixLen := len(ContractsByCreatorPrefix)
+	r := make([]byte, prefixLen+len(bz))
+	copy(r[0:], ContractsByCreatorPrefix)
+	copy(r[prefixLen:], bz)
+	return r
+}
+
+// GetContractByAdminSecondaryIndexKey returns the key for the secondary index:
+// `<prefix><adminAddress length><adminAddress><created><contractAddr>`
+func GetContractByAdminSecondaryIndexKey(admin sdk.AccAddress, created *AbsoluteTxPosition, contractAddr sdk.AccAddress) []byte {
+	prefix := GetContractsByAdminPrefix(admin)
+	prefixLen := len(prefix)
+	contractAddrLen := len(contractAddr)
+	r := make([]byte, prefixLen+AbsoluteTxPositionLen+contractAddrLen)
+	copy(r[0:], prefix)
+	copy(r[prefixLen:], created.Bytes())
+	copy(r[prefixLen+AbsoluteTxPositionLen:], contractAddr)
+	return r
+}
+
+// GetContractsByAdminPrefix returns the prefix for the secondary index: `<prefix><adminAddress length><adminAddress>`
+func GetContractsByAdminPrefix(admin sdk.AccAddress) []byte {
+	bz := address.MustLengthPrefix(admin)
+	prefixLen := len(ContractsByAdminPrefix)
+	r := make([]byte, prefixLen+len(bz))
+	copy(r[0:], ContractsByAdminPrefix)
+	copy(r[prefixLen:], bz)
+	return r
+}
+
 // GetContractCodeHistoryElementKey returns the key a contract code history entry: `<prefix><contractAddr><position>`
 func GetContractCodeHistoryElementKey(contractAddr sdk.AccAddress, pos uint64) []byte {
 	prefix := GetContractCodeHistoryElementPrefix(contractAddr)
@@ -108,3 +258,25 @@ func GetPinnedCodeIndexPrefix(codeID uint64) []byte {
 func ParsePinnedCodeIndex(s []byte) uint64 {
 	return sdk.BigEndianToUint64(s)
 }
+
+// GetPrunedCodeIndexKey returns the key of the pruned flag for a code id
+func GetPrunedCodeIndexKey(codeID uint64) []byte {
+	return append(PrunedCodeIndexPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetContractCapabilityKey returns the key of a capability granted to a contract:
+// `<prefix><contractAddress length><contractAddress><capability>`
+func GetContractCapabilityKey(contractAddr sdk.AccAddress, capability string) []byte {
+	return append(GetContractCapabilitiesPrefix(contractAddr), capability...)
+}
+
+// GetContractCapabilitiesPrefix returns the key prefix of the capabilities granted to a contract:
+// `<prefix><contractAddress length><contractAddress>`
+func GetContractCapabilitiesPrefix(contractAddr sdk.AccAddress) []byte {
+	bz := address.MustLengthPrefix(contractAddr)
+	prefixLen := len(ContractCapabilityPrefix)
+	r := make([]byte, prefixLen+len(bz))
+	copy(r[0:], ContractCapabilityPrefix)
+	copy(r[prefixLen:], bz)
+	return r
+}
@@ -80,3 +80,57 @@ func TestGetContractByCreatedSecondaryIndexKey(t *testing.T) {
 	}
 	assert.Equal(t, exp, got)
 }
+
+func TestGetContractByCreatorSecondaryIndexKey(t *testing.T) {
+	creatorAddr := bytes.Repeat([]byte{4}, 20)
+	pos := &AbsoluteTxPosition{2 + 1<<(8*7), 3 + 1<<(8*7)}
+
+	// test that contract addresses of 20 length are still supported
+	contractAddr := bytes.Repeat([]byte{5}, 20)
+	got := GetContractByCreatorSecondaryIndexKey(creatorAddr, pos, contractAddr)
+	exp := []byte{9, // prefix
+		20,                           // creator address length
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4, // creator address 20 bytes
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
+		1, 0, 0, 0, 0, 0, 0, 2, // height
+		1, 0, 0, 0, 0, 0, 0, 3, // index
+		5, 5, 5, 5, 5, 5, 5, 5, 5, 5, // contract address 20 bytes
+		5, 5, 5, 5, 5, 5, 5, 5, 5, 5,
+	}
+	assert.Equal(t, exp, got)
+
+	contractAddr = bytes.Repeat([]byte{5}, ContractAddrLen)
+	got = GetContractByCreatorSecondaryIndexKey(creatorAddr, pos, contractAddr)
+	exp = []byte{9, // prefix
+		20,                           // creator address length
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4, // creator address 20 bytes
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
+		1, 0, 0, 0, 0, 0, 0, 2, // height
+		1, 0, 0, 0, 0, 0, 0, 3, // index
+		5, 5, 5, 5, 5, 5, 5, 5, 5, 5, // contract address 32 bytes
+		5, 5, 5, 5, 5, 5, 5, 5, 5, 5,
+		5, 5, 5, 5, 5, 5, 5, 5, 5, 5,
+		5, 5,
+	}
+	assert.Equal(t, exp, got)
+}
+
+func TestGetContractByAdminSecondaryIndexKey(t *testing.T) {
+	adminAddr := bytes.Repeat([]byte{4}, 20)
+	pos := &AbsoluteTxPosition{2 + 1<<(8*7), 3 + 1<<(8*7)}
+	contractAddr := bytes.Repeat([]byte{5}, ContractAddrLen)
+
+	got := GetContractByAdminSecondaryIndexKey(adminAddr, pos, contractAddr)
+	exp := []byte{10, // prefix
+		20,                           // admin address length
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4, // admin address 20 bytes
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
+		1, 0, 0, 0, 0, 0, 0, 2, // height
+		1, 0, 0, 0, 0, 0, 0, 3, // index
+		5, 5, 5, 5, 5, 5, 5, 5, 5, 5, // contract address 32 bytes
+		5, 5, 5, 5, 5, 5, 5, 5, 5, 5,
+		5, 5, 5, 5, 5, 5, 5, 5, 5, 5,
+		5, 5,
+	}
+	assert.Equal(t, exp, got)
+}
@@ -14,6 +14,12 @@ import (
 
 var ParamStoreKeyUploadAccess = []byte("uploadAccess")
 var ParamStoreKeyInstantiateAccess = []byte("instantiateAccess")
+var ParamStoreKeyMaxContractMsgSize = []byte("maxContractMsgSize")
+var ParamStoreKeyMaxContractResponseDataSize = []byte("maxContractResponseDataSize")
+var ParamStoreKeyDeniedFundsDenoms = []byte("deniedFundsDenoms")
+var ParamStoreKeyInstanceCost = []byte("instanceCost")
+var ParamStoreKeyCompileCost = []byte("compileCost")
+var ParamStoreKeyDefaultCreatorAdmin = []byte("defaultCreatorAdmin")
 
 var AllAccessTypes = []AccessType{
 	AccessTypeNobody,
@@ -90,6 +96,10 @@ func DefaultParams() Params {
 	return Params{
 		CodeUploadAccess:             AllowEverybody,
 		InstantiateDefaultPermission: AccessTypeEverybody,
+		MaxContractMsgSize:           DefaultMaxContractMsgSize,
+		MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
+		InstanceCost:                 DefaultInstanceCost,
+		CompileCost:                  DefaultCompileCost,
 	}
 }
 
@@ -106,6 +116,12 @@ func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 	return paramtypes.ParamSetPairs{
 		paramtypes.NewParamSetPair(ParamStoreKeyUploadAccess, &p.CodeUploadAccess, validateAccessConfig),
 		paramtypes.NewParamSetPair(ParamStoreKeyInstantiateAccess, &p.InstantiateDefaultPermission, validateAccessType),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxContractMsgSize, &p.MaxContractMsgSize, validateMaxContractMsgSize),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxContractResponseDataSize, &p.MaxContractResponseDataSize, validateMaxContractResponseDataSize),
+		paramtypes.NewParamSetPair(ParamStoreKeyDeniedFundsDenoms, &p.DeniedFundsDenoms, validateDeniedFundsDenoms),
+		paramtypes.NewParamSetPair(ParamStoreKeyInstanceCost, &p.InstanceCost, validateGasCost),
+		paramtypes.NewParamSetPair(ParamStoreKeyCompileCost, &p.CompileCost, validateGasCost),
+		paramtypes.NewParamSetPair(ParamStoreKeyDefaultCreatorAdmin, &p.DefaultCreatorAdmin, validateBool),
 	}
 }
 
@@ -117,6 +133,21 @@ func (p Params) ValidateBasic() error {
 	if err := validateAccessConfig(p.CodeUploadAccess); err != nil {
 		return errors.Wrap(err, "upload access")
 	}
+	if err := validateMaxContractMsgSize(p.MaxContractMsgSize); err != nil {
+		return errors.Wrap(err, "max contract msg size")
+	}
+	if err := validateMaxContractResponseDataSize(p.MaxContractResponseDataSize); err != nil {
+		return errors.Wrap(err, "max contract response data size")
+	}
+	if err := validateDeniedFundsDenoms(p.DeniedFundsDenoms); err != nil {
+		return errors.Wrap(err, "denied funds denoms")
+	}
+	if err := validateGasCost(p.InstanceCost); err != nil {
+		return errors.Wrap(err, "instance cost")
+	}
+	if err := validateGasCost(p.CompileCost); err != nil {
+		return errors.Wrap(err, "compile cost")
+	}
 	return nil
 }
 
@@ -128,6 +159,63 @@ func validateAccessConfig(i interface{}) error {
 	return v.ValidateBasic()
 }
 
+func validateMaxContractMsgSize(i interface{}) error {
+	a, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if a == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "size")
+	}
+	if a > uint64(MaxContractMsgSize) {
+		return sdkerrors.Wrapf(ErrLimit, "cannot be greater than %d bytes", MaxContractMsgSize)
+	}
+	return nil
+}
+
+func validateMaxContractResponseDataSize(i interface{}) error {
+	a, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if a == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "size")
+	}
+	return nil
+}
+
+func validateGasCost(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateBool(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateDeniedFundsDenoms(i interface{}) error {
+	a, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	unique := make(map[string]struct{}, len(a))
+	for _, d := range a {
+		if err := sdk.ValidateDenom(d); err != nil {
+			return sdkerrors.Wrapf(ErrInvalid, "denom %q: %s", d, err)
+		}
+		if _, exists := unique[d]; exists {
+			return sdkerrors.Wrapf(ErrDuplicate, "denom %q", d)
+		}
+		unique[d] = struct{}{}
+	}
+	return nil
+}
+
 func validateAccessType(i interface{}) error {
 	a, ok := i.(AccessType)
 	if !ok {
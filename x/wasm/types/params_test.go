@@ -28,20 +28,87 @@ func TestValidateParams(t *testing.T) {
 			src: Params{
 				CodeUploadAccess:             AllowNobody,
 				InstantiateDefaultPermission: AccessTypeNobody,
+				MaxContractMsgSize:           DefaultMaxContractMsgSize,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 		},
 		"all good with everybody": {
 			src: Params{
 				CodeUploadAccess:             AllowEverybody,
 				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxContractMsgSize:           DefaultMaxContractMsgSize,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 		},
 		"all good with only address": {
 			src: Params{
 				CodeUploadAccess:             AccessTypeOnlyAddress.With(anyAddress),
 				InstantiateDefaultPermission: AccessTypeOnlyAddress,
+				MaxContractMsgSize:           DefaultMaxContractMsgSize,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 		},
+		"all good with min max contract msg size": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxContractMsgSize:           1,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
+			},
+		},
+		"all good with denied funds denoms": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxContractMsgSize:           DefaultMaxContractMsgSize,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
+				DeniedFundsDenoms:            []string{"ulocked", "ibc/7F1D3FCF4AE79E1554D670D1AD949A9BA4E4A3C76C63093E17E446A46061A7A2"},
+			},
+		},
+		"reject invalid denied funds denom": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxContractMsgSize:           DefaultMaxContractMsgSize,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
+				DeniedFundsDenoms:            []string{"1"},
+			},
+			expErr: true,
+		},
+		"reject duplicate denied funds denom": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxContractMsgSize:           DefaultMaxContractMsgSize,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
+				DeniedFundsDenoms:            []string{"ulocked", "ulocked"},
+			},
+			expErr: true,
+		},
+		"reject empty max contract msg size": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
+			},
+			expErr: true,
+		},
+		"reject empty max contract response data size": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxContractMsgSize:           DefaultMaxContractMsgSize,
+			},
+			expErr: true,
+		},
+		"reject max contract msg size exceeding limit": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxContractMsgSize:           uint64(MaxContractMsgSize) + 1,
+			},
+			expErr: true,
+		},
 		"reject empty type in instantiate permission": {
 			src: Params{
 				CodeUploadAccess: AllowNobody,
@@ -150,9 +217,27 @@ func TestParamsUnmarshalJson(t *testing.T) {
 
 		"defaults": {
 			src: `{"code_upload_access": {"permission": "Everybody"},
-				"instantiate_default_permission": "Everybody"}`,
+				"instantiate_default_permission": "Everybody",
+				"max_contract_msg_size": "1048576",
+				"max_contract_response_data_size": "65536",
+				"instance_cost": "60000",
+				"compile_cost": "3"}`,
 			exp: DefaultParams(),
 		},
+		"default creator admin": {
+			src: `{"code_upload_access": {"permission": "Everybody"},
+				"instantiate_default_permission": "Everybody",
+				"max_contract_msg_size": "1048576",
+				"max_contract_response_data_size": "65536",
+				"instance_cost": "60000",
+				"compile_cost": "3",
+				"default_creator_admin": true}`,
+			exp: func() Params {
+				p := DefaultParams()
+				p.DefaultCreatorAdmin = true
+				return p
+			}(),
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -22,6 +22,14 @@ const (
 	ProposalTypeClearAdmin          ProposalType = "ClearAdmin"
 	ProposalTypePinCodes            ProposalType = "PinCodes"
 	ProposalTypeUnpinCodes          ProposalType = "UnpinCodes"
+	ProposalTypeDeactivateContracts ProposalType = "DeactivateContracts"
+	ProposalTypeActivateContracts   ProposalType = "ActivateContracts"
+	ProposalTypeUpdateExecuteGas    ProposalType = "UpdateExecuteGasLimit"
+	ProposalTypeRegisterCron        ProposalType = "RegisterCronContracts"
+	ProposalTypeUnregisterCron      ProposalType = "UnregisterCronContracts"
+	ProposalTypePruneCodes          ProposalType = "PruneCodes"
+	ProposalTypeGrantCapabilities   ProposalType = "GrantContractCapabilities"
+	ProposalTypeRevokeCapabilities  ProposalType = "RevokeContractCapabilities"
 )
 
 // DisableAllProposals contains no wasm gov types.
@@ -38,6 +46,14 @@ var EnableAllProposals = []ProposalType{
 	ProposalTypeClearAdmin,
 	ProposalTypePinCodes,
 	ProposalTypeUnpinCodes,
+	ProposalTypeDeactivateContracts,
+	ProposalTypeActivateContracts,
+	ProposalTypeUpdateExecuteGas,
+	ProposalTypeRegisterCron,
+	ProposalTypeUnregisterCron,
+	ProposalTypePruneCodes,
+	ProposalTypeGrantCapabilities,
+	ProposalTypeRevokeCapabilities,
 }
 
 // ConvertToProposals maps each key to a ProposalType and returns a typed list.
@@ -68,6 +84,14 @@ func init() { // register new content types with the sdk
 	govtypes.RegisterProposalType(string(ProposalTypeClearAdmin))
 	govtypes.RegisterProposalType(string(ProposalTypePinCodes))
 	govtypes.RegisterProposalType(string(ProposalTypeUnpinCodes))
+	govtypes.RegisterProposalType(string(ProposalTypeDeactivateContracts))
+	govtypes.RegisterProposalType(string(ProposalTypeActivateContracts))
+	govtypes.RegisterProposalType(string(ProposalTypeUpdateExecuteGas))
+	govtypes.RegisterProposalType(string(ProposalTypeRegisterCron))
+	govtypes.RegisterProposalType(string(ProposalTypeUnregisterCron))
+	govtypes.RegisterProposalType(string(ProposalTypePruneCodes))
+	govtypes.RegisterProposalType(string(ProposalTypeGrantCapabilities))
+	govtypes.RegisterProposalType(string(ProposalTypeRevokeCapabilities))
 	govtypes.RegisterProposalTypeCodec(&StoreCodeProposal{}, "wasm/StoreCodeProposal")
 	govtypes.RegisterProposalTypeCodec(&InstantiateContractProposal{}, "wasm/InstantiateContractProposal")
 	govtypes.RegisterProposalTypeCodec(&MigrateContractProposal{}, "wasm/MigrateContractProposal")
@@ -77,6 +101,14 @@ func init() { // register new content types with the sdk
 	govtypes.RegisterProposalTypeCodec(&ClearAdminProposal{}, "wasm/ClearAdminProposal")
 	govtypes.RegisterProposalTypeCodec(&PinCodesProposal{}, "wasm/PinCodesProposal")
 	govtypes.RegisterProposalTypeCodec(&UnpinCodesProposal{}, "wasm/UnpinCodesProposal")
+	govtypes.RegisterProposalTypeCodec(&DeactivateContractsProposal{}, "wasm/DeactivateContractsProposal")
+	govtypes.RegisterProposalTypeCodec(&ActivateContractsProposal{}, "wasm/ActivateContractsProposal")
+	govtypes.RegisterProposalTypeCodec(&UpdateExecuteGasLimitProposal{}, "wasm/UpdateExecuteGasLimitProposal")
+	govtypes.RegisterProposalTypeCodec(&RegisterCronContractsProposal{}, "wasm/RegisterCronContractsProposal")
+	govtypes.RegisterProposalTypeCodec(&UnregisterCronContractsProposal{}, "wasm/UnregisterCronContractsProposal")
+	govtypes.RegisterProposalTypeCodec(&PruneCodesProposal{}, "wasm/PruneCodesProposal")
+	govtypes.RegisterProposalTypeCodec(&GrantContractCapabilitiesProposal{}, "wasm/GrantContractCapabilitiesProposal")
+	govtypes.RegisterProposalTypeCodec(&RevokeContractCapabilitiesProposal{}, "wasm/RevokeContractCapabilitiesProposal")
 }
 
 // ProposalRoute returns the routing key of a parameter change proposal.
@@ -103,6 +135,12 @@ func (p StoreCodeProposal) ValidateBasic() error {
 	if err := validateWasmCode(p.WASMByteCode); err != nil {
 		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "code bytes %s", err.Error())
 	}
+	if err := ValidateSource(p.Source); err != nil {
+		return sdkerrors.Wrap(err, "source")
+	}
+	if err := ValidateBuilder(p.Builder); err != nil {
+		return sdkerrors.Wrap(err, "builder")
+	}
 
 	if p.InstantiatePermission != nil {
 		if err := p.InstantiatePermission.ValidateBasic(); err != nil {
@@ -119,7 +157,9 @@ func (p StoreCodeProposal) String() string {
   Description: %s
   Run as:      %s
   WasmCode:    %X
-`, p.Title, p.Description, p.RunAs, p.WASMByteCode)
+  Source:      %s
+  Builder:     %s
+`, p.Title, p.Description, p.RunAs, p.WASMByteCode, p.Source, p.Builder)
 }
 
 // MarshalYAML pretty prints the wasm byte code
@@ -129,12 +169,16 @@ func (p StoreCodeProposal) MarshalYAML() (interface{}, error) {
 		Description           string        `yaml:"description"`
 		RunAs                 string        `yaml:"run_as"`
 		WASMByteCode          string        `yaml:"wasm_byte_code"`
+		Source                string        `yaml:"source"`
+		Builder               string        `yaml:"builder"`
 		InstantiatePermission *AccessConfig `yaml:"instantiate_permission"`
 	}{
 		Title:                 p.Title,
 		Description:           p.Description,
 		RunAs:                 p.RunAs,
 		WASMByteCode:          base64.StdEncoding.EncodeToString(p.WASMByteCode),
+		Source:                p.Source,
+		Builder:               p.Builder,
 		InstantiatePermission: p.InstantiatePermission,
 	}, nil
 }
@@ -166,12 +210,12 @@ func (p InstantiateContractProposal) ValidateBasic() error {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "code id is required")
 	}
 
-	if err := validateLabel(p.Label); err != nil {
-		return err
+	if err := ValidateLabel(p.Label); err != nil {
+		return sdkerrors.Wrap(err, "label")
 	}
 
-	if !p.Funds.IsValid() {
-		return sdkerrors.ErrInvalidCoins
+	if err := validateFunds(p.Funds); err != nil {
+		return sdkerrors.Wrap(err, "funds")
 	}
 
 	if len(p.Admin) != 0 {
@@ -353,8 +397,8 @@ func (p ExecuteContractProposal) ValidateBasic() error {
 	if _, err := sdk.AccAddressFromBech32(p.RunAs); err != nil {
 		return sdkerrors.Wrap(err, "run as")
 	}
-	if !p.Funds.IsValid() {
-		return sdkerrors.ErrInvalidCoins
+	if err := validateFunds(p.Funds); err != nil {
+		return sdkerrors.Wrap(err, "funds")
 	}
 	if err := p.Msg.ValidateBasic(); err != nil {
 		return sdkerrors.Wrap(err, "payload msg")
@@ -525,6 +569,290 @@ func (p UnpinCodesProposal) String() string {
 `, p.Title, p.Description, p.CodeIDs)
 }
 
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p DeactivateContractsProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *DeactivateContractsProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p DeactivateContractsProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p DeactivateContractsProposal) ProposalType() string {
+	return string(ProposalTypeDeactivateContracts)
+}
+
+// ValidateBasic validates the proposal
+func (p DeactivateContractsProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	return validateContractAddresses(p.Contracts)
+}
+
+// String implements the Stringer interface.
+func (p DeactivateContractsProposal) String() string {
+	return fmt.Sprintf(`Deactivate Contracts Proposal:
+  Title:       %s
+  Description: %s
+  Contracts:   %v
+`, p.Title, p.Description, p.Contracts)
+}
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p ActivateContractsProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *ActivateContractsProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p ActivateContractsProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p ActivateContractsProposal) ProposalType() string {
+	return string(ProposalTypeActivateContracts)
+}
+
+// ValidateBasic validates the proposal
+func (p ActivateContractsProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	return validateContractAddresses(p.Contracts)
+}
+
+// String implements the Stringer interface.
+func (p ActivateContractsProposal) String() string {
+	return fmt.Sprintf(`Activate Contracts Proposal:
+  Title:       %s
+  Description: %s
+  Contracts:   %v
+`, p.Title, p.Description, p.Contracts)
+}
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p UpdateExecuteGasLimitProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *UpdateExecuteGasLimitProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p UpdateExecuteGasLimitProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p UpdateExecuteGasLimitProposal) ProposalType() string {
+	return string(ProposalTypeUpdateExecuteGas)
+}
+
+// ValidateBasic validates the proposal
+func (p UpdateExecuteGasLimitProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	if _, err := sdk.AccAddressFromBech32(p.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	return nil
+}
+
+// String implements the Stringer interface.
+func (p UpdateExecuteGasLimitProposal) String() string {
+	return fmt.Sprintf(`Update Execute Gas Limit Proposal:
+  Title:       %s
+  Description: %s
+  Contract:    %s
+  Gas Limit:   %d
+`, p.Title, p.Description, p.Contract, p.GasLimit)
+}
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p RegisterCronContractsProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *RegisterCronContractsProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p RegisterCronContractsProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p RegisterCronContractsProposal) ProposalType() string {
+	return string(ProposalTypeRegisterCron)
+}
+
+// ValidateBasic validates the proposal
+func (p RegisterCronContractsProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	return validateContractAddresses(p.Contracts)
+}
+
+// String implements the Stringer interface.
+func (p RegisterCronContractsProposal) String() string {
+	return fmt.Sprintf(`Register Cron Contracts Proposal:
+  Title:       %s
+  Description: %s
+  Contracts:   %v
+`, p.Title, p.Description, p.Contracts)
+}
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p UnregisterCronContractsProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *UnregisterCronContractsProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p UnregisterCronContractsProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p UnregisterCronContractsProposal) ProposalType() string {
+	return string(ProposalTypeUnregisterCron)
+}
+
+// ValidateBasic validates the proposal
+func (p UnregisterCronContractsProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	return validateContractAddresses(p.Contracts)
+}
+
+// String implements the Stringer interface.
+func (p UnregisterCronContractsProposal) String() string {
+	return fmt.Sprintf(`Unregister Cron Contracts Proposal:
+  Title:       %s
+  Description: %s
+  Contracts:   %v
+`, p.Title, p.Description, p.Contracts)
+}
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p PruneCodesProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *PruneCodesProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p PruneCodesProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p PruneCodesProposal) ProposalType() string { return string(ProposalTypePruneCodes) }
+
+// ValidateBasic validates the proposal
+func (p PruneCodesProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	if len(p.CodeIDs) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "code ids")
+	}
+	return nil
+}
+
+// String implements the Stringer interface.
+func (p PruneCodesProposal) String() string {
+	return fmt.Sprintf(`Prune Wasm Codes Proposal:
+  Title:       %s
+  Description: %s
+  Codes:       %v
+`, p.Title, p.Description, p.CodeIDs)
+}
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p GrantContractCapabilitiesProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *GrantContractCapabilitiesProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p GrantContractCapabilitiesProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p GrantContractCapabilitiesProposal) ProposalType() string {
+	return string(ProposalTypeGrantCapabilities)
+}
+
+// ValidateBasic validates the proposal
+func (p GrantContractCapabilitiesProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	if _, err := sdk.AccAddressFromBech32(p.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if len(p.Capabilities) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "capabilities")
+	}
+	return validateCapabilities(p.Capabilities)
+}
+
+// String implements the Stringer interface.
+func (p GrantContractCapabilitiesProposal) String() string {
+	return fmt.Sprintf(`Grant Contract Capabilities Proposal:
+  Title:        %s
+  Description:  %s
+  Contract:     %s
+  Capabilities: %v
+`, p.Title, p.Description, p.Contract, p.Capabilities)
+}
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p RevokeContractCapabilitiesProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *RevokeContractCapabilitiesProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p RevokeContractCapabilitiesProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p RevokeContractCapabilitiesProposal) ProposalType() string {
+	return string(ProposalTypeRevokeCapabilities)
+}
+
+// ValidateBasic validates the proposal
+func (p RevokeContractCapabilitiesProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	if _, err := sdk.AccAddressFromBech32(p.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if len(p.Capabilities) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "capabilities")
+	}
+	return validateCapabilities(p.Capabilities)
+}
+
+// String implements the Stringer interface.
+func (p RevokeContractCapabilitiesProposal) String() string {
+	return fmt.Sprintf(`Revoke Contract Capabilities Proposal:
+  Title:        %s
+  Description:  %s
+  Contract:     %s
+  Capabilities: %v
+`, p.Title, p.Description, p.Contract, p.Capabilities)
+}
+
+func validateContractAddresses(contracts []string) error {
+	if len(contracts) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "contracts")
+	}
+	seen := make(map[string]struct{}, len(contracts))
+	for _, c := range contracts {
+		if _, err := sdk.AccAddressFromBech32(c); err != nil {
+			return sdkerrors.Wrapf(err, "contract %s", c)
+		}
+		if _, ok := seen[c]; ok {
+			return sdkerrors.Wrapf(ErrDuplicate, "contract %s", c)
+		}
+		seen[c] = struct{}{}
+	}
+	return nil
+}
+
 func validateProposalCommons(title, description string) error {
 	if strings.TrimSpace(title) != title {
 		return sdkerrors.Wrap(govtypes.ErrInvalidProposalContent, "proposal title must not start/end with white spaces")
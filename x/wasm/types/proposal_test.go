@@ -531,6 +531,147 @@ func TestValidateClearAdminProposal(t *testing.T) {
 	}
 }
 
+func TestValidateDeactivateContractsProposal(t *testing.T) {
+	specs := map[string]struct {
+		src    *DeactivateContractsProposal
+		expErr bool
+	}{
+		"all good": {
+			src: DeactivateContractsProposalFixture(),
+		},
+		"base data missing": {
+			src: DeactivateContractsProposalFixture(func(p *DeactivateContractsProposal) {
+				p.Title = ""
+			}),
+			expErr: true,
+		},
+		"contracts missing": {
+			src: DeactivateContractsProposalFixture(func(p *DeactivateContractsProposal) {
+				p.Contracts = nil
+			}),
+			expErr: true,
+		},
+		"contract invalid": {
+			src: DeactivateContractsProposalFixture(func(p *DeactivateContractsProposal) {
+				p.Contracts = []string{"invalid address"}
+			}),
+			expErr: true,
+		},
+		"duplicate contracts": {
+			src: DeactivateContractsProposalFixture(func(p *DeactivateContractsProposal) {
+				p.Contracts = append(p.Contracts, p.Contracts[0])
+			}),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateRegisterCronContractsProposal(t *testing.T) {
+	specs := map[string]struct {
+		src    *RegisterCronContractsProposal
+		expErr bool
+	}{
+		"all good": {
+			src: RegisterCronContractsProposalFixture(),
+		},
+		"base data missing": {
+			src: RegisterCronContractsProposalFixture(func(p *RegisterCronContractsProposal) {
+				p.Title = ""
+			}),
+			expErr: true,
+		},
+		"contracts missing": {
+			src: RegisterCronContractsProposalFixture(func(p *RegisterCronContractsProposal) {
+				p.Contracts = nil
+			}),
+			expErr: true,
+		},
+		"contract invalid": {
+			src: RegisterCronContractsProposalFixture(func(p *RegisterCronContractsProposal) {
+				p.Contracts = []string{"invalid address"}
+			}),
+			expErr: true,
+		},
+		"duplicate contracts": {
+			src: RegisterCronContractsProposalFixture(func(p *RegisterCronContractsProposal) {
+				p.Contracts = append(p.Contracts, p.Contracts[0])
+			}),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateGrantContractCapabilitiesProposal(t *testing.T) {
+	specs := map[string]struct {
+		src    *GrantContractCapabilitiesProposal
+		expErr bool
+	}{
+		"all good": {
+			src: GrantContractCapabilitiesProposalFixture(),
+		},
+		"base data missing": {
+			src: GrantContractCapabilitiesProposalFixture(func(p *GrantContractCapabilitiesProposal) {
+				p.Title = ""
+			}),
+			expErr: true,
+		},
+		"contract invalid": {
+			src: GrantContractCapabilitiesProposalFixture(func(p *GrantContractCapabilitiesProposal) {
+				p.Contract = "invalid"
+			}),
+			expErr: true,
+		},
+		"capabilities missing": {
+			src: GrantContractCapabilitiesProposalFixture(func(p *GrantContractCapabilitiesProposal) {
+				p.Capabilities = nil
+			}),
+			expErr: true,
+		},
+		"capability invalid": {
+			src: GrantContractCapabilitiesProposalFixture(func(p *GrantContractCapabilitiesProposal) {
+				p.Capabilities = []string{"Mint"}
+			}),
+			expErr: true,
+		},
+		"duplicate capabilities": {
+			src: GrantContractCapabilitiesProposalFixture(func(p *GrantContractCapabilitiesProposal) {
+				p.Capabilities = []string{"mint", "mint"}
+			}),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestProposalStrings(t *testing.T) {
 	specs := map[string]struct {
 		src govtypes.Content
@@ -539,12 +680,16 @@ func TestProposalStrings(t *testing.T) {
 		"store code": {
 			src: StoreCodeProposalFixture(func(p *StoreCodeProposal) {
 				p.WASMByteCode = []byte{01, 02, 03, 04, 05, 06, 07, 0x08, 0x09, 0x0a}
+				p.Source = "https://example.com/code"
+				p.Builder = "cosmwasm/rust-optimizer:0.12.6"
 			}),
 			exp: `Store Code Proposal:
   Title:       Foo
   Description: Bar
   Run as:      cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs2m6sx4
   WasmCode:    0102030405060708090A
+  Source:      https://example.com/code
+  Builder:     cosmwasm/rust-optimizer:0.12.6
 `,
 		},
 		"instantiate contract": {
@@ -637,6 +782,27 @@ func TestProposalStrings(t *testing.T) {
   Title:       Foo
   Description: Bar
   Codes:       [3 2 1]
+`,
+		},
+		"prune codes": {
+			src: &PruneCodesProposal{
+				Title:       "Foo",
+				Description: "Bar",
+				CodeIDs:     []uint64{1, 2},
+			},
+			exp: `Prune Wasm Codes Proposal:
+  Title:       Foo
+  Description: Bar
+  Codes:       [1 2]
+`,
+		},
+		"grant contract capabilities": {
+			src: GrantContractCapabilitiesProposalFixture(),
+			exp: `Grant Contract Capabilities Proposal:
+  Title:        Foo
+  Description:  Bar
+  Contract:     cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr
+  Capabilities: [mint]
 `,
 		},
 	}
@@ -655,11 +821,15 @@ func TestProposalYaml(t *testing.T) {
 		"store code": {
 			src: StoreCodeProposalFixture(func(p *StoreCodeProposal) {
 				p.WASMByteCode = []byte{01, 02, 03, 04, 05, 06, 07, 0x08, 0x09, 0x0a}
+				p.Source = "https://example.com/code"
+				p.Builder = "cosmwasm/rust-optimizer:0.12.6"
 			}),
 			exp: `title: Foo
 description: Bar
 run_as: cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs2m6sx4
 wasm_byte_code: AQIDBAUGBwgJCg==
+source: https://example.com/code
+builder: cosmwasm/rust-optimizer:0.12.6
 instantiate_permission: null
 `,
 		},
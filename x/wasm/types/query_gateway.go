@@ -0,0 +1,63 @@
+package types
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/proto" //nolint
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+)
+
+// queryDataParam is the path parameter name of the binary payload in the contract state routes
+const queryDataParam = "query_data"
+
+type gatewayRequestFn func(ctx context.Context, marshaler runtime.Marshaler, client QueryClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error)
+
+// RegisterContractStateHandlerClient registers the http handlers for the raw and smart contract state routes
+// with a lenient decoding of the `query_data` path parameter. Next to standard base64 the URL-safe alphabet is
+// accepted, with or without padding, so that clients can put payloads into a path segment without escaping.
+//
+// The handlers must be registered before the generated ones from `RegisterQueryHandlerClient` as the first
+// matching route wins.
+func RegisterContractStateHandlerClient(mux *runtime.ServeMux, client QueryClient) {
+	mux.Handle("GET", pattern_Query_RawContractState_0, contractStateHandler(mux, client, request_Query_RawContractState_0))
+	mux.Handle("GET", pattern_Query_SmartContractState_0, contractStateHandler(mux, client, request_Query_SmartContractState_0))
+}
+
+func contractStateHandler(mux *runtime.ServeMux, client QueryClient, requestFn gatewayRequestFn) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		rctx, err := runtime.AnnotateContext(ctx, mux, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		if v, ok := pathParams[queryDataParam]; ok {
+			pathParams[queryDataParam] = NormalizeBase64(v)
+		}
+		resp, md, err := requestFn(rctx, inboundMarshaler, client, req, pathParams)
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	}
+}
+
+// NormalizeBase64 converts a standard or URL-safe base64 string, with or without padding, into the standard
+// padded encoding. Input that can not be decoded is returned unmodified so that the caller fails with the
+// original value.
+func NormalizeBase64(s string) string {
+	raw := strings.TrimRight(s, "=")
+	raw = strings.NewReplacer("+", "-", "/", "_").Replace(raw)
+	bz, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return s
+	}
+	return base64.StdEncoding.EncodeToString(bz)
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestNormalizeBase64(t *testing.T) {
+	// payload chosen to contain the `+` and `/` characters in standard encoding
+	payload := []byte{0xfb, 0xff, 0xbf, 0x7b, 0x7d}
+	std := base64.StdEncoding.EncodeToString(payload)
+	require.Equal(t, "+/+/e30=", std)
+
+	specs := map[string]struct {
+		src string
+		exp string
+	}{
+		"standard":            {src: std, exp: std},
+		"url safe":            {src: base64.URLEncoding.EncodeToString(payload), exp: std},
+		"url safe no padding": {src: base64.RawURLEncoding.EncodeToString(payload), exp: std},
+		"standard no padding": {src: base64.RawStdEncoding.EncodeToString(payload), exp: std},
+		"empty":               {src: "", exp: ""},
+		"invalid":             {src: "not base64!", exp: "not base64!"},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, NormalizeBase64(spec.src))
+		})
+	}
+}
+
+func TestRegisterContractStateHandlerClient(t *testing.T) {
+	payload := []byte(`{"foo":">>>"}`)
+	specs := map[string]struct {
+		path      string
+		expStatus int
+		expSmart  []byte
+		expRaw    []byte
+	}{
+		"smart with standard base64": {
+			path:      "/cosmwasm/wasm/v1/contract/myAddr/smart/" + base64.StdEncoding.EncodeToString(payload),
+			expStatus: http.StatusOK,
+			expSmart:  payload,
+		},
+		"smart with url safe base64 without padding": {
+			path:      "/cosmwasm/wasm/v1/contract/myAddr/smart/" + base64.RawURLEncoding.EncodeToString(payload),
+			expStatus: http.StatusOK,
+			expSmart:  payload,
+		},
+		"raw with url safe base64 without padding": {
+			path:      "/cosmwasm/wasm/v1/contract/myAddr/raw/" + base64.RawURLEncoding.EncodeToString(payload),
+			expStatus: http.StatusOK,
+			expRaw:    payload,
+		},
+		"invalid encoding": {
+			path:      "/cosmwasm/wasm/v1/contract/myAddr/smart/not-base64!",
+			expStatus: http.StatusBadRequest,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := &mockQueryClient{}
+			mux := runtime.NewServeMux()
+			RegisterContractStateHandlerClient(mux, mock)
+			require.NoError(t, RegisterQueryHandlerClient(context.Background(), mux, mock))
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest("GET", spec.path, nil))
+
+			require.Equal(t, spec.expStatus, rec.Code, rec.Body.String())
+			assert.Equal(t, spec.expSmart, mock.smartQueryData)
+			assert.Equal(t, spec.expRaw, mock.rawQueryData)
+		})
+	}
+}
+
+type mockQueryClient struct {
+	QueryClient
+	smartQueryData []byte
+	rawQueryData   []byte
+}
+
+func (m *mockQueryClient) SmartContractState(ctx context.Context, in *QuerySmartContractStateRequest, opts ...grpc.CallOption) (*QuerySmartContractStateResponse, error) {
+	m.smartQueryData = in.QueryData
+	return &QuerySmartContractStateResponse{Data: []byte(`{}`)}, nil
+}
+
+func (m *mockQueryClient) RawContractState(ctx context.Context, in *QueryRawContractStateRequest, opts ...grpc.CallOption) (*QueryRawContractStateResponse, error) {
+	m.rawQueryData = in.QueryData
+	return &QueryRawContractStateResponse{}, nil
+}
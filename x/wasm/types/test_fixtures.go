@@ -329,3 +329,43 @@ func ClearAdminProposalFixture(mutators ...func(p *ClearAdminProposal)) *ClearAd
 	}
 	return p
 }
+
+func DeactivateContractsProposalFixture(mutators ...func(p *DeactivateContractsProposal)) *DeactivateContractsProposal {
+	const contractAddr = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	p := &DeactivateContractsProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contracts:   []string{contractAddr},
+	}
+	for _, m := range mutators {
+		m(p)
+	}
+	return p
+}
+
+func GrantContractCapabilitiesProposalFixture(mutators ...func(p *GrantContractCapabilitiesProposal)) *GrantContractCapabilitiesProposal {
+	const contractAddr = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	p := &GrantContractCapabilitiesProposal{
+		Title:        "Foo",
+		Description:  "Bar",
+		Contract:     contractAddr,
+		Capabilities: []string{"mint"},
+	}
+	for _, m := range mutators {
+		m(p)
+	}
+	return p
+}
+
+func RegisterCronContractsProposalFixture(mutators ...func(p *RegisterCronContractsProposal)) *RegisterCronContractsProposal {
+	const contractAddr = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	p := &RegisterCronContractsProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contracts:   []string{contractAddr},
+	}
+	for _, m := range mutators {
+		m(p)
+	}
+	return p
+}
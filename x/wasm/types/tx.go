@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"strings"
@@ -26,10 +27,27 @@ func (r *RawContractMessage) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// ValidateBasic ensures that the message is a json object within the max size. This is used for the messages
+// in txs and proposals so that malformed payloads are rejected before any gas is spent in the VM.
 func (r *RawContractMessage) ValidateBasic() error {
+	if err := r.ValidateQuery(); err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(bytes.TrimLeft(*r, " \t\r\n"), []byte("{")) {
+		return sdkerrors.Wrap(ErrInvalid, "must be a json object")
+	}
+	return nil
+}
+
+// ValidateQuery ensures that the message is valid json within the max size. Unlike ValidateBasic it accepts
+// any json value, as smart queries can be unit enum variants that are serialized as a plain string like `"config"`.
+func (r *RawContractMessage) ValidateQuery() error {
 	if r == nil {
 		return ErrEmpty
 	}
+	if len(*r) > MaxContractMsgSize {
+		return sdkerrors.Wrapf(ErrLimit, "cannot be longer than %d bytes", MaxContractMsgSize)
+	}
 	if !json.Valid(*r) {
 		return ErrInvalid
 	}
@@ -57,6 +75,12 @@ func (msg MsgStoreCode) ValidateBasic() error {
 	if err := validateWasmCode(msg.WASMByteCode); err != nil {
 		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "code bytes %s", err.Error())
 	}
+	if err := ValidateSource(msg.Source); err != nil {
+		return sdkerrors.Wrap(err, "source")
+	}
+	if err := ValidateBuilder(msg.Builder); err != nil {
+		return sdkerrors.Wrap(err, "builder")
+	}
 
 	if msg.InstantiatePermission != nil {
 		if err := msg.InstantiatePermission.ValidateBasic(); err != nil {
@@ -96,13 +120,12 @@ func (msg MsgInstantiateContract) ValidateBasic() error {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "code id is required")
 	}
 
-	if err := validateLabel(msg.Label); err != nil {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "label is required")
-
+	if err := ValidateLabel(msg.Label); err != nil {
+		return sdkerrors.Wrap(err, "label")
 	}
 
-	if !msg.Funds.IsValid() {
-		return sdkerrors.ErrInvalidCoins
+	if err := validateFunds(msg.Funds); err != nil {
+		return sdkerrors.Wrap(err, "funds")
 	}
 
 	if len(msg.Admin) != 0 {
@@ -110,6 +133,9 @@ func (msg MsgInstantiateContract) ValidateBasic() error {
 			return sdkerrors.Wrap(err, "admin")
 		}
 	}
+	if err := validateAdminMode(msg.Admin, msg.AdminMode); err != nil {
+		return sdkerrors.Wrap(err, "admin mode")
+	}
 	if err := msg.Msg.ValidateBasic(); err != nil {
 		return sdkerrors.Wrap(err, "payload msg")
 	}
@@ -145,8 +171,8 @@ func (msg MsgExecuteContract) ValidateBasic() error {
 		return sdkerrors.Wrap(err, "contract")
 	}
 
-	if !msg.Funds.IsValid() {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "sentFunds")
+	if err := validateFunds(msg.Funds); err != nil {
+		return sdkerrors.Wrap(err, "funds")
 	}
 	if err := msg.Msg.ValidateBasic(); err != nil {
 		return sdkerrors.Wrap(err, "payload msg")
@@ -278,6 +304,300 @@ func (msg MsgClearAdmin) GetSigners() []sdk.AccAddress {
 
 }
 
+func (msg MsgUpdateExecuteGasLimit) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateExecuteGasLimit) Type() string {
+	return "update-execute-gas-limit"
+}
+
+func (msg MsgUpdateExecuteGasLimit) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgUpdateExecuteGasLimit) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateExecuteGasLimit) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgStoreAndInstantiateContract) Route() string {
+	return RouterKey
+}
+
+func (msg MsgStoreAndInstantiateContract) Type() string {
+	return "store-and-instantiate"
+}
+
+func (msg MsgStoreAndInstantiateContract) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+
+	if err := validateWasmCode(msg.WASMByteCode); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "code bytes %s", err.Error())
+	}
+
+	if msg.InstantiatePermission != nil {
+		if err := msg.InstantiatePermission.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, "instantiate permission")
+		}
+	}
+
+	if err := ValidateLabel(msg.Label); err != nil {
+		return sdkerrors.Wrap(err, "label")
+	}
+
+	if err := validateFunds(msg.Funds); err != nil {
+		return sdkerrors.Wrap(err, "funds")
+	}
+
+	if len(msg.Admin) != 0 {
+		if _, err := sdk.AccAddressFromBech32(msg.Admin); err != nil {
+			return sdkerrors.Wrap(err, "admin")
+		}
+	}
+	if err := validateAdminMode(msg.Admin, msg.AdminMode); err != nil {
+		return sdkerrors.Wrap(err, "admin mode")
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
+func (msg MsgStoreAndInstantiateContract) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgStoreAndInstantiateContract) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgStoreAndMigrateContract) Route() string {
+	return RouterKey
+}
+
+func (msg MsgStoreAndMigrateContract) Type() string {
+	return "store-and-migrate"
+}
+
+func (msg MsgStoreAndMigrateContract) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+
+	if err := validateWasmCode(msg.WASMByteCode); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "code bytes %s", err.Error())
+	}
+
+	if msg.InstantiatePermission != nil {
+		if err := msg.InstantiatePermission.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, "instantiate permission")
+		}
+	}
+
+	if err := validateContractAddresses(msg.Contracts); err != nil {
+		return err
+	}
+
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
+func (msg MsgStoreAndMigrateContract) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgStoreAndMigrateContract) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgScheduleContractCall) Route() string {
+	return RouterKey
+}
+
+func (msg MsgScheduleContractCall) Type() string {
+	return "schedule-contract-call"
+}
+
+func (msg MsgScheduleContractCall) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "payload msg")
+	}
+	return validateDeferredCallDue(msg.Height, msg.Time)
+}
+
+func (msg MsgScheduleContractCall) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgScheduleContractCall) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgDepositRent) Route() string {
+	return RouterKey
+}
+
+func (msg MsgDepositRent) Type() string {
+	return "deposit-rent"
+}
+
+func (msg MsgDepositRent) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if !msg.Amount.IsValid() || msg.Amount.IsZero() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount")
+	}
+	return nil
+}
+
+func (msg MsgDepositRent) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgDepositRent) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgUpdateOperator) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateOperator) Type() string {
+	return "update-contract-operator"
+}
+
+func (msg MsgUpdateOperator) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if err := validateOperator(msg.Operator, msg.SudoMsgs); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (msg MsgUpdateOperator) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateOperator) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgUpdateLabel) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateLabel) Type() string {
+	return "update-contract-label"
+}
+
+func (msg MsgUpdateLabel) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if err := ValidateLabel(msg.Label); err != nil {
+		return sdkerrors.Wrap(err, "label")
+	}
+	return nil
+}
+
+func (msg MsgUpdateLabel) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateLabel) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgOperatorSudo) Route() string {
+	return RouterKey
+}
+
+func (msg MsgOperatorSudo) Type() string {
+	return "operator-sudo"
+}
+
+func (msg MsgOperatorSudo) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
+func (msg MsgOperatorSudo) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgOperatorSudo) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
 func (msg MsgIBCSend) Route() string {
 	return RouterKey
 }
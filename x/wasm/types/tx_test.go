@@ -14,6 +14,100 @@ import (
 
 const firstCodeID = 1
 
+func TestRawContractMessageValidation(t *testing.T) {
+	specs := map[string]struct {
+		src    RawContractMessage
+		expErr error
+	}{
+		"empty object": {
+			src: RawContractMessage(`{}`),
+		},
+		"object": {
+			src: RawContractMessage(`{"foo":{"bar":1}}`),
+		},
+		"object with leading whitespace": {
+			src: RawContractMessage(" \n{}"),
+		},
+		"empty": {
+			src:    RawContractMessage{},
+			expErr: ErrInvalid,
+		},
+		"non json": {
+			src:    RawContractMessage(`foo`),
+			expErr: ErrInvalid,
+		},
+		"string": {
+			src:    RawContractMessage(`"foo"`),
+			expErr: ErrInvalid,
+		},
+		"array": {
+			src:    RawContractMessage(`[{}]`),
+			expErr: ErrInvalid,
+		},
+		"number": {
+			src:    RawContractMessage(`1`),
+			expErr: ErrInvalid,
+		},
+		"null": {
+			src:    RawContractMessage(`null`),
+			expErr: ErrInvalid,
+		},
+		"exceeds max size": {
+			src:    RawContractMessage(`{"foo":"` + strings.Repeat("a", MaxContractMsgSize) + `"}`),
+			expErr: ErrLimit,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, spec.expErr)
+		})
+	}
+}
+
+func TestRawContractMessageQueryValidation(t *testing.T) {
+	specs := map[string]struct {
+		src    RawContractMessage
+		expErr error
+	}{
+		"object": {
+			src: RawContractMessage(`{"foo":{"bar":1}}`),
+		},
+		"unit variant": {
+			src: RawContractMessage(`"config"`),
+		},
+		"array": {
+			src: RawContractMessage(`[{}]`),
+		},
+		"empty": {
+			src:    RawContractMessage{},
+			expErr: ErrInvalid,
+		},
+		"non json": {
+			src:    RawContractMessage(`foo`),
+			expErr: ErrInvalid,
+		},
+		"exceeds max size": {
+			src:    RawContractMessage(`"` + strings.Repeat("a", MaxContractMsgSize) + `"`),
+			expErr: ErrLimit,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			err := spec.src.ValidateQuery()
+			if spec.expErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, spec.expErr)
+		})
+	}
+}
+
 func TestStoreCodeValidation(t *testing.T) {
 	bad, err := sdk.AccAddressFromHex("012345")
 	require.NoError(t, err)
@@ -50,11 +144,30 @@ func TestStoreCodeValidation(t *testing.T) {
 			valid: false,
 		},
 		"correct maximal": {
+			msg: MsgStoreCode{
+				Sender:                goodAddress,
+				WASMByteCode:          []byte("foo"),
+				Source:                "https://github.com/CosmWasm/cosmwasm/tree/v1.0.0/contracts/hackatom",
+				Builder:               "cosmwasm/rust-optimizer:0.12.6",
+				InstantiatePermission: &AllowEverybody,
+			},
+			valid: true,
+		},
+		"invalid source": {
 			msg: MsgStoreCode{
 				Sender:       goodAddress,
 				WASMByteCode: []byte("foo"),
+				Source:       "http://github.com/CosmWasm/cosmwasm",
 			},
-			valid: true,
+			valid: false,
+		},
+		"invalid builder": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Builder:      "cosmwasm/rust-optimizer",
+			},
+			valid: false,
 		},
 		"invalid InstantiatePermission": {
 			msg: MsgStoreCode{
@@ -171,6 +284,281 @@ func TestInstantiateContractValidation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"init msg too long": {
+			msg: MsgInstantiateContract{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte(`"` + strings.Repeat("a", MaxContractMsgSize) + `"`),
+			},
+			valid: false,
+		},
+		"creator admin mode": {
+			msg: MsgInstantiateContract{
+				Sender:    goodAddress,
+				CodeID:    firstCodeID,
+				Label:     "foo",
+				Msg:       []byte("{}"),
+				AdminMode: InstantiateAdminModeCreator,
+			},
+			valid: true,
+		},
+		"none admin mode": {
+			msg: MsgInstantiateContract{
+				Sender:    goodAddress,
+				CodeID:    firstCodeID,
+				Label:     "foo",
+				Msg:       []byte("{}"),
+				AdminMode: InstantiateAdminModeNone,
+			},
+			valid: true,
+		},
+		"admin mode with admin address": {
+			msg: MsgInstantiateContract{
+				Sender:    goodAddress,
+				CodeID:    firstCodeID,
+				Label:     "foo",
+				Msg:       []byte("{}"),
+				Admin:     goodAddress,
+				AdminMode: InstantiateAdminModeCreator,
+			},
+			valid: false,
+		},
+		"unknown admin mode": {
+			msg: MsgInstantiateContract{
+				Sender:    goodAddress,
+				CodeID:    firstCodeID,
+				Label:     "foo",
+				Msg:       []byte("{}"),
+				AdminMode: 3,
+			},
+			valid: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestStoreAndInstantiateContractValidation(t *testing.T) {
+	bad, err := sdk.AccAddressFromHex("012345")
+	require.NoError(t, err)
+	badAddress := bad.String()
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	cases := map[string]struct {
+		msg   MsgStoreAndInstantiateContract
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgStoreAndInstantiateContract{},
+			valid: false,
+		},
+		"correct minimal": {
+			msg: MsgStoreAndInstantiateContract{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Label:        "foo",
+				Msg:          []byte("{}"),
+			},
+			valid: true,
+		},
+		"correct maximal": {
+			msg: MsgStoreAndInstantiateContract{
+				Sender:                goodAddress,
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AllowEverybody,
+				Admin:                 goodAddress,
+				Label:                 "foo",
+				Msg:                   []byte(`{"some": "data"}`),
+				Funds:                 sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdk.NewInt(200)}},
+			},
+			valid: true,
+		},
+		"missing code": {
+			msg: MsgStoreAndInstantiateContract{
+				Sender: goodAddress,
+				Label:  "foo",
+				Msg:    []byte("{}"),
+			},
+			valid: false,
+		},
+		"invalid InstantiatePermission": {
+			msg: MsgStoreAndInstantiateContract{
+				Sender:                goodAddress,
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeOnlyAddress, Address: badAddress},
+				Label:                 "foo",
+				Msg:                   []byte("{}"),
+			},
+			valid: false,
+		},
+		"missing label": {
+			msg: MsgStoreAndInstantiateContract{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Msg:          []byte("{}"),
+			},
+			valid: false,
+		},
+		"bad sender": {
+			msg: MsgStoreAndInstantiateContract{
+				Sender:       badAddress,
+				WASMByteCode: []byte("foo"),
+				Label:        "foo",
+				Msg:          []byte("{}"),
+			},
+			valid: false,
+		},
+		"bad admin": {
+			msg: MsgStoreAndInstantiateContract{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Admin:        badAddress,
+				Label:        "foo",
+				Msg:          []byte("{}"),
+			},
+			valid: false,
+		},
+		"negative funds": {
+			msg: MsgStoreAndInstantiateContract{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Label:        "foo",
+				Msg:          []byte("{}"),
+				Funds:        sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdk.NewInt(-200)}},
+			},
+			valid: false,
+		},
+		"non json init msg": {
+			msg: MsgStoreAndInstantiateContract{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Label:        "foo",
+				Msg:          []byte("invalid-json"),
+			},
+			valid: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestStoreAndMigrateContractValidation(t *testing.T) {
+	bad, err := sdk.AccAddressFromHex("012345")
+	require.NoError(t, err)
+	badAddress := bad.String()
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+
+	cases := map[string]struct {
+		msg   MsgStoreAndMigrateContract
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgStoreAndMigrateContract{},
+			valid: false,
+		},
+		"correct minimal": {
+			msg: MsgStoreAndMigrateContract{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Contracts:    []string{anotherGoodAddress},
+				Msg:          []byte("{}"),
+			},
+			valid: true,
+		},
+		"correct maximal": {
+			msg: MsgStoreAndMigrateContract{
+				Sender:                goodAddress,
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AllowNobody,
+				Contracts:             []string{goodAddress, anotherGoodAddress},
+				Msg:                   []byte(`{"some": "data"}`),
+			},
+			valid: true,
+		},
+		"missing code": {
+			msg: MsgStoreAndMigrateContract{
+				Sender:    goodAddress,
+				Contracts: []string{anotherGoodAddress},
+				Msg:       []byte("{}"),
+			},
+			valid: false,
+		},
+		"invalid InstantiatePermission": {
+			msg: MsgStoreAndMigrateContract{
+				Sender:                goodAddress,
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeOnlyAddress, Address: badAddress},
+				Contracts:             []string{anotherGoodAddress},
+				Msg:                   []byte("{}"),
+			},
+			valid: false,
+		},
+		"bad sender": {
+			msg: MsgStoreAndMigrateContract{
+				Sender:       badAddress,
+				WASMByteCode: []byte("foo"),
+				Contracts:    []string{anotherGoodAddress},
+				Msg:          []byte("{}"),
+			},
+			valid: false,
+		},
+		"no contracts": {
+			msg: MsgStoreAndMigrateContract{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Msg:          []byte("{}"),
+			},
+			valid: false,
+		},
+		"bad contract": {
+			msg: MsgStoreAndMigrateContract{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Contracts:    []string{anotherGoodAddress, badAddress},
+				Msg:          []byte("{}"),
+			},
+			valid: false,
+		},
+		"duplicate contracts": {
+			msg: MsgStoreAndMigrateContract{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Contracts:    []string{anotherGoodAddress, anotherGoodAddress},
+				Msg:          []byte("{}"),
+			},
+			valid: false,
+		},
+		"non json migrate msg": {
+			msg: MsgStoreAndMigrateContract{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Contracts:    []string{anotherGoodAddress},
+				Msg:          []byte("invalid-json"),
+			},
+			valid: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -280,6 +668,14 @@ func TestExecuteContractValidation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"msg too long": {
+			msg: MsgExecuteContract{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte(`"` + strings.Repeat("a", MaxContractMsgSize) + `"`),
+			},
+			valid: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -504,6 +900,293 @@ func TestMsgMigrateContract(t *testing.T) {
 	}
 }
 
+func TestMsgScheduleContractCall(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgScheduleContractCall
+		expErr bool
+	}{
+		"all good with height": {
+			src: MsgScheduleContractCall{
+				Sender: goodAddress,
+				Msg:    []byte("{}"),
+				Height: 1,
+			},
+		},
+		"all good with time": {
+			src: MsgScheduleContractCall{
+				Sender: goodAddress,
+				Msg:    []byte("{}"),
+				Time:   1,
+			},
+		},
+		"bad sender": {
+			src: MsgScheduleContractCall{
+				Sender: "invalid",
+				Msg:    []byte("{}"),
+				Height: 1,
+			},
+			expErr: true,
+		},
+		"empty sender": {
+			src: MsgScheduleContractCall{
+				Msg:    []byte("{}"),
+				Height: 1,
+			},
+			expErr: true,
+		},
+		"non json msg": {
+			src: MsgScheduleContractCall{
+				Sender: goodAddress,
+				Msg:    []byte("invalid json"),
+				Height: 1,
+			},
+			expErr: true,
+		},
+		"empty msg": {
+			src: MsgScheduleContractCall{
+				Sender: goodAddress,
+				Height: 1,
+			},
+			expErr: true,
+		},
+		"height and time not set": {
+			src: MsgScheduleContractCall{
+				Sender: goodAddress,
+				Msg:    []byte("{}"),
+			},
+			expErr: true,
+		},
+		"height and time set": {
+			src: MsgScheduleContractCall{
+				Sender: goodAddress,
+				Msg:    []byte("{}"),
+				Height: 1,
+				Time:   1,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgDepositRent(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgDepositRent
+		expErr bool
+	}{
+		"all good": {
+			src: MsgDepositRent{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Amount:   sdk.NewInt64Coin("denom", 1),
+			},
+		},
+		"bad sender": {
+			src: MsgDepositRent{
+				Sender:   "invalid",
+				Contract: anotherGoodAddress,
+				Amount:   sdk.NewInt64Coin("denom", 1),
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgDepositRent{
+				Sender:   goodAddress,
+				Contract: "invalid",
+				Amount:   sdk.NewInt64Coin("denom", 1),
+			},
+			expErr: true,
+		},
+		"zero amount": {
+			src: MsgDepositRent{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Amount:   sdk.NewInt64Coin("denom", 0),
+			},
+			expErr: true,
+		},
+		"empty amount": {
+			src: MsgDepositRent{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgUpdateOperator(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgUpdateOperator
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUpdateOperator{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Operator: goodAddress,
+				SudoMsgs: []string{"foo", "bar"},
+			},
+		},
+		"clear operator": {
+			src: MsgUpdateOperator{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+			},
+		},
+		"bad sender": {
+			src: MsgUpdateOperator{
+				Sender:   "invalid",
+				Contract: anotherGoodAddress,
+				Operator: goodAddress,
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgUpdateOperator{
+				Sender:   goodAddress,
+				Contract: "invalid",
+				Operator: goodAddress,
+			},
+			expErr: true,
+		},
+		"bad operator addr": {
+			src: MsgUpdateOperator{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Operator: "invalid",
+			},
+			expErr: true,
+		},
+		"sudo msgs without operator": {
+			src: MsgUpdateOperator{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				SudoMsgs: []string{"foo"},
+			},
+			expErr: true,
+		},
+		"empty sudo msg": {
+			src: MsgUpdateOperator{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Operator: goodAddress,
+				SudoMsgs: []string{""},
+			},
+			expErr: true,
+		},
+		"duplicate sudo msgs": {
+			src: MsgUpdateOperator{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Operator: goodAddress,
+				SudoMsgs: []string{"foo", "foo"},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgOperatorSudo(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgOperatorSudo
+		expErr bool
+	}{
+		"all good": {
+			src: MsgOperatorSudo{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Msg:      []byte(`{"foo":{}}`),
+			},
+		},
+		"bad sender": {
+			src: MsgOperatorSudo{
+				Sender:   "invalid",
+				Contract: anotherGoodAddress,
+				Msg:      []byte(`{"foo":{}}`),
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgOperatorSudo{
+				Sender:   goodAddress,
+				Contract: "invalid",
+				Msg:      []byte(`{"foo":{}}`),
+			},
+			expErr: true,
+		},
+		"non json msg": {
+			src: MsgOperatorSudo{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Msg:      []byte("invalid-json"),
+			},
+			expErr: true,
+		},
+		"empty msg": {
+			src: MsgOperatorSudo{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestMsgJsonSignBytes(t *testing.T) {
 	const myInnerMsg = `{"foo":"bar"}`
 	specs := map[string]struct {
@@ -524,6 +1207,22 @@ func TestMsgJsonSignBytes(t *testing.T) {
 {
 	"type":"wasm/MsgExecuteContract",
 	"value": {"msg": {"foo":"bar"}, "funds":[]}
+}`,
+		},
+		"MsgStoreAndInstantiateContract": {
+			src: &MsgStoreAndInstantiateContract{Msg: RawContractMessage(myInnerMsg)},
+			exp: `
+{
+	"type":"wasm/MsgStoreAndInstantiateContract",
+	"value": {"msg": {"foo":"bar"}, "funds":[]}
+}`,
+		},
+		"MsgStoreAndMigrateContract": {
+			src: &MsgStoreAndMigrateContract{Msg: RawContractMessage(myInnerMsg)},
+			exp: `
+{
+	"type":"wasm/MsgStoreAndMigrateContract",
+	"value": {"msg": {"foo":"bar"}}
 }`,
 		},
 		"MsgMigrateContract": {
@@ -532,6 +1231,14 @@ func TestMsgJsonSignBytes(t *testing.T) {
 {
 	"type":"wasm/MsgMigrateContract",
 	"value": {"msg": {"foo":"bar"}}
+}`,
+		},
+		"MsgScheduleContractCall": {
+			src: &MsgScheduleContractCall{Msg: RawContractMessage(myInnerMsg)},
+			exp: `
+{
+	"type":"wasm/MsgScheduleContractCall",
+	"value": {"msg": {"foo":"bar"}}
 }`,
 		},
 	}
@@ -3,6 +3,8 @@ package types
 import (
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -29,6 +31,89 @@ func (m Model) ValidateBasic() error {
 	return nil
 }
 
+func (c DeferredCall) ValidateBasic() error {
+	if c.ID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "id")
+	}
+	if _, err := sdk.AccAddressFromBech32(c.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if err := c.Msg.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "payload msg")
+	}
+	return validateDeferredCallDue(c.Height, c.Time)
+}
+
+func (c PendingAdminChange) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(c.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if _, err := sdk.AccAddressFromBech32(c.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(c.NewAdmin); err != nil {
+		return sdkerrors.Wrap(err, "new admin")
+	}
+	if c.Height == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "height")
+	}
+	return nil
+}
+
+func (m PendingMigration) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(m.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if _, err := sdk.AccAddressFromBech32(m.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if m.CodeID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "code id")
+	}
+	if err := m.Msg.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "payload msg")
+	}
+	if m.Height == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "height")
+	}
+	return nil
+}
+
+func (c PendingOperatorChange) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(c.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if _, err := sdk.AccAddressFromBech32(c.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if c.Operator == "" {
+		return sdkerrors.Wrap(ErrEmpty, "operator")
+	}
+	if err := validateOperator(c.Operator, c.SudoMsgs); err != nil {
+		return err
+	}
+	if c.Height == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "height")
+	}
+	return nil
+}
+
+func (c PendingOperatorSudo) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(c.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if _, err := sdk.AccAddressFromBech32(c.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if err := c.Msg.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "payload msg")
+	}
+	if c.Height == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "height")
+	}
+	return nil
+}
+
 func (c CodeInfo) ValidateBasic() error {
 	if len(c.CodeHash) == 0 {
 		return sdkerrors.Wrap(ErrEmpty, "code hash")
@@ -36,6 +121,12 @@ func (c CodeInfo) ValidateBasic() error {
 	if _, err := sdk.AccAddressFromBech32(c.Creator); err != nil {
 		return sdkerrors.Wrap(err, "creator")
 	}
+	if err := ValidateSource(c.Source); err != nil {
+		return sdkerrors.Wrap(err, "source")
+	}
+	if err := ValidateBuilder(c.Builder); err != nil {
+		return sdkerrors.Wrap(err, "builder")
+	}
 	if err := c.InstantiateConfig.ValidateBasic(); err != nil {
 		return sdkerrors.Wrap(err, "instantiate config")
 	}
@@ -88,9 +179,12 @@ func (c *ContractInfo) ValidateBasic() error {
 			return sdkerrors.Wrap(err, "admin")
 		}
 	}
-	if err := validateLabel(c.Label); err != nil {
+	if err := validateLabelLength(c.Label); err != nil {
 		return sdkerrors.Wrap(err, "label")
 	}
+	if err := validateOperator(c.Operator, c.OperatorSudoMsgs); err != nil {
+		return err
+	}
 	if c.Extension == nil {
 		return nil
 	}
@@ -170,6 +264,27 @@ func (c *ContractInfo) AddMigration(ctx sdk.Context, codeID uint64, msg []byte)
 	return h
 }
 
+// ValidateBasic does syntax checks on the data
+func (e ContractCodeHistoryEntry) ValidateBasic() error {
+	var found bool
+	for _, v := range AllCodeHistoryTypes {
+		if e.Operation == v {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return sdkerrors.Wrap(ErrInvalid, "operation")
+	}
+	if e.CodeID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "code id")
+	}
+	if e.Updated == nil {
+		return sdkerrors.Wrap(ErrEmpty, "updated")
+	}
+	return nil
+}
+
 // ResetFromGenesis resets contracts timestamp and history.
 func (c *ContractInfo) ResetFromGenesis(ctx sdk.Context) ContractCodeHistoryEntry {
 	c.Created = NewAbsoluteTxPosition(ctx)
@@ -192,6 +307,18 @@ func (c *ContractInfo) AdminAddr() sdk.AccAddress {
 	return admin
 }
 
+// OperatorAddr convert into sdk.AccAddress or nil when not set
+func (c *ContractInfo) OperatorAddr() sdk.AccAddress {
+	if c.Operator == "" {
+		return nil
+	}
+	operator, err := sdk.AccAddressFromBech32(c.Operator)
+	if err != nil { // should never happen
+		panic(err.Error())
+	}
+	return operator
+}
+
 // ContractInfoExtension defines the extension point for custom data to be stored with a contract info
 type ContractInfoExtension interface {
 	proto.Message
@@ -303,13 +430,26 @@ func NewWasmCoins(cosmosCoins sdk.Coins) (wasmCoins []wasmvmtypes.Coin) {
 type WasmConfig struct {
 	// SimulationGasLimit is the max gas to be used in a tx simulation call.
 	// When not set the consensus max block gas is used instead
-	SimulationGasLimit *uint64
-	// SimulationGasLimit is the max gas to be used in a smart query contract call
-	SmartQueryGasLimit uint64
+	SimulationGasLimit *uint64 `mapstructure:"simulation_gas_limit"`
+	// SmartQueryGasLimit is the max gas to be used in a smart query contract call
+	SmartQueryGasLimit uint64 `mapstructure:"query_gas_limit"`
 	// MemoryCacheSize in MiB not bytes
-	MemoryCacheSize uint32
-	// ContractDebugMode log what contract print
-	ContractDebugMode bool
+	MemoryCacheSize uint32 `mapstructure:"memory_cache_size"`
+	// ContractDebugMode log what contract print. The output of the contract debug calls goes to STDOUT
+	// and each VM call is logged with the contract address so that the output can be attributed.
+	// This must not be enabled on production nodes.
+	ContractDebugMode bool `mapstructure:"contract_debug_mode"`
+	// MetricsContracts are the bech32 contract addresses that are labeled individually in the VM call metrics.
+	// All other contracts share a single label.
+	MetricsContracts []string `mapstructure:"metrics_contracts"`
+	// SmartQueryDisabled rejects smart contract state queries on the gRPC and legacy query server of this node.
+	// Contract execution and queries between contracts are not affected.
+	SmartQueryDisabled bool `mapstructure:"smart_query_disabled"`
+	// SmartQueryRateLimit is the max number of smart queries per second that a single client can send to the gRPC
+	// query server of this node. Zero disables the limit. Only direct gRPC clients are limited, by their IP. Smart
+	// queries via the REST gateway, the legacy REST routes, the legacy querier or the Tendermint RPC are executed as
+	// ABCI queries without client address and are not limited. Use a reverse proxy to limit these.
+	SmartQueryRateLimit uint32 `mapstructure:"smart_query_rate_limit"`
 }
 
 // DefaultWasmConfig returns the default settings for WasmConfig
@@ -321,11 +461,68 @@ func DefaultWasmConfig() WasmConfig {
 	}
 }
 
+// DefaultConfigTemplate toml snippet with default values for app.toml
+func DefaultConfigTemplate() string {
+	return ConfigTemplate(DefaultWasmConfig())
+}
+
+// ConfigTemplate toml snippet for app.toml
+func ConfigTemplate(c WasmConfig) string {
+	simGasLimit := `# simulation_gas_limit =`
+	if c.SimulationGasLimit != nil {
+		simGasLimit = fmt.Sprintf(`simulation_gas_limit = %d`, *c.SimulationGasLimit)
+	}
+	metricsContracts := `# metrics_contracts = []`
+	if len(c.MetricsContracts) != 0 {
+		quoted := make([]string, len(c.MetricsContracts))
+		for i, v := range c.MetricsContracts {
+			quoted[i] = strconv.Quote(v)
+		}
+		metricsContracts = fmt.Sprintf(`metrics_contracts = [%s]`, strings.Join(quoted, ", "))
+	}
+
+	return fmt.Sprintf(`
+###############################################################################
+###                             Wasm Configuration                          ###
+###############################################################################
+
+[wasm]
+# Smart query gas limit is the max gas to be used in a smart query contract call
+query_gas_limit = %d
+
+# In-memory cache for compiled Wasm modules. Set to 0 to disable.
+# The value is in MiB not bytes
+memory_cache_size = %d
+
+# Simulation gas limit is the max gas to be used in a tx simulation call.
+# When not set the consensus max block gas is used instead
+%s
+
+# Print the output of contract debug calls to STDOUT and log each contract call with the contract address.
+# This slows down the node and must not be enabled in production.
+contract_debug_mode = %t
+
+# Contract addresses that are labeled individually in the VM call metrics.
+# All other contracts share the "other" label.
+%s
+
+# Reject smart contract state queries on the query server of this node, i.e. on public RPC nodes.
+# Contract execution and queries between contracts are not affected.
+smart_query_disabled = %t
+
+# Max number of smart queries per second that a single client can send to the gRPC query server.
+# Clients are identified by IP. Set to 0 to disable.
+# Only direct gRPC clients are limited. Queries via REST, the legacy querier or the Tendermint RPC have no
+# client address and are not limited by this setting.
+smart_query_rate_limit = %d
+`, c.SmartQueryGasLimit, c.MemoryCacheSize, simGasLimit, c.ContractDebugMode, metricsContracts, c.SmartQueryDisabled, c.SmartQueryRateLimit)
+}
+
 // VerifyAddressLen ensures that the address matches the expected length
 func VerifyAddressLen() func(addr []byte) error {
 	return func(addr []byte) error {
 		if len(addr) != ContractAddrLen && len(addr) != SDKAddrLen {
-			return sdkerrors.ErrInvalidAddress
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "length %d, expected %d or %d", len(addr), SDKAddrLen, ContractAddrLen)
 		}
 		return nil
 	}
@@ -13,6 +13,7 @@ import (
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/rand"
@@ -123,6 +124,51 @@ func TestCodeInfoValidateBasic(t *testing.T) {
 	}
 }
 
+func TestContractCodeHistoryEntryValidateBasic(t *testing.T) {
+	specs := map[string]struct {
+		srcMutator func(*ContractCodeHistoryEntry)
+		expError   bool
+	}{
+		"all good": {srcMutator: func(_ *ContractCodeHistoryEntry) {}},
+		"operation unspecified": {
+			srcMutator: func(e *ContractCodeHistoryEntry) { e.Operation = ContractCodeHistoryOperationTypeUnspecified },
+			expError:   true,
+		},
+		"operation unknown": {
+			srcMutator: func(e *ContractCodeHistoryEntry) { e.Operation = 99 },
+			expError:   true,
+		},
+		"code id empty": {
+			srcMutator: func(e *ContractCodeHistoryEntry) { e.CodeID = 0 },
+			expError:   true,
+		},
+		"updated empty": {
+			srcMutator: func(e *ContractCodeHistoryEntry) { e.Updated = nil },
+			expError:   true,
+		},
+		"msg empty": {
+			srcMutator: func(e *ContractCodeHistoryEntry) { e.Msg = nil },
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			entry := ContractCodeHistoryEntry{
+				Operation: ContractCodeHistoryOperationTypeMigrate,
+				CodeID:    1,
+				Updated:   &AbsoluteTxPosition{BlockHeight: 1, TxIndex: 2},
+				Msg:       []byte(`{}`),
+			}
+			spec.srcMutator(&entry)
+			got := entry.ValidateBasic()
+			if spec.expError {
+				require.Error(t, got)
+				return
+			}
+			require.NoError(t, got)
+		})
+	}
+}
+
 func TestContractInfoSetExtension(t *testing.T) {
 	anyTime := time.Now().UTC()
 	aNestedProtobufExt := func() ContractInfoExtension {
@@ -372,3 +418,38 @@ func TestVerifyAddressLen(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigTemplate(t *testing.T) {
+	simulationGasLimit := uint64(4)
+	specs := map[string]struct {
+		src WasmConfig
+	}{
+		"defaults": {
+			src: DefaultWasmConfig(),
+		},
+		"all set": {
+			src: WasmConfig{
+				SimulationGasLimit:  &simulationGasLimit,
+				SmartQueryGasLimit:  2,
+				MemoryCacheSize:     3,
+				ContractDebugMode:   true,
+				MetricsContracts:    []string{"cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr", "cosmos1nc5tatafv6eyq7llkr2gv50ff9e22mnf70qgjlv737ktmt4eswrqr5j2ht"},
+				SmartQueryDisabled:  true,
+				SmartQueryRateLimit: 5,
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			v := viper.New()
+			v.SetConfigType("toml")
+			require.NoError(t, v.ReadConfig(strings.NewReader(ConfigTemplate(spec.src))))
+
+			var got struct {
+				Wasm WasmConfig `mapstructure:"wasm"`
+			}
+			require.NoError(t, v.Unmarshal(&got))
+			assert.Equal(t, spec.src, got.Wasm)
+		})
+	}
+}
@@ -1,6 +1,13 @@
 package types
 
 import (
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
@@ -10,8 +17,62 @@ var (
 
 	// MaxWasmSize is the largest a compiled contract code can be when storing code on chain
 	MaxWasmSize = 800 * 1024 // extension point for chains to customize via compile flag.
+
+	// MaxWasmFunctions is the max number of imported and defined functions a wasm code can have when stored on chain
+	MaxWasmFunctions = 20_000 // extension point for chains to customize via compile flag.
+
+	// MaxContractMsgSize is the largest instantiate, execute or migrate message that can be sent to a contract.
+	// The max size param can only restrict this further.
+	MaxContractMsgSize = 1024 * 1024 // extension point for chains to customize via compile flag.
+
+	// MaxContractEvents is the max number of custom events a contract can emit in a single call
+	MaxContractEvents = 100 // extension point for chains to customize via compile flag.
+
+	// MaxContractEventAttributes is the max number of attributes a contract can emit in a single call,
+	// summed up over the wasm module event and all custom events
+	MaxContractEventAttributes = 256 // extension point for chains to customize via compile flag.
+
+	// MaxEventAttributeKeySize is the max length in bytes of an event attribute key or custom event type
+	MaxEventAttributeKeySize = 128 // extension point for chains to customize via compile flag.
+
+	// MaxEventAttributeValueSize is the max length in bytes of an event attribute value
+	MaxEventAttributeValueSize = 16 * 1024 // extension point for chains to customize via compile flag.
+
+	// MaxContractEventPayloadSize is the max length in bytes of all event types, attribute keys and values
+	// a contract can emit in a single call
+	MaxContractEventPayloadSize = 256 * 1024 // extension point for chains to customize via compile flag.
+
+	// MaxSourceURLSize is the max length in bytes of the source URL that can be stored with the code
+	MaxSourceURLSize = 512 // extension point for chains to customize via compile flag.
+
+	// MaxBuilderSize is the max length in bytes of the builder image that can be stored with the code
+	MaxBuilderSize = 128 // extension point for chains to customize via compile flag.
+
+	// MaxFundsCount is the max number of coins with distinct denoms that can be sent to a contract in a single message
+	MaxFundsCount = 32 // extension point for chains to customize via compile flag.
+)
+
+const (
+	// DefaultMaxContractMsgSize is the default value of the max contract message size param
+	DefaultMaxContractMsgSize uint64 = 1024 * 1024
+	// DefaultMaxContractResponseDataSize is the default value of the max contract response data size param
+	DefaultMaxContractResponseDataSize uint64 = 64 * 1024
+	// DefaultInstanceCost is the default value of the instance cost param.
+	// Benchmarks and numbers were discussed in: https://github.com/CosmWasm/wasmd/pull/634#issuecomment-938056803
+	DefaultInstanceCost uint64 = 60_000
+	// DefaultCompileCost is the default value of the per byte compile cost param.
+	// Benchmarks and numbers were discussed in: https://github.com/CosmWasm/wasmd/pull/634#issuecomment-938056803
+	DefaultCompileCost uint64 = 3
 )
 
+// validateDeferredCallDue ensures that exactly one of the block height or time of a deferred call is set
+func validateDeferredCallDue(height, time uint64) error {
+	if (height == 0) == (time == 0) {
+		return sdkerrors.Wrap(ErrInvalid, "either height or time must be set")
+	}
+	return nil
+}
+
 func validateWasmCode(s []byte) error {
 	if len(s) == 0 {
 		return sdkerrors.Wrap(ErrEmpty, "is required")
@@ -22,12 +83,163 @@ func validateWasmCode(s []byte) error {
 	return nil
 }
 
-func validateLabel(label string) error {
+// validateFunds ensures that the coins sent to a contract are sorted by denom, have positive amounts, contain every
+// denom only once and do not exceed MaxFundsCount entries. Empty funds are valid.
+func validateFunds(funds sdk.Coins) error {
+	if len(funds) > MaxFundsCount {
+		return sdkerrors.Wrapf(ErrLimit, "cannot contain more than %d coins", MaxFundsCount)
+	}
+	for i, c := range funds {
+		if err := sdk.ValidateDenom(c.Denom); err != nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+		}
+		if !c.Amount.IsPositive() {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "amount must be positive: %s", c)
+		}
+		if i == 0 {
+			continue
+		}
+		switch prev := funds[i-1].Denom; {
+		case c.Denom == prev:
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "duplicate denom: %s", c.Denom)
+		case c.Denom < prev:
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "denoms not sorted: %s after %s", c.Denom, prev)
+		}
+	}
+	return nil
+}
+
+// ValidateLabel ensures that the label for a new contract is not empty, has no leading or trailing whitespace,
+// contains printable characters only and is not longer than MaxLabelSize bytes.
+func ValidateLabel(label string) error {
+	if err := validateLabelLength(label); err != nil {
+		return err
+	}
+	if strings.TrimSpace(label) != label {
+		return sdkerrors.Wrap(ErrInvalid, "must not start or end with whitespace")
+	}
+	if !utf8.ValidString(label) {
+		return sdkerrors.Wrap(ErrInvalid, "must be valid utf-8")
+	}
+	for _, r := range label {
+		if !unicode.IsPrint(r) {
+			return sdkerrors.Wrap(ErrInvalid, "must contain printable characters only")
+		}
+	}
+	return nil
+}
+
+// validateLabelLength checks only the length of the label. It is used for contracts that exist already and
+// may have been created before the stricter rules of ValidateLabel applied.
+func validateLabelLength(label string) error {
 	if label == "" {
 		return sdkerrors.Wrap(ErrEmpty, "is required")
 	}
 	if len(label) > MaxLabelSize {
-		return sdkerrors.Wrap(ErrLimit, "cannot be longer than 128 characters")
+		return sdkerrors.Wrapf(ErrLimit, "cannot be longer than %d bytes", MaxLabelSize)
+	}
+	return nil
+}
+
+// validateAdminMode ensures that the admin mode is known and that no admin address is set together with a mode
+func validateAdminMode(admin string, mode InstantiateAdminMode) error {
+	switch mode {
+	case InstantiateAdminModeUnspecified:
+		return nil
+	case InstantiateAdminModeCreator, InstantiateAdminModeNone:
+		if admin != "" {
+			return sdkerrors.Wrap(ErrInvalid, "admin address not allowed with admin mode")
+		}
+		return nil
+	}
+	return sdkerrors.Wrapf(ErrInvalid, "unknown admin mode: %d", mode)
+}
+
+// validateOperator ensures that the operator is a valid address when set and that the allowed sudo messages are
+// unique and non empty. Sudo messages require an operator.
+func validateOperator(operator string, sudoMsgs []string) error {
+	if operator == "" {
+		if len(sudoMsgs) != 0 {
+			return sdkerrors.Wrap(ErrInvalid, "sudo msgs without operator")
+		}
+		return nil
+	}
+	if _, err := sdk.AccAddressFromBech32(operator); err != nil {
+		return sdkerrors.Wrap(err, "operator")
+	}
+	unique := make(map[string]struct{}, len(sudoMsgs))
+	for _, m := range sudoMsgs {
+		if strings.TrimSpace(m) == "" {
+			return sdkerrors.Wrap(ErrEmpty, "sudo msg")
+		}
+		if _, exists := unique[m]; exists {
+			return sdkerrors.Wrapf(ErrDuplicate, "sudo msg: %s", m)
+		}
+		unique[m] = struct{}{}
+	}
+	return nil
+}
+
+// capabilityRegexp matches a capability name like `mint` or `gov_hooks`
+var capabilityRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// ValidateCapability ensures that the capability name is a lower case identifier with at most 64 characters
+func ValidateCapability(capability string) error {
+	if !capabilityRegexp.MatchString(capability) {
+		return sdkerrors.Wrapf(ErrInvalid, "capability %q", capability)
+	}
+	return nil
+}
+
+// validateCapabilities ensures that all capability names are valid and unique
+func validateCapabilities(capabilities []string) error {
+	unique := make(map[string]struct{}, len(capabilities))
+	for _, c := range capabilities {
+		if err := ValidateCapability(c); err != nil {
+			return err
+		}
+		if _, exists := unique[c]; exists {
+			return sdkerrors.Wrapf(ErrDuplicate, "capability: %s", c)
+		}
+		unique[c] = struct{}{}
+	}
+	return nil
+}
+
+// builderRegexp matches a docker image reference with a mandatory tag, i.e. `cosmwasm/rust-optimizer:0.12.6`
+// or `ghcr.io/org/workspace-optimizer:0.12.6`.
+var builderRegexp = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*(?::[0-9]+)?(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)*:[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127}$`)
+
+// ValidateSource ensures that the optional source is an absolute https URL that is not longer than
+// MaxSourceURLSize bytes.
+func ValidateSource(source string) error {
+	if source == "" {
+		return nil
+	}
+	if len(source) > MaxSourceURLSize {
+		return sdkerrors.Wrapf(ErrLimit, "cannot be longer than %d bytes", MaxSourceURLSize)
+	}
+	u, err := url.ParseRequestURI(source)
+	if err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return sdkerrors.Wrap(ErrInvalid, "must be an absolute https url")
+	}
+	return nil
+}
+
+// ValidateBuilder ensures that the optional builder is a docker image name with tag that is not longer than
+// MaxBuilderSize bytes.
+func ValidateBuilder(builder string) error {
+	if builder == "" {
+		return nil
+	}
+	if len(builder) > MaxBuilderSize {
+		return sdkerrors.Wrapf(ErrLimit, "cannot be longer than %d bytes", MaxBuilderSize)
+	}
+	if !builderRegexp.MatchString(builder) {
+		return sdkerrors.Wrap(ErrInvalid, "must be a docker image name with tag")
 	}
 	return nil
 }
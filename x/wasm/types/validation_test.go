@@ -0,0 +1,247 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLabel(t *testing.T) {
+	specs := map[string]struct {
+		src    string
+		expErr error
+	}{
+		"simple": {
+			src: "my contract",
+		},
+		"unicode": {
+			src: "mein Vertrag ünd 契約",
+		},
+		"max length": {
+			src: strings.Repeat("a", MaxLabelSize),
+		},
+		"empty": {
+			src:    "",
+			expErr: ErrEmpty,
+		},
+		"too long": {
+			src:    strings.Repeat("a", MaxLabelSize+1),
+			expErr: ErrLimit,
+		},
+		"leading whitespace": {
+			src:    " foo",
+			expErr: ErrInvalid,
+		},
+		"trailing whitespace": {
+			src:    "foo\t",
+			expErr: ErrInvalid,
+		},
+		"whitespace only": {
+			src:    " ",
+			expErr: ErrInvalid,
+		},
+		"line break": {
+			src:    "foo\nbar",
+			expErr: ErrInvalid,
+		},
+		"control character": {
+			src:    "foo\x00bar",
+			expErr: ErrInvalid,
+		},
+		"invalid utf-8": {
+			src:    "foo\xffbar",
+			expErr: ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateLabel(spec.src)
+			if spec.expErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, spec.expErr)
+		})
+	}
+}
+
+func TestValidateSource(t *testing.T) {
+	specs := map[string]struct {
+		src    string
+		expErr error
+	}{
+		"empty": {
+			src: "",
+		},
+		"https url": {
+			src: "https://github.com/CosmWasm/cosmwasm/tree/v1.0.0/contracts/hackatom",
+		},
+		"with query": {
+			src: "https://example.com/code.tar.gz?rev=1",
+		},
+		"http url": {
+			src:    "http://github.com/CosmWasm/cosmwasm",
+			expErr: ErrInvalid,
+		},
+		"relative url": {
+			src:    "github.com/CosmWasm/cosmwasm",
+			expErr: ErrInvalid,
+		},
+		"no host": {
+			src:    "https:///cosmwasm",
+			expErr: ErrInvalid,
+		},
+		"too long": {
+			src:    "https://example.com/" + strings.Repeat("a", MaxSourceURLSize),
+			expErr: ErrLimit,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateSource(spec.src)
+			if spec.expErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, spec.expErr)
+		})
+	}
+}
+
+func TestValidateBuilder(t *testing.T) {
+	specs := map[string]struct {
+		src    string
+		expErr error
+	}{
+		"empty": {
+			src: "",
+		},
+		"image with tag": {
+			src: "cosmwasm/rust-optimizer:0.12.6",
+		},
+		"image with registry": {
+			src: "ghcr.io/cosmwasm/workspace-optimizer:0.12.6",
+		},
+		"image with registry port": {
+			src: "localhost:5000/optimizer:latest",
+		},
+		"without tag": {
+			src:    "cosmwasm/rust-optimizer",
+			expErr: ErrInvalid,
+		},
+		"empty tag": {
+			src:    "cosmwasm/rust-optimizer:",
+			expErr: ErrInvalid,
+		},
+		"upper case name": {
+			src:    "CosmWasm/rust-optimizer:0.12.6",
+			expErr: ErrInvalid,
+		},
+		"whitespace": {
+			src:    "cosmwasm/rust-optimizer :0.12.6",
+			expErr: ErrInvalid,
+		},
+		"too long": {
+			src:    strings.Repeat("a", MaxBuilderSize) + ":1",
+			expErr: ErrLimit,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateBuilder(spec.src)
+			if spec.expErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, spec.expErr)
+		})
+	}
+}
+
+func TestValidateCapability(t *testing.T) {
+	specs := map[string]struct {
+		src    string
+		expErr bool
+	}{
+		"simple":          {src: "mint"},
+		"with underscore": {src: "gov_hooks"},
+		"with digits":     {src: "v2_mint"},
+		"max length":      {src: strings.Repeat("a", 64)},
+		"empty":           {src: "", expErr: true},
+		"too long":        {src: strings.Repeat("a", 65), expErr: true},
+		"upper case":      {src: "Mint", expErr: true},
+		"leading digit":   {src: "2mint", expErr: true},
+		"whitespace":      {src: "mint ", expErr: true},
+		"dash":            {src: "gov-hooks", expErr: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCapability(spec.src)
+			if spec.expErr {
+				assert.ErrorIs(t, err, ErrInvalid)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateFunds(t *testing.T) {
+	maxFunds := make(sdk.Coins, MaxFundsCount)
+	for i := range maxFunds {
+		maxFunds[i] = sdk.NewInt64Coin(fmt.Sprintf("denom%03d", i), 1)
+	}
+	specs := map[string]struct {
+		src    sdk.Coins
+		expErr error
+	}{
+		"empty": {},
+		"single coin": {
+			src: sdk.Coins{sdk.NewInt64Coin("alx", 1)},
+		},
+		"sorted coins": {
+			src: sdk.Coins{sdk.NewInt64Coin("alx", 1), sdk.NewInt64Coin("blx", 2)},
+		},
+		"max count": {
+			src: maxFunds,
+		},
+		"exceeds max count": {
+			src:    append(maxFunds, sdk.NewInt64Coin("zzz", 1)),
+			expErr: ErrLimit,
+		},
+		"not sorted": {
+			src:    sdk.Coins{sdk.NewInt64Coin("blx", 1), sdk.NewInt64Coin("alx", 2)},
+			expErr: sdkerrors.ErrInvalidCoins,
+		},
+		"duplicate denom": {
+			src:    sdk.Coins{sdk.NewInt64Coin("alx", 1), sdk.NewInt64Coin("alx", 2)},
+			expErr: sdkerrors.ErrInvalidCoins,
+		},
+		"zero amount": {
+			src:    sdk.Coins{sdk.NewInt64Coin("alx", 0)},
+			expErr: sdkerrors.ErrInvalidCoins,
+		},
+		"negative amount": {
+			src:    sdk.Coins{sdk.Coin{Denom: "alx", Amount: sdk.NewInt(-1)}},
+			expErr: sdkerrors.ErrInvalidCoins,
+		},
+		"invalid denom": {
+			src:    sdk.Coins{sdk.Coin{Denom: "1", Amount: sdk.OneInt()}},
+			expErr: sdkerrors.ErrInvalidCoins,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			err := validateFunds(spec.src)
+			if spec.expErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, spec.expErr)
+		})
+	}
+}